@@ -22,6 +22,7 @@ const (
 // Command represents a command received from a smart home platform.
 type Command struct {
 	Action      Action
+	DeviceID    string // empty targets the adapter's default pod
 	Side        model.Side
 	Temperature *int // only for ActionSetTemp
 }
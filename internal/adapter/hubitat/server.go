@@ -23,15 +23,21 @@ var _ adapter.Adapter = (*Adapter)(nil)
 // Adapter implements the adapter.Adapter interface for Hubitat integration.
 type Adapter struct {
 	server       *http.Server
-	stateManager *state.Manager
+	pods         *state.Fleet
 	port         int
 	pollInterval time.Duration
 }
 
-// New creates a new Hubitat adapter.
+// New creates a new Hubitat adapter for a single pod.
 func New(stateManager *state.Manager, port int, pollInterval time.Duration) *Adapter {
+	return NewMulti([]*state.Manager{stateManager}, port, pollInterval)
+}
+
+// NewMulti creates a Hubitat adapter serving several pods. The unprefixed
+// routes act on the first pod; /devices/{device}/... selects one by ID or name.
+func NewMulti(managers []*state.Manager, port int, pollInterval time.Duration) *Adapter {
 	return &Adapter{
-		stateManager: stateManager,
+		pods:         state.NewFleet(managers...),
 		port:         port,
 		pollInterval: pollInterval,
 	}
@@ -39,22 +45,9 @@ func New(stateManager *state.Manager, port int, pollInterval time.Duration) *Ada
 
 // Start begins the HTTP server for Hubitat integration.
 func (a *Adapter) Start(ctx context.Context) error {
-	mux := http.NewServeMux()
-
-	// Register routes
-	mux.HandleFunc("/status", a.handleStatus)
-	mux.HandleFunc("/left/status", a.handleSideStatus(model.Left))
-	mux.HandleFunc("/right/status", a.handleSideStatus(model.Right))
-	mux.HandleFunc("/left/on", a.handleSideOn(model.Left))
-	mux.HandleFunc("/right/on", a.handleSideOn(model.Right))
-	mux.HandleFunc("/left/off", a.handleSideOff(model.Left))
-	mux.HandleFunc("/right/off", a.handleSideOff(model.Right))
-	mux.HandleFunc("/left/temperature", a.handleSideTemperature(model.Left))
-	mux.HandleFunc("/right/temperature", a.handleSideTemperature(model.Right))
-
 	a.server = &http.Server{
 		Addr:              fmt.Sprintf(":%d", a.port),
-		Handler:           mux,
+		Handler:           a.routes(),
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
@@ -78,18 +71,42 @@ func (a *Adapter) Start(ctx context.Context) error {
 	}
 }
 
+// routes builds the HTTP handler for every pod.
+func (a *Adapter) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	// Register routes; the bare paths target the default pod.
+	mux.HandleFunc("/devices", a.handleDevices)
+	for _, prefix := range []string{"", "/devices/{device}"} {
+		mux.HandleFunc(prefix+"/status", a.handleStatus)
+		mux.HandleFunc(prefix+"/left/status", a.handleSideStatus(model.Left))
+		mux.HandleFunc(prefix+"/right/status", a.handleSideStatus(model.Right))
+		mux.HandleFunc(prefix+"/left/on", a.handleSideOn(model.Left))
+		mux.HandleFunc(prefix+"/right/on", a.handleSideOn(model.Right))
+		mux.HandleFunc(prefix+"/left/off", a.handleSideOff(model.Left))
+		mux.HandleFunc(prefix+"/right/off", a.handleSideOff(model.Right))
+		mux.HandleFunc(prefix+"/left/temperature", a.handleSideTemperature(model.Left))
+		mux.HandleFunc(prefix+"/right/temperature", a.handleSideTemperature(model.Right))
+	}
+	return mux
+}
+
 // HandleCommand processes a command from the smart home platform.
 func (a *Adapter) HandleCommand(ctx context.Context, cmd adapter.Command) error {
+	mgr, ok := a.pods.Get(cmd.DeviceID)
+	if !ok {
+		return fmt.Errorf("unknown device: %s", cmd.DeviceID)
+	}
 	switch cmd.Action {
 	case adapter.ActionOn:
-		return a.stateManager.TurnOn(ctx, cmd.Side)
+		return mgr.TurnOn(ctx, cmd.Side)
 	case adapter.ActionOff:
-		return a.stateManager.TurnOff(ctx, cmd.Side)
+		return mgr.TurnOff(ctx, cmd.Side)
 	case adapter.ActionSetTemp:
 		if cmd.Temperature == nil {
 			return fmt.Errorf("temperature level required for set_temperature action")
 		}
-		return a.stateManager.SetTemperature(ctx, cmd.Side, *cmd.Temperature)
+		return mgr.SetTemperature(ctx, cmd.Side, *cmd.Temperature)
 	default:
 		return fmt.Errorf("unknown action: %s", cmd.Action)
 	}
@@ -121,6 +138,37 @@ type SideStatus struct {
 	BedTemperature float64 `json:"bed_temperature"`
 }
 
+// DeviceEntry describes one pod served by the adapter.
+type DeviceEntry struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// handleDevices lists the pods this server controls.
+func (a *Adapter) handleDevices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries := make([]DeviceEntry, 0, len(a.pods.Managers()))
+	for _, m := range a.pods.Managers() {
+		entries = append(entries, DeviceEntry{ID: m.DeviceID(), Name: m.Name()})
+	}
+	writeJSON(w, entries)
+}
+
+// podFor resolves the pod named by the request's {device} path segment,
+// writing a 404 when it is unknown.
+func (a *Adapter) podFor(w http.ResponseWriter, r *http.Request) (*state.Manager, bool) {
+	key := r.PathValue("device")
+	mgr, ok := a.pods.Get(key)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown device: %s", key), http.StatusNotFound)
+	}
+	return mgr, ok
+}
+
 // handleStatus returns the full device state as JSON.
 func (a *Adapter) handleStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -128,7 +176,12 @@ func (a *Adapter) handleStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	deviceState, err := a.stateManager.GetState(r.Context())
+	mgr, ok := a.podFor(w, r)
+	if !ok {
+		return
+	}
+
+	deviceState, err := mgr.GetState(r.Context())
 	if err != nil {
 		http.Error(w, fmt.Sprintf("failed to get state: %v", err), http.StatusInternalServerError)
 		return
@@ -165,7 +218,12 @@ func (a *Adapter) handleSideStatus(side model.Side) http.HandlerFunc {
 			return
 		}
 
-		deviceState, err := a.stateManager.GetState(r.Context())
+		mgr, ok := a.podFor(w, r)
+		if !ok {
+			return
+		}
+
+		deviceState, err := mgr.GetState(r.Context())
 		if err != nil {
 			http.Error(w, fmt.Sprintf("failed to get state: %v", err), http.StatusInternalServerError)
 			return
@@ -195,9 +253,15 @@ func (a *Adapter) handleSideOn(side model.Side) http.HandlerFunc {
 			return
 		}
 
+		mgr, ok := a.podFor(w, r)
+		if !ok {
+			return
+		}
+
 		cmd := adapter.Command{
-			Action: adapter.ActionOn,
-			Side:   side,
+			Action:   adapter.ActionOn,
+			DeviceID: mgr.DeviceID(),
+			Side:     side,
 		}
 
 		if err := a.HandleCommand(r.Context(), cmd); err != nil {
@@ -217,9 +281,15 @@ func (a *Adapter) handleSideOff(side model.Side) http.HandlerFunc {
 			return
 		}
 
+		mgr, ok := a.podFor(w, r)
+		if !ok {
+			return
+		}
+
 		cmd := adapter.Command{
-			Action: adapter.ActionOff,
-			Side:   side,
+			Action:   adapter.ActionOff,
+			DeviceID: mgr.DeviceID(),
+			Side:     side,
 		}
 
 		if err := a.HandleCommand(r.Context(), cmd); err != nil {
@@ -256,8 +326,14 @@ func (a *Adapter) handleSideTemperature(side model.Side) http.HandlerFunc {
 			return
 		}
 
+		mgr, ok := a.podFor(w, r)
+		if !ok {
+			return
+		}
+
 		cmd := adapter.Command{
 			Action:      adapter.ActionSetTemp,
+			DeviceID:    mgr.DeviceID(),
 			Side:        side,
 			Temperature: &level,
 		}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -434,3 +435,83 @@ func TestAdapter_Stop_NilServer(t *testing.T) {
 
 // Verify compile-time interface compliance
 var _ adapter.Adapter = (*Adapter)(nil)
+
+// setupTwoPodAdapter serves two pods, "bedroom" (device-123) and
+// "guest" (device-456), and records power-on calls by user ID.
+func setupTwoPodAdapter(t *testing.T, turnOnCalls *[]string) *Adapter {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/login" && r.Method == http.MethodPost:
+			json.NewEncoder(w).Encode(map[string]any{
+				"session": map[string]any{"token": "test-token", "expiresAt": "2099-01-01T00:00:00Z", "userId": "user-123"},
+			})
+		case r.URL.Path == "/devices/device-123":
+			json.NewEncoder(w).Encode(map[string]any{
+				"result": map[string]any{"id": "device-123", "leftUserId": "left-user", "rightUserId": "right-user"},
+			})
+		case r.URL.Path == "/devices/device-456":
+			json.NewEncoder(w).Encode(map[string]any{
+				"result": map[string]any{"id": "device-456", "leftUserId": "guest-left", "rightUserId": "guest-right"},
+			})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/temperature"):
+			json.NewEncoder(w).Encode(map[string]any{
+				"currentLevel": 0,
+				"currentState": map[string]any{"type": "off"},
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/devices/power"):
+			*turnOnCalls = append(*turnOnCalls, strings.Split(r.URL.Path, "/")[2])
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Logf("unhandled request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c := client.New("test@test.com", "pass", "", "", "")
+	c.BaseURL = srv.URL
+	c.UserID = "user-123"
+
+	return NewMulti([]*state.Manager{
+		state.NewManager(c, "device-123", state.WithName("Bedroom")),
+		state.NewManager(c, "device-456", state.WithName("Guest")),
+	}, 0, 60*time.Second)
+}
+
+func TestAdapter_Routes_MultiPod(t *testing.T) {
+	var turnOnCalls []string
+	a := setupTwoPodAdapter(t, &turnOnCalls)
+	h := a.routes()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/devices", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var devices []DeviceEntry
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &devices))
+	assert.Equal(t, []DeviceEntry{{ID: "device-123", Name: "Bedroom"}, {ID: "device-456", Name: "Guest"}}, devices)
+
+	for _, path := range []string{"/devices/guest/left/on", "/devices/device-123/right/on", "/left/on"} {
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+	assert.Equal(t, []string{"guest-left", "right-user", "left-user"}, turnOnCalls)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/devices/attic/left/on", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdapter_HandleCommand_DeviceID(t *testing.T) {
+	var turnOnCalls []string
+	a := setupTwoPodAdapter(t, &turnOnCalls)
+
+	err := a.HandleCommand(context.Background(), adapter.Command{DeviceID: "device-456", Action: adapter.ActionOn, Side: model.Right})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"guest-right"}, turnOnCalls)
+
+	err = a.HandleCommand(context.Background(), adapter.Command{DeviceID: "nope", Action: adapter.ActionOn, Side: model.Right})
+	assert.ErrorContains(t, err, "unknown device")
+}
@@ -4,6 +4,7 @@ package mqtt
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -21,8 +22,8 @@ import (
 type Config struct {
 	BrokerURL    string        // e.g., "tcp://localhost:1883"
 	TopicPrefix  string        // e.g., "homeassistant" for HA discovery
-	DeviceID     string        // Eight Sleep device ID
-	DeviceName   string        // Human-readable name like "Bedroom Pod"
+	DeviceID     string        // Eight Sleep device ID, used when a manager has none
+	DeviceName   string        // Human-readable name like "Bedroom Pod"; pods without their own name use it
 	PollInterval time.Duration // How often to poll state
 	ClientID     string        // MQTT client ID
 	Username     string        // Optional MQTT username
@@ -31,25 +32,92 @@ type Config struct {
 
 // Adapter implements the adapter.Adapter interface for MQTT/Home Assistant.
 type Adapter struct {
-	cfg          Config
-	stateManager *state.Manager
-	client       mqtt.Client
-	stopCh       chan struct{}
-	wg           sync.WaitGroup
+	cfg    Config
+	pods   *state.Fleet
+	client mqtt.Client
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex         // guards cfg.DeviceName and cfg.PollInterval
+	reset chan time.Duration // new poll intervals for pollLoop
 }
 
 // Compile-time check that Adapter implements adapter.Adapter.
 var _ adapter.Adapter = (*Adapter)(nil)
 
-// New creates a new MQTT adapter.
-func New(cfg Config, stateManager *state.Manager) *Adapter {
+// New creates a new MQTT adapter bridging one or more pods. Each pod gets
+// its own Home Assistant device and topic tree keyed by its device ID.
+func New(cfg Config, managers ...*state.Manager) *Adapter {
 	return &Adapter{
-		cfg:          cfg,
-		stateManager: stateManager,
-		stopCh:       make(chan struct{}),
+		cfg:    cfg,
+		pods:   state.NewFleet(managers...),
+		stopCh: make(chan struct{}),
+		reset:  make(chan time.Duration, 1),
 	}
 }
 
+// podID returns the device ID used in a pod's topics.
+func (a *Adapter) podID(m *state.Manager) string {
+	if id := m.DeviceID(); id != "" {
+		return id
+	}
+	return a.cfg.DeviceID
+}
+
+// pod finds a pod by the device ID in its topics, or by name. An empty
+// deviceID returns the default pod.
+func (a *Adapter) pod(deviceID string) (*state.Manager, bool) {
+	if m, ok := a.pods.Get(deviceID); ok {
+		return m, true
+	}
+	for _, m := range a.pods.Managers() {
+		if a.podID(m) == deviceID {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// podName returns the Home Assistant device name for a pod.
+func (a *Adapter) podName(m *state.Manager) string {
+	if name := m.Name(); name != "" {
+		return name
+	}
+	a.mu.Lock()
+	deviceName := a.cfg.DeviceName
+	a.mu.Unlock()
+	if len(a.pods.Managers()) > 1 {
+		// Keep unnamed pods apart in the HA device registry.
+		return fmt.Sprintf("%s %s", deviceName, a.podID(m))
+	}
+	return deviceName
+}
+
+// Reconfigure applies a new poll interval and default device name to a
+// running bridge and republishes discovery, so Home Assistant picks up pods
+// renamed here or through their managers. Zero values keep the current
+// settings.
+func (a *Adapter) Reconfigure(pollInterval time.Duration, deviceName string) error {
+	a.mu.Lock()
+	if deviceName != "" {
+		a.cfg.DeviceName = deviceName
+	}
+	if pollInterval > 0 && pollInterval != a.cfg.PollInterval {
+		a.cfg.PollInterval = pollInterval
+		select {
+		case <-a.reset: // replace a change pollLoop has not seen yet
+		default:
+		}
+		a.reset <- pollInterval
+	}
+	a.mu.Unlock()
+
+	if a.client == nil || !a.client.IsConnected() {
+		return nil // onConnect publishes discovery
+	}
+	return a.publishDiscovery()
+}
+
 // Start connects to the MQTT broker, publishes discovery configs, and starts polling.
 func (a *Adapter) Start(ctx context.Context) error {
 	// Configure MQTT client options
@@ -104,16 +172,20 @@ func (a *Adapter) Start(ctx context.Context) error {
 
 // HandleCommand processes a command from the smart home platform.
 func (a *Adapter) HandleCommand(ctx context.Context, cmd adapter.Command) error {
+	mgr, ok := a.pod(cmd.DeviceID)
+	if !ok {
+		return fmt.Errorf("unknown device: %s", cmd.DeviceID)
+	}
 	switch cmd.Action {
 	case adapter.ActionOn:
-		return a.stateManager.TurnOn(ctx, cmd.Side)
+		return mgr.TurnOn(ctx, cmd.Side)
 	case adapter.ActionOff:
-		return a.stateManager.TurnOff(ctx, cmd.Side)
+		return mgr.TurnOff(ctx, cmd.Side)
 	case adapter.ActionSetTemp:
 		if cmd.Temperature == nil {
 			return fmt.Errorf("temperature required for set_temperature action")
 		}
-		return a.stateManager.SetTemperature(ctx, cmd.Side, *cmd.Temperature)
+		return mgr.SetTemperature(ctx, cmd.Side, *cmd.Temperature)
 	default:
 		return fmt.Errorf("unknown action: %s", cmd.Action)
 	}
@@ -152,12 +224,22 @@ func (a *Adapter) onConnectionLost(_ mqtt.Client, _ error) {
 	// Auto-reconnect is enabled, so we just wait for reconnection
 }
 
-// publishDiscovery publishes Home Assistant MQTT discovery configs for both sides.
+// publishDiscovery publishes Home Assistant MQTT discovery configs for both sides of every pod.
 func (a *Adapter) publishDiscovery() error {
-	configs := GenerateDiscoveryConfigs(a.cfg.TopicPrefix, a.cfg.DeviceID, a.cfg.DeviceName)
+	for _, m := range a.pods.Managers() {
+		if err := a.publishPodDiscovery(a.podID(m), a.podName(m)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishPodDiscovery publishes the discovery configs for one pod.
+func (a *Adapter) publishPodDiscovery(deviceID, deviceName string) error {
+	configs := GenerateDiscoveryConfigs(a.cfg.TopicPrefix, deviceID, deviceName)
 
 	for side, config := range configs {
-		topic := DiscoveryTopic(a.cfg.TopicPrefix, a.cfg.DeviceID, side)
+		topic := DiscoveryTopic(a.cfg.TopicPrefix, deviceID, side)
 		payload, err := json.Marshal(config)
 		if err != nil {
 			return fmt.Errorf("failed to marshal discovery config for %s: %w", side, err)
@@ -173,11 +255,24 @@ func (a *Adapter) publishDiscovery() error {
 	return nil
 }
 
-// publishState fetches current state and publishes to state topics.
+// publishState fetches current state for every pod and publishes to state
+// topics. A pod that fails does not stop the others from publishing.
 func (a *Adapter) publishState(ctx context.Context) error {
-	deviceState, err := a.stateManager.GetState(ctx)
+	var errs []error
+	for _, m := range a.pods.Managers() {
+		if err := a.publishPodState(ctx, m); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// publishPodState fetches one pod's state and publishes it.
+func (a *Adapter) publishPodState(ctx context.Context, m *state.Manager) error {
+	deviceID := a.podID(m)
+	deviceState, err := m.GetState(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get device state: %w", err)
+		return fmt.Errorf("failed to get device state for %s: %w", deviceID, err)
 	}
 
 	// Publish state for each side
@@ -196,16 +291,16 @@ func (a *Adapter) publishState(ctx context.Context) error {
 		}
 
 		// Publish temperature level
-		tempTopic := fmt.Sprintf("eightsleep/%s/%s/temperature", a.cfg.DeviceID, s.name)
+		tempTopic := fmt.Sprintf("eightsleep/%s/%s/temperature", deviceID, s.name)
 		a.publish(tempTopic, strconv.Itoa(s.user.TargetLevel))
 
 		// Publish mode
-		modeTopic := fmt.Sprintf("eightsleep/%s/%s/mode", a.cfg.DeviceID, s.name)
+		modeTopic := fmt.Sprintf("eightsleep/%s/%s/mode", deviceID, s.name)
 		mode := a.powerStateToMode(s.user.State, s.user.TargetLevel)
 		a.publish(modeTopic, mode)
 
 		// Publish current bed temperature
-		currentTempTopic := fmt.Sprintf("eightsleep/%s/%s/current_temperature", a.cfg.DeviceID, s.name)
+		currentTempTopic := fmt.Sprintf("eightsleep/%s/%s/current_temperature", deviceID, s.name)
 		a.publish(currentTempTopic, fmt.Sprintf("%.1f", s.user.BedTemperature))
 	}
 
@@ -227,21 +322,24 @@ func (a *Adapter) powerStateToMode(state model.PowerState, level int) string {
 	}
 }
 
-// subscribeCommands subscribes to command topics for both sides.
+// subscribeCommands subscribes to command topics for both sides of every pod.
 func (a *Adapter) subscribeCommands() error {
 	sides := []string{"left", "right"}
 
-	for _, side := range sides {
-		// Subscribe to temperature commands
-		tempTopic := fmt.Sprintf("eightsleep/%s/%s/set_temperature", a.cfg.DeviceID, side)
-		if err := a.subscribe(tempTopic, a.handleTemperatureCommand(side)); err != nil {
-			return err
-		}
+	for _, m := range a.pods.Managers() {
+		deviceID := a.podID(m)
+		for _, side := range sides {
+			// Subscribe to temperature commands
+			tempTopic := fmt.Sprintf("eightsleep/%s/%s/set_temperature", deviceID, side)
+			if err := a.subscribe(tempTopic, a.handleTemperatureCommand(deviceID, side)); err != nil {
+				return err
+			}
 
-		// Subscribe to mode commands
-		modeTopic := fmt.Sprintf("eightsleep/%s/%s/set_mode", a.cfg.DeviceID, side)
-		if err := a.subscribe(modeTopic, a.handleModeCommand(side)); err != nil {
-			return err
+			// Subscribe to mode commands
+			modeTopic := fmt.Sprintf("eightsleep/%s/%s/set_mode", deviceID, side)
+			if err := a.subscribe(modeTopic, a.handleModeCommand(deviceID, side)); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -251,13 +349,16 @@ func (a *Adapter) subscribeCommands() error {
 // unsubscribeCommands unsubscribes from all command topics.
 func (a *Adapter) unsubscribeCommands() {
 	sides := []string{"left", "right"}
-	topics := make([]string, 0, 4)
-
-	for _, side := range sides {
-		topics = append(topics,
-			fmt.Sprintf("eightsleep/%s/%s/set_temperature", a.cfg.DeviceID, side),
-			fmt.Sprintf("eightsleep/%s/%s/set_mode", a.cfg.DeviceID, side),
-		)
+	topics := make([]string, 0, 4*len(a.pods.Managers()))
+
+	for _, m := range a.pods.Managers() {
+		deviceID := a.podID(m)
+		for _, side := range sides {
+			topics = append(topics,
+				fmt.Sprintf("eightsleep/%s/%s/set_temperature", deviceID, side),
+				fmt.Sprintf("eightsleep/%s/%s/set_mode", deviceID, side),
+			)
+		}
 	}
 
 	token := a.client.Unsubscribe(topics...)
@@ -265,7 +366,7 @@ func (a *Adapter) unsubscribeCommands() {
 }
 
 // handleTemperatureCommand returns a handler for temperature set commands.
-func (a *Adapter) handleTemperatureCommand(sideName string) mqtt.MessageHandler {
+func (a *Adapter) handleTemperatureCommand(deviceID, sideName string) mqtt.MessageHandler {
 	return func(_ mqtt.Client, msg mqtt.Message) {
 		level, err := strconv.Atoi(strings.TrimSpace(string(msg.Payload())))
 		if err != nil {
@@ -279,6 +380,7 @@ func (a *Adapter) handleTemperatureCommand(sideName string) mqtt.MessageHandler
 
 		cmd := adapter.Command{
 			Action:      adapter.ActionSetTemp,
+			DeviceID:    deviceID,
 			Side:        side,
 			Temperature: &level,
 		}
@@ -293,14 +395,16 @@ func (a *Adapter) handleTemperatureCommand(sideName string) mqtt.MessageHandler
 		}
 
 		// Publish updated state
-		if err := a.publishState(ctx); err != nil {
-			log.Printf("[mqtt] error publishing state after temperature command: %v", err)
+		if m, ok := a.pod(deviceID); ok {
+			if err := a.publishPodState(ctx, m); err != nil {
+				log.Printf("[mqtt] error publishing state after temperature command: %v", err)
+			}
 		}
 	}
 }
 
 // handleModeCommand returns a handler for mode set commands.
-func (a *Adapter) handleModeCommand(sideName string) mqtt.MessageHandler {
+func (a *Adapter) handleModeCommand(deviceID, sideName string) mqtt.MessageHandler {
 	return func(_ mqtt.Client, msg mqtt.Message) {
 		mode := strings.TrimSpace(strings.ToLower(string(msg.Payload())))
 
@@ -309,8 +413,7 @@ func (a *Adapter) handleModeCommand(sideName string) mqtt.MessageHandler {
 			return
 		}
 
-		var cmd adapter.Command
-		cmd.Side = side
+		cmd := adapter.Command{DeviceID: deviceID, Side: side}
 
 		switch mode {
 		case "off":
@@ -331,8 +434,10 @@ func (a *Adapter) handleModeCommand(sideName string) mqtt.MessageHandler {
 		}
 
 		// Publish updated state
-		if err := a.publishState(ctx); err != nil {
-			log.Printf("[mqtt] error publishing state after mode command: %v", err)
+		if m, ok := a.pod(deviceID); ok {
+			if err := a.publishPodState(ctx, m); err != nil {
+				log.Printf("[mqtt] error publishing state after mode command: %v", err)
+			}
 		}
 	}
 }
@@ -350,17 +455,21 @@ func (a *Adapter) publish(topic, payload string) {
 	token.Wait()
 }
 
-// publishAvailability publishes the availability status.
+// publishAvailability publishes the availability status for every pod.
 func (a *Adapter) publishAvailability(status string) {
-	topic := fmt.Sprintf("eightsleep/%s/availability", a.cfg.DeviceID)
-	a.publish(topic, status)
+	for _, m := range a.pods.Managers() {
+		topic := fmt.Sprintf("eightsleep/%s/availability", a.podID(m))
+		a.publish(topic, status)
+	}
 }
 
 // pollLoop polls the state manager and publishes updates.
 func (a *Adapter) pollLoop(ctx context.Context) {
 	defer a.wg.Done()
 
+	a.mu.Lock()
 	ticker := time.NewTicker(a.cfg.PollInterval)
+	a.mu.Unlock()
 	defer ticker.Stop()
 
 	for {
@@ -369,9 +478,13 @@ func (a *Adapter) pollLoop(ctx context.Context) {
 			return
 		case <-ctx.Done():
 			return
+		case d := <-a.reset:
+			ticker.Reset(d)
 		case <-ticker.C:
-			// Invalidate cache to get fresh state
-			a.stateManager.InvalidateCache()
+			// Invalidate caches to get fresh state
+			for _, m := range a.pods.Managers() {
+				m.InvalidateCache()
+			}
 
 			pollCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 			if err := a.publishState(pollCtx); err != nil {
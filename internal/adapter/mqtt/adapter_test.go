@@ -280,3 +280,55 @@ func TestConfig_OptionalFields(t *testing.T) {
 
 // Verify compile-time interface compliance
 var _ adapter.Adapter = (*Adapter)(nil)
+
+func TestAdapter_HandleCommand_DeviceID(t *testing.T) {
+	var turnOnCalls []string
+	base, srv := setupTestAdapter(t, nil, &turnOnCalls, nil)
+	defer srv.Close()
+
+	// The guest pod is listed first, so it is the default; commands
+	// addressed to device-123 must still reach the bedroom pod.
+	guest := state.NewManager(client.New("test@test.com", "pass", "", "", ""), "device-456", state.WithName("Guest"))
+	a := New(base.cfg, guest, base.pods.Default())
+
+	err := a.HandleCommand(context.Background(), adapter.Command{DeviceID: "device-123", Action: adapter.ActionOn, Side: model.Left})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"left-user"}, turnOnCalls)
+
+	err = a.HandleCommand(context.Background(), adapter.Command{DeviceID: "device-789", Action: adapter.ActionOn, Side: model.Left})
+	assert.ErrorContains(t, err, "unknown device")
+}
+
+func TestAdapter_podName(t *testing.T) {
+	c := client.New("test@test.com", "pass", "", "", "")
+	cfg := Config{DeviceID: "device-123", DeviceName: "Eight Sleep Pod"}
+	named := state.NewManager(c, "device-123", state.WithName("Bedroom"))
+	unnamed := state.NewManager(c, "device-456")
+
+	assert.Equal(t, "Eight Sleep Pod", New(cfg, unnamed).podName(unnamed))
+
+	a := New(cfg, named, unnamed)
+	assert.Equal(t, "Bedroom", a.podName(named))
+	assert.Equal(t, "Eight Sleep Pod device-456", a.podName(unnamed))
+}
+
+func TestAdapter_Reconfigure(t *testing.T) {
+	c := client.New("test@test.com", "pass", "", "", "")
+	m := state.NewManager(c, "device-123")
+	a := New(Config{DeviceName: "Eight Sleep Pod", PollInterval: 30 * time.Second}, m)
+
+	require.NoError(t, a.Reconfigure(time.Minute, "Bedroom Pod"))
+	assert.Equal(t, "Bedroom Pod", a.podName(m))
+	assert.Equal(t, time.Minute, <-a.reset)
+
+	// Zero values and an unchanged interval leave things alone.
+	require.NoError(t, a.Reconfigure(0, ""))
+	require.NoError(t, a.Reconfigure(time.Minute, ""))
+	assert.Equal(t, "Bedroom Pod", a.podName(m))
+	assert.Empty(t, a.reset)
+
+	// Only the latest pending interval reaches the poll loop.
+	require.NoError(t, a.Reconfigure(2*time.Minute, ""))
+	require.NoError(t, a.Reconfigure(3*time.Minute, ""))
+	assert.Equal(t, 3*time.Minute, <-a.reset)
+}
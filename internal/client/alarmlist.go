@@ -48,11 +48,11 @@ func (c *Client) ListAlarms(ctx context.Context) ([]Alarm, error) {
 	if err := c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/v2/users/%s/alarms", c.UserID)
+	path := fmt.Sprintf("/users/%s/alarms", c.userID())
 	var res struct {
 		Alarms []Alarm `json:"alarms"`
 	}
-	if err := c.doAppAPI(ctx, http.MethodGet, path, nil, nil, &res); err != nil {
+	if err := c.doHost(ctx, hostAppAPIV2, http.MethodGet, path, nil, nil, &res); err != nil {
 		return nil, err
 	}
 	return res.Alarms, nil
@@ -62,7 +62,7 @@ func (c *Client) CreateAlarm(ctx context.Context, alarm Alarm) (*Alarm, error) {
 	if err := c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/alarms", c.UserID)
+	path := fmt.Sprintf("/users/%s/alarms", c.userID())
 	var res struct {
 		Alarm Alarm `json:"alarm"`
 	}
@@ -76,7 +76,7 @@ func (c *Client) UpdateAlarm(ctx context.Context, alarmID string, patch map[stri
 	if err := c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/alarms/%s", c.UserID, alarmID)
+	path := fmt.Sprintf("/users/%s/alarms/%s", c.userID(), alarmID)
 	var res struct {
 		Alarm Alarm `json:"alarm"`
 	}
@@ -90,7 +90,7 @@ func (c *Client) DeleteAlarm(ctx context.Context, alarmID string) error {
 	if err := c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/alarms/%s", c.UserID, alarmID)
+	path := fmt.Sprintf("/users/%s/alarms/%s", c.userID(), alarmID)
 	return c.doAppAPI(ctx, http.MethodDelete, path, nil, nil, nil)
 }
 
@@ -99,6 +99,6 @@ func (c *Client) ListAlarmsV1(ctx context.Context, out any) error {
 	if err := c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/alarms", c.UserID)
+	path := fmt.Sprintf("/users/%s/alarms", c.userID())
 	return c.doAppAPI(ctx, http.MethodGet, path, nil, nil, out)
 }
@@ -22,7 +22,7 @@ func TestListAlarms(t *testing.T) {
 	defer srv.Close()
 
 	c := New("email", "pass", "uid-123", "", "")
-	c.AppAPIBaseURL = srv.URL
+	c.AppAPIBaseURL = srv.URL + "/v1"
 	c.token = "t"
 	c.tokenExp = time.Now().Add(time.Hour)
 	c.HTTP = srv.Client()
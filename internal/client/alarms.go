@@ -25,7 +25,7 @@ func (a *AlarmActions) SnoozeWithDuration(ctx context.Context, alarmID string, m
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/alarms/%s/snooze", a.c.UserID, alarmID)
+	path := fmt.Sprintf("/users/%s/alarms/%s/snooze", a.c.userID(), alarmID)
 	body := map[string]any{"snoozeMinutes": minutes}
 	return a.c.doAppAPI(ctx, http.MethodPut, path, nil, body, nil)
 }
@@ -36,7 +36,7 @@ func (a *AlarmActions) Dismiss(ctx context.Context, alarmID string) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/alarms/%s/dismiss", a.c.UserID, alarmID)
+	path := fmt.Sprintf("/users/%s/alarms/%s/dismiss", a.c.userID(), alarmID)
 	return a.c.doAppAPI(ctx, http.MethodPut, path, nil, map[string]any{}, nil)
 }
 
@@ -46,7 +46,7 @@ func (a *AlarmActions) DismissAll(ctx context.Context) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/alarms/active/dismiss-all", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/alarms/active/dismiss-all", a.c.userID())
 	return a.c.doAppAPI(ctx, http.MethodPut, path, nil, map[string]any{}, nil)
 }
 
@@ -54,6 +54,6 @@ func (a *AlarmActions) VibrationTest(ctx context.Context) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/vibration-test", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/vibration-test", a.c.userID())
 	return a.c.doAppAPI(ctx, http.MethodPost, path, nil, map[string]any{}, nil)
 }
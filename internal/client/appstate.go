@@ -19,7 +19,7 @@ func (a *AppStateActions) MessagesState(ctx context.Context, out any) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/app-state/messages", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/app-state/messages", a.c.userID())
 	return a.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -28,7 +28,7 @@ func (a *AppStateActions) UpdateMessagesState(ctx context.Context, body map[stri
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/app-state/messages", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/app-state/messages", a.c.userID())
 	return a.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
@@ -37,6 +37,6 @@ func (a *AppStateActions) PatchMessagesState(ctx context.Context, body map[strin
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/app-state/messages", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/app-state/messages", a.c.userID())
 	return a.c.do(ctx, http.MethodPatch, path, nil, body, nil)
 }
@@ -14,7 +14,7 @@ func (a *AudioActions) Tracks(ctx context.Context) ([]AudioTrack, error) {
 	if err := a.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/audio/tracks", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/tracks", a.c.userID())
 	var res struct {
 		Tracks []AudioTrack `json:"tracks"`
 	}
@@ -24,28 +24,44 @@ func (a *AudioActions) Tracks(ctx context.Context) ([]AudioTrack, error) {
 	return res.Tracks, nil
 }
 
-func (a *AudioActions) Categories(ctx context.Context) (any, error) {
-	path := "/audio/categories"
-	var res any
-	err := a.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+// AudioCategory groups tracks in the sound library.
+type AudioCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
-func (a *AudioActions) PlayerState(ctx context.Context) (any, error) {
+// AudioCategories lists the sound library's categories.
+type AudioCategories struct {
+	Categories []AudioCategory `json:"categories"`
+	RawResponse
+}
+
+func (a *AudioActions) Categories(ctx context.Context) (*AudioCategories, error) {
+	return fetchModel[AudioCategories](ctx, a.c, hostClientV1, http.MethodGet, "/audio/categories", nil)
+}
+
+// AudioPlayerState is the pod speaker's playback state.
+type AudioPlayerState struct {
+	State    string `json:"state"`
+	TrackID  string `json:"trackId"`
+	Volume   int    `json:"volume"`
+	Position int    `json:"position"`
+	RawResponse
+}
+
+func (a *AudioActions) PlayerState(ctx context.Context) (*AudioPlayerState, error) {
 	if err := a.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player/state", a.c.UserID)
-	var res any
-	err := a.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/audio/player/state", a.c.userID())
+	return fetchModel[AudioPlayerState](ctx, a.c, hostClientV1, http.MethodGet, path, nil)
 }
 
 func (a *AudioActions) Play(ctx context.Context, trackID string) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/player", a.c.userID())
 	body := map[string]any{"action": "play"}
 	if trackID != "" {
 		body["trackId"] = trackID
@@ -57,7 +73,7 @@ func (a *AudioActions) Pause(ctx context.Context) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/player", a.c.userID())
 	body := map[string]any{"action": "pause"}
 	return a.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -66,7 +82,7 @@ func (a *AudioActions) Seek(ctx context.Context, positionMs int) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player/seek", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/player/seek", a.c.userID())
 	body := map[string]any{"position": positionMs}
 	return a.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -75,7 +91,7 @@ func (a *AudioActions) Volume(ctx context.Context, level int) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player/volume", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/player/volume", a.c.userID())
 	body := map[string]any{"level": level}
 	return a.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -89,31 +105,40 @@ func (a *AudioActions) Pair(ctx context.Context) error {
 	return a.c.do(ctx, http.MethodPost, path, nil, map[string]any{}, nil)
 }
 
-func (a *AudioActions) RecommendedNext(ctx context.Context) (any, error) {
+// AudioRecommendation is the track suggested to play next.
+type AudioRecommendation struct {
+	TrackID string `json:"trackId"`
+	Title   string `json:"title"`
+	RawResponse
+}
+
+func (a *AudioActions) RecommendedNext(ctx context.Context) (*AudioRecommendation, error) {
 	if err := a.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/audio/tracks/recommended-next-track", a.c.UserID)
-	var res any
-	err := a.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/audio/tracks/recommended-next-track", a.c.userID())
+	return fetchModel[AudioRecommendation](ctx, a.c, hostClientV1, http.MethodGet, path, nil)
+}
+
+// AudioFavorites lists the IDs of the user's favorite tracks.
+type AudioFavorites struct {
+	Favorites []string `json:"favorites"`
+	RawResponse
 }
 
-func (a *AudioActions) Favorites(ctx context.Context) (any, error) {
+func (a *AudioActions) Favorites(ctx context.Context) (*AudioFavorites, error) {
 	if err := a.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/audio/tracks/favorites", a.c.UserID)
-	var res any
-	err := a.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/audio/tracks/favorites", a.c.userID())
+	return fetchModel[AudioFavorites](ctx, a.c, hostClientV1, http.MethodGet, path, nil)
 }
 
 func (a *AudioActions) AddFavorite(ctx context.Context, trackID string) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/tracks/favorites", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/tracks/favorites", a.c.userID())
 	body := map[string]any{"trackId": trackID}
 	return a.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -122,7 +147,7 @@ func (a *AudioActions) RemoveFavorite(ctx context.Context, trackID string) error
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/tracks/favorites/%s", a.c.UserID, trackID)
+	path := fmt.Sprintf("/users/%s/audio/tracks/favorites/%s", a.c.userID(), trackID)
 	return a.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
 }
 
@@ -130,7 +155,7 @@ func (a *AudioActions) Player(ctx context.Context, out any) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/player", a.c.userID())
 	return a.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -156,7 +181,7 @@ func (a *AudioActions) UpdatePlayer(ctx context.Context, body map[string]any) er
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/player", a.c.userID())
 	return a.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
@@ -164,7 +189,7 @@ func (a *AudioActions) SetPlaybackState(ctx context.Context, state string) error
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player/state", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/player/state", a.c.userID())
 	body := map[string]any{"state": state}
 	return a.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
@@ -173,6 +198,6 @@ func (a *AudioActions) PreviewTrack(ctx context.Context, body map[string]any) er
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/audio/player/preview-track", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/audio/player/preview-track", a.c.userID())
 	return a.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
@@ -37,7 +37,7 @@ func TestAudioActions_Categories(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/audio/categories", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"categories":["nature","ambient"]}`))
+		w.Write([]byte(`{"categories":[{"id":"nature","name":"Nature"},{"id":"ambient","name":"Ambient"}]}`))
 	})
 	srv := httptest.NewServer(mux)
 	defer srv.Close()
@@ -52,8 +52,8 @@ func TestAudioActions_Categories(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Categories error: %v", err)
 	}
-	if res == nil {
-		t.Error("expected non-nil response")
+	if len(res.Categories) != 2 || res.Categories[1].Name != "Ambient" {
+		t.Errorf("unexpected categories %+v", res.Categories)
 	}
 }
 
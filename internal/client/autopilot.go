@@ -10,41 +10,70 @@ type AutopilotActions struct{ c *Client }
 
 func (c *Client) Autopilot() *AutopilotActions { return &AutopilotActions{c: c} }
 
-func (a *AutopilotActions) Details(ctx context.Context) (any, error) {
+// AutopilotDetails is the user's Autopilot configuration and current state.
+type AutopilotDetails struct {
+	Enabled           bool   `json:"enabled"`
+	Mode              string `json:"mode"`
+	SnoringMitigation struct {
+		Enabled bool `json:"enabled"`
+	} `json:"snoringMitigation"`
+	RawResponse
+}
+
+// AutopilotAdjustment is one temperature change Autopilot made overnight.
+type AutopilotAdjustment struct {
+	Timestamp string `json:"timestamp"`
+	Stage     string `json:"stage"`
+	FromLevel int    `json:"fromLevel"`
+	ToLevel   int    `json:"toLevel"`
+	Reason    string `json:"reason"`
+}
+
+// AutopilotHistory lists recent Autopilot adjustments.
+type AutopilotHistory struct {
+	History []AutopilotAdjustment `json:"history"`
+	RawResponse
+}
+
+// AutopilotRecap summarizes what Autopilot did during the last night.
+type AutopilotRecap struct {
+	Recap struct {
+		Day         string                `json:"day"`
+		Summary     string                `json:"summary"`
+		Adjustments []AutopilotAdjustment `json:"adjustments"`
+	} `json:"recap"`
+	RawResponse
+}
+
+func (a *AutopilotActions) Details(ctx context.Context) (*AutopilotDetails, error) {
 	if err := a.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/autopilotDetails", a.c.UserID)
-	var res any
-	err := a.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/autopilotDetails", a.c.userID())
+	return fetchModel[AutopilotDetails](ctx, a.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (a *AutopilotActions) History(ctx context.Context) (any, error) {
+func (a *AutopilotActions) History(ctx context.Context) (*AutopilotHistory, error) {
 	if err := a.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/autopilot-history", a.c.UserID)
-	var res any
-	err := a.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/autopilot-history", a.c.userID())
+	return fetchModel[AutopilotHistory](ctx, a.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (a *AutopilotActions) Recap(ctx context.Context) (any, error) {
+func (a *AutopilotActions) Recap(ctx context.Context) (*AutopilotRecap, error) {
 	if err := a.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/autopilotDetails/autopilotRecap", a.c.UserID)
-	var res any
-	err := a.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/autopilotDetails/autopilotRecap", a.c.userID())
+	return fetchModel[AutopilotRecap](ctx, a.c, hostClientV1, http.MethodGet, path, nil)
 }
 
 func (a *AutopilotActions) GetLevelSuggestions(ctx context.Context, out any) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/level-suggestions-mode", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/level-suggestions-mode", a.c.userID())
 	return a.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -52,7 +81,7 @@ func (a *AutopilotActions) SetLevelSuggestions(ctx context.Context, enabled bool
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/level-suggestions-mode", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/level-suggestions-mode", a.c.userID())
 	body := map[string]any{"enabled": enabled}
 	return a.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
@@ -63,7 +92,7 @@ func (a *AutopilotActions) SetSnoreMitigation(ctx context.Context, enabled bool)
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/autopilotDetails/snoringMitigation", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/autopilotDetails/snoringMitigation", a.c.userID())
 	body := map[string]any{"enabled": enabled}
 	return a.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
@@ -22,7 +22,7 @@ func (a *AwayModeActions) Get(ctx context.Context) (*AwayModeStatus, error) {
 	if err := a.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/away-mode", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/away-mode", a.c.userID())
 	var res AwayModeStatus
 	if err := a.c.doAppAPI(ctx, http.MethodGet, path, nil, nil, &res); err != nil {
 		return nil, err
@@ -35,7 +35,7 @@ func (a *AwayModeActions) Set(ctx context.Context, enabled bool) error {
 	if err := a.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/away-mode", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/away-mode", a.c.userID())
 	body := map[string]any{"enabled": enabled}
 	return a.c.doAppAPI(ctx, http.MethodPut, path, nil, body, nil)
 }
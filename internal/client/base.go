@@ -10,14 +10,23 @@ type BaseActions struct{ c *Client }
 
 func (c *Client) Base() *BaseActions { return &BaseActions{c: c} }
 
-func (b *BaseActions) Info(ctx context.Context) (any, error) {
+// BaseInfo is the adjustable base's current position and hardware.
+type BaseInfo struct {
+	Model      string `json:"model"`
+	Firmware   string `json:"firmware"`
+	TorsoAngle int    `json:"torsoAngle"`
+	LegAngle   int    `json:"legAngle"`
+	Preset     string `json:"preset"`
+	InMotion   bool   `json:"inMotion"`
+	RawResponse
+}
+
+func (b *BaseActions) Info(ctx context.Context) (*BaseInfo, error) {
 	if err := b.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/base", b.c.UserID)
-	var res any
-	err := b.c.doAppAPI(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/base", b.c.userID())
+	return fetchModel[BaseInfo](ctx, b.c, hostAppAPI, http.MethodGet, path, nil)
 }
 
 // SetAngle sets the adjustable base angles.
@@ -27,7 +36,7 @@ func (b *BaseActions) SetAngle(ctx context.Context, torsoAngle, legAngle int) er
 	if err := b.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/base/angle", b.c.UserID)
+	path := fmt.Sprintf("/users/%s/base/angle", b.c.userID())
 	body := map[string]any{"torsoAngle": torsoAngle, "legAngle": legAngle}
 	return b.c.doAppAPI(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -37,7 +46,7 @@ func (b *BaseActions) SetAngleWithSnoreMitigation(ctx context.Context, torsoAngl
 	if err := b.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/base/angle", b.c.UserID)
+	path := fmt.Sprintf("/users/%s/base/angle", b.c.userID())
 	body := map[string]any{"torsoAngle": torsoAngle, "legAngle": legAngle, "snoreMitigation": true}
 	return b.c.doAppAPI(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -47,27 +56,38 @@ func (b *BaseActions) StopMovement(ctx context.Context) error {
 	if err := b.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/base/angle", b.c.UserID)
+	path := fmt.Sprintf("/users/%s/base/angle", b.c.userID())
 	return b.c.doAppAPI(ctx, http.MethodDelete, path, nil, nil, nil)
 }
 
+// BasePreset is a named base position.
+type BasePreset struct {
+	Name       string `json:"name"`
+	TorsoAngle int    `json:"torsoAngle"`
+	LegAngle   int    `json:"legAngle"`
+}
+
+// BasePresets lists the positions the base can move to by name.
+type BasePresets struct {
+	Presets []BasePreset `json:"presets"`
+	RawResponse
+}
+
 // Presets retrieves available base presets.
 // Uses v2 endpoint per APK.
-func (b *BaseActions) Presets(ctx context.Context) (any, error) {
+func (b *BaseActions) Presets(ctx context.Context) (*BasePresets, error) {
 	if err := b.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/v2/users/%s/base/presets", b.c.UserID)
-	var res any
-	err := b.c.doAppAPI(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/v2/users/%s/base/presets", b.c.userID())
+	return fetchModel[BasePresets](ctx, b.c, hostAppAPI, http.MethodGet, path, nil)
 }
 
 func (b *BaseActions) RunPreset(ctx context.Context, name string) error {
 	if err := b.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/base/presets", b.c.UserID)
+	path := fmt.Sprintf("/users/%s/base/presets", b.c.userID())
 	body := map[string]any{"name": name}
 	return b.c.doAppAPI(ctx, http.MethodPost, path, nil, body, nil)
 }
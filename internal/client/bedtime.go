@@ -34,7 +34,7 @@ func (b *BedtimeActions) Get(ctx context.Context) (*BedtimeSchedule, error) {
 	if err := b.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/temperature", b.c.UserID)
+	path := fmt.Sprintf("/users/%s/temperature", b.c.userID())
 	var res struct {
 		Bedtime BedtimeSchedule `json:"bedtime"`
 	}
@@ -49,7 +49,7 @@ func (b *BedtimeActions) Set(ctx context.Context, schedule *BedtimeSchedule) err
 	if err := b.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/bedtime", b.c.UserID)
+	path := fmt.Sprintf("/users/%s/bedtime", b.c.userID())
 	return b.c.doAppAPI(ctx, http.MethodPut, path, nil, schedule, nil)
 }
 
@@ -58,7 +58,7 @@ func (b *BedtimeActions) Enable(ctx context.Context) error {
 	if err := b.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/bedtime", b.c.UserID)
+	path := fmt.Sprintf("/users/%s/bedtime", b.c.userID())
 	body := map[string]any{"enabled": true}
 	return b.c.doAppAPI(ctx, http.MethodPut, path, nil, body, nil)
 }
@@ -68,7 +68,7 @@ func (b *BedtimeActions) Disable(ctx context.Context) error {
 	if err := b.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/bedtime", b.c.UserID)
+	path := fmt.Sprintf("/users/%s/bedtime", b.c.userID())
 	body := map[string]any{"enabled": false}
 	return b.c.doAppAPI(ctx, http.MethodPut, path, nil, body, nil)
 }
@@ -25,6 +25,6 @@ func (a *ChallengesActions) Challenges(ctx context.Context, state string, out an
 		q = url.Values{}
 		q.Set("state", state)
 	}
-	path := fmt.Sprintf("/users/%s/challenges", a.c.UserID)
+	path := fmt.Sprintf("/users/%s/challenges", a.c.userID())
 	return a.c.do(ctx, http.MethodGet, path, q, nil, out)
 }
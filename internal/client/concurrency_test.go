@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const hammer = 50
+
+// runConcurrently calls fn from n goroutines released at the same moment.
+func runConcurrently(t *testing.T, n int, fn func() error) {
+	t.Helper()
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs <- fn()
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent call failed: %v", err)
+		}
+	}
+}
+
+func TestConcurrent401_SingleReauth(t *testing.T) {
+	useTempKeyring(t)
+
+	var logins atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		logins.Add(1)
+		time.Sleep(20 * time.Millisecond) // widen the window for duplicate sign-ins
+		w.Write([]byte(`{"access_token":"fresh","expires_in":3600,"userId":"uid"}`))
+	})
+	mux.HandleFunc("/v1/ping", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "uid", "", "")
+	c.BaseURL = srv.URL + "/v1"
+	c.AuthURL = srv.URL + "/v1/tokens"
+	c.HTTP = srv.Client()
+	c.token = "stale"
+	c.tokenExp = time.Now().Add(time.Hour)
+
+	runConcurrently(t, hammer, func() error {
+		return c.do(context.Background(), http.MethodGet, "/ping", nil, nil, nil)
+	})
+	if n := logins.Load(); n != 1 {
+		t.Fatalf("expected exactly one re-authentication, got %d", n)
+	}
+}
+
+func TestConcurrentFirstUse_SingleAuthAndLookup(t *testing.T) {
+	useTempKeyring(t)
+
+	var logins, lookups atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		logins.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"access_token":"tok","expires_in":3600}`))
+	})
+	mux.HandleFunc("/v1/users/me", func(w http.ResponseWriter, r *http.Request) {
+		lookups.Add(1)
+		w.Write([]byte(`{"user":{"userId":"uid","currentDevice":{"id":"dev"}}}`))
+	})
+	mux.HandleFunc("/v1/users/uid/temperature", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"currentLevel":5,"currentState":{"type":"smart"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "", "", "")
+	c.BaseURL = srv.URL + "/v1"
+	c.AuthURL = srv.URL + "/v1/tokens"
+	c.HTTP = srv.Client()
+
+	ctx := context.Background()
+	var i atomic.Int32
+	runConcurrently(t, hammer, func() error {
+		switch i.Add(1) % 3 {
+		case 0:
+			_, err := c.EnsureDeviceID(ctx)
+			return err
+		case 1:
+			return c.EnsureUserID(ctx)
+		default:
+			_, err := c.GetStatus(ctx)
+			return err
+		}
+	})
+
+	if n := logins.Load(); n != 1 {
+		t.Errorf("expected one sign-in, got %d", n)
+	}
+	// One lookup for the user ID and one for the device ID.
+	if n := lookups.Load(); n != 2 {
+		t.Errorf("expected two /users/me lookups, got %d", n)
+	}
+	if c.UserID != "uid" || c.DeviceID != "dev" {
+		t.Errorf("unexpected ids %q %q", c.UserID, c.DeviceID)
+	}
+}
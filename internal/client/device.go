@@ -7,89 +7,180 @@ import (
 	"net/url"
 )
 
-type DeviceActions struct{ c *Client }
+type DeviceActions struct {
+	c  *Client
+	id string
+}
 
+// Device returns actions for the client's selected pod: DeviceID when set,
+// otherwise the account's current device.
 func (c *Client) Device() *DeviceActions { return &DeviceActions{c: c} }
 
-func (d *DeviceActions) Info(ctx context.Context) (any, error) {
-	id, err := d.c.EnsureDeviceID(ctx)
+// DeviceByID returns actions for a specific pod. An empty id behaves like Device.
+func (c *Client) DeviceByID(id string) *DeviceActions { return &DeviceActions{c: c, id: id} }
+
+// deviceID returns the pod these actions target.
+func (d *DeviceActions) deviceID(ctx context.Context) (string, error) {
+	if d.id != "" {
+		return d.id, nil
+	}
+	return d.c.EnsureDeviceID(ctx)
+}
+
+// DeviceInfo is the pod's device record.
+type DeviceInfo struct {
+	ID                string        `json:"id"`
+	OwnerID           string        `json:"ownerId"`
+	LeftUserID        string        `json:"leftUserId"`
+	RightUserID       string        `json:"rightUserId"`
+	Model             string        `json:"modelString"`
+	FirmwareVersion   string        `json:"firmwareVersion"`
+	Timezone          string        `json:"timezone"`
+	Online            bool          `json:"online"`
+	LastHeard         string        `json:"lastHeard"`
+	RoomTemperature   float64       `json:"roomTemperature"`
+	WaterLevel        int           `json:"waterLevel"`
+	HasWater          bool          `json:"hasWater"`
+	NeedsPriming      bool          `json:"needsPriming"`
+	Priming           DevicePriming `json:"priming"`
+	LeftHeatingLevel  int           `json:"leftHeatingLevel"`
+	RightHeatingLevel int           `json:"rightHeatingLevel"`
+	Features          []string      `json:"features"`
+	RawResponse
+}
+
+// DevicePriming is the priming state embedded in the device record.
+type DevicePriming struct {
+	Status string `json:"status"`
+}
+
+// Peripheral is an accessory paired with the pod, such as a base or hub.
+type Peripheral struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Side string `json:"side"`
+}
+
+// DevicePeripherals lists the pod's paired accessories.
+type DevicePeripherals struct {
+	Peripherals []Peripheral `json:"peripherals"`
+	RawResponse
+}
+
+// DeviceOwner identifies the account that owns the pod.
+type DeviceOwner struct {
+	OwnerID string `json:"ownerId"`
+	Owner   struct {
+		UserID string `json:"userId"`
+		Email  string `json:"email"`
+	} `json:"owner"`
+	RawResponse
+}
+
+// DeviceWarranty is the pod's warranty coverage.
+type DeviceWarranty struct {
+	Warranty struct {
+		Status  string `json:"status"`
+		Expires string `json:"expires"`
+	} `json:"warranty"`
+	RawResponse
+}
+
+// DeviceOnline reports when the pod last checked in.
+type DeviceOnline struct {
+	Online    bool   `json:"online"`
+	LastHeard string `json:"lastHeard"`
+	RawResponse
+}
+
+// PrimingTask is a queued or running priming cycle.
+type PrimingTask struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+// PrimingTasks lists the pod's priming tasks.
+type PrimingTasks struct {
+	Tasks []PrimingTask `json:"tasks"`
+	RawResponse
+}
+
+// PrimingSchedule is the pod's automatic daily priming setting.
+type PrimingSchedule struct {
+	Schedule struct {
+		Enabled bool   `json:"enabled"`
+		Time    string `json:"time"`
+	} `json:"schedule"`
+	RawResponse
+}
+
+func (d *DeviceActions) Info(ctx context.Context) (*DeviceInfo, error) {
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("/devices/%s", id)
-	var res any
-	err = d.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	return fetchResult[DeviceInfo](ctx, d.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (d *DeviceActions) Peripherals(ctx context.Context) (any, error) {
-	id, err := d.c.EnsureDeviceID(ctx)
+func (d *DeviceActions) Peripherals(ctx context.Context) (*DevicePeripherals, error) {
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("/devices/%s/peripherals", id)
-	var res any
-	err = d.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	return fetchResult[DevicePeripherals](ctx, d.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (d *DeviceActions) Owner(ctx context.Context) (any, error) {
-	id, err := d.c.EnsureDeviceID(ctx)
+func (d *DeviceActions) Owner(ctx context.Context) (*DeviceOwner, error) {
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("/devices/%s/owner", id)
-	var res any
-	err = d.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	return fetchResult[DeviceOwner](ctx, d.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (d *DeviceActions) Warranty(ctx context.Context) (any, error) {
-	id, err := d.c.EnsureDeviceID(ctx)
+func (d *DeviceActions) Warranty(ctx context.Context) (*DeviceWarranty, error) {
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("/devices/%s/warranty", id)
-	var res any
-	err = d.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	return fetchResult[DeviceWarranty](ctx, d.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (d *DeviceActions) Online(ctx context.Context) (any, error) {
-	id, err := d.c.EnsureDeviceID(ctx)
+func (d *DeviceActions) Online(ctx context.Context) (*DeviceOnline, error) {
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("/devices/%s/online", id)
-	var res any
-	err = d.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	return fetchResult[DeviceOnline](ctx, d.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (d *DeviceActions) PrimingTasks(ctx context.Context) (any, error) {
-	id, err := d.c.EnsureDeviceID(ctx)
+func (d *DeviceActions) PrimingTasks(ctx context.Context) (*PrimingTasks, error) {
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("/devices/%s/priming/tasks", id)
-	var res any
-	err = d.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	return fetchResult[PrimingTasks](ctx, d.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (d *DeviceActions) PrimingSchedule(ctx context.Context) (any, error) {
-	id, err := d.c.EnsureDeviceID(ctx)
+func (d *DeviceActions) PrimingSchedule(ctx context.Context) (*PrimingSchedule, error) {
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	path := fmt.Sprintf("/devices/%s/priming/schedule", id)
-	var res any
-	err = d.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	return fetchResult[PrimingSchedule](ctx, d.c, hostClientV1, http.MethodGet, path, nil)
 }
 
 func (d *DeviceActions) Update(ctx context.Context, body map[string]any) error {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return err
 	}
@@ -98,7 +189,7 @@ func (d *DeviceActions) Update(ctx context.Context, body map[string]any) error {
 }
 
 func (d *DeviceActions) SetOwner(ctx context.Context, body map[string]any) error {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return err
 	}
@@ -107,7 +198,7 @@ func (d *DeviceActions) SetOwner(ctx context.Context, body map[string]any) error
 }
 
 func (d *DeviceActions) SetPeripherals(ctx context.Context, body map[string]any) error {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return err
 	}
@@ -116,7 +207,7 @@ func (d *DeviceActions) SetPeripherals(ctx context.Context, body map[string]any)
 }
 
 func (d *DeviceActions) AddPeripheral(ctx context.Context, body map[string]any) error {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return err
 	}
@@ -125,7 +216,7 @@ func (d *DeviceActions) AddPeripheral(ctx context.Context, body map[string]any)
 }
 
 func (d *DeviceActions) GetBLEKey(ctx context.Context, out any) error {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return err
 	}
@@ -134,7 +225,7 @@ func (d *DeviceActions) GetBLEKey(ctx context.Context, out any) error {
 }
 
 func (d *DeviceActions) UpdatePrimingSchedule(ctx context.Context, body map[string]any) error {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return err
 	}
@@ -143,7 +234,7 @@ func (d *DeviceActions) UpdatePrimingSchedule(ctx context.Context, body map[stri
 }
 
 func (d *DeviceActions) CreatePrimingTask(ctx context.Context, body map[string]any) error {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return err
 	}
@@ -152,7 +243,7 @@ func (d *DeviceActions) CreatePrimingTask(ctx context.Context, body map[string]a
 }
 
 func (d *DeviceActions) CancelPrimingTask(ctx context.Context) error {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return err
 	}
@@ -173,7 +264,7 @@ type DeviceWithUsers struct {
 
 // GetWithUsers fetches device info with left/right user assignments.
 func (d *DeviceActions) GetWithUsers(ctx context.Context) (*DeviceWithUsers, error) {
-	id, err := d.c.EnsureDeviceID(ctx)
+	id, err := d.deviceID(ctx)
 	if err != nil {
 		return nil, err
 	}
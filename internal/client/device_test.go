@@ -18,7 +18,7 @@ func TestDeviceActions_Info(t *testing.T) {
 	})
 	mux.HandleFunc("/devices/dev-456", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"id":"dev-456","model":"Pod 3"}`))
+		w.Write([]byte(`{"result":{"id":"dev-456","modelString":"Pod 3","online":true,"priming":{"status":"idle"}}}`))
 	})
 	srv := httptest.NewServer(mux)
 	defer srv.Close()
@@ -33,8 +33,36 @@ func TestDeviceActions_Info(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Info error: %v", err)
 	}
-	if res == nil {
-		t.Error("expected non-nil response")
+	if res.ID != "dev-456" || res.Model != "Pod 3" || !res.Online || res.Priming.Status != "idle" {
+		t.Errorf("unexpected device info %+v", res)
+	}
+}
+
+func TestDeviceActions_InfoToleratesFieldTypeDrift(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"user":{"userId":"uid-123","currentDevice":{"id":"dev-456"}}}`))
+	})
+	mux.HandleFunc("/devices/dev-456", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"result":{"id":"dev-456","waterLevel":87.5,"online":true}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "", "", "")
+	c.BaseURL = srv.URL
+	c.token = "t"
+	c.tokenExp = time.Now().Add(time.Hour)
+	c.HTTP = srv.Client()
+
+	res, err := c.Device().Info(context.Background())
+	if err != nil {
+		t.Fatalf("Info error: %v", err)
+	}
+	if res.ID != "dev-456" || !res.Online {
+		t.Errorf("fields around the mismatched one were not decoded: %+v", res)
 	}
 }
 
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/charmbracelet/log"
+)
+
+// DeviceRef identifies a pod the account can control.
+type DeviceRef struct {
+	ID      string
+	Name    string // household name, when the household endpoint reports one
+	Current bool   // the account's currentDevice
+}
+
+// ListDevices enumerates the pods visible to the account: the device list on
+// /users/me plus any household devices. The current device comes first.
+// Household lookups are best effort because that endpoint is not available
+// to every account.
+func (c *Client) ListDevices(ctx context.Context) ([]DeviceRef, error) {
+	var me struct {
+		User struct {
+			UserID        string   `json:"userId"`
+			Devices       []string `json:"devices"`
+			CurrentDevice struct {
+				ID string `json:"id"`
+			} `json:"currentDevice"`
+		} `json:"user"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/users/me", nil, nil, &me); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.UserID == "" {
+		c.UserID = me.User.UserID
+	}
+	c.mu.Unlock()
+
+	var refs []DeviceRef
+	index := map[string]int{}
+	add := func(id, name string) {
+		if id == "" {
+			return
+		}
+		if i, ok := index[id]; ok {
+			if refs[i].Name == "" {
+				refs[i].Name = name
+			}
+			return
+		}
+		index[id] = len(refs)
+		refs = append(refs, DeviceRef{ID: id, Name: name, Current: id == me.User.CurrentDevice.ID})
+	}
+
+	add(me.User.CurrentDevice.ID, "")
+	for _, id := range me.User.Devices {
+		add(id, "")
+	}
+	if household, err := c.Household().Devices(ctx); err == nil {
+		for _, d := range household.Devices {
+			add(d.ID, d.Name)
+		}
+	} else {
+		log.Debug("household devices unavailable", "error", err)
+	}
+	return refs, nil
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestListDevices(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"user":{"userId":"uid-123","devices":["dev-1","dev-2"],"currentDevice":{"id":"dev-2"}}}`))
+	})
+	mux.HandleFunc("/household/users/uid-123/devices", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"devices":[{"deviceId":"dev-1","name":"Guest room"},{"deviceId":"dev-3","name":"Cabin"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "", "", "")
+	c.BaseURL = srv.URL
+	c.token = "t"
+	c.tokenExp = time.Now().Add(time.Hour)
+	c.HTTP = srv.Client()
+
+	got, err := c.ListDevices(context.Background())
+	if err != nil {
+		t.Fatalf("ListDevices error: %v", err)
+	}
+	want := []DeviceRef{
+		{ID: "dev-2", Current: true},
+		{ID: "dev-1", Name: "Guest room"},
+		{ID: "dev-3", Name: "Cabin"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListDevices = %+v, want %+v", got, want)
+	}
+	if c.UserID != "uid-123" {
+		t.Errorf("expected user ID to be filled, got %q", c.UserID)
+	}
+}
+
+func TestListDevices_HouseholdUnavailable(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"user":{"userId":"uid-123","devices":["dev-1"],"currentDevice":{"id":"dev-1"}}}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "", "", "")
+	c.BaseURL = srv.URL
+	c.token = "t"
+	c.tokenExp = time.Now().Add(time.Hour)
+	c.HTTP = srv.Client()
+	c.Retry.MaxAttempts = 1
+
+	got, err := c.ListDevices(context.Background())
+	if err != nil {
+		t.Fatalf("ListDevices error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "dev-1" || !got[0].Current {
+		t.Errorf("unexpected devices %+v", got)
+	}
+}
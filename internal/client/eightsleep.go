@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/log"
@@ -34,17 +35,73 @@ type Client struct {
 	ClientID     string
 	ClientSecret string
 	DeviceID     string
+	Profile      string // config profile; keeps its cached token separate
 
 	HTTP          *http.Client
 	BaseURL       string
-	AppAPIBaseURL string // For testing; defaults to appAPIBaseURL constant
-	token         string
-	tokenExp      time.Time
+	AppAPIBaseURL string  // For testing; defaults to appAPIBaseURL constant
+	AuthURL       string  // For testing; defaults to authURL constant
+	Limiter       Limiter // Optional; throttles every request when set
+	Retry         RetryPolicy
+	middleware    []Middleware
+	pipelines     map[bool]*http.Client // built pipelines by authenticated; guarded by pipeMu
+	pipeMu        sync.Mutex
+	offline       bool // replaying fixtures; never read or write the token cache
+	passwordFunc  func(context.Context) (string, error)
+
+	// mu guards token, tokenExp, UserID, and DeviceID once the client is
+	// shared between goroutines.
+	mu           sync.Mutex
+	token        string
+	tokenExp     time.Time
+	refreshToken string // issued by the OAuth endpoint; empty after legacy login
+	// authMu lets exactly one goroutine sign in at a time; the others wait
+	// and reuse its token.
+	authMu sync.Mutex
+	// lookupMu serializes the /users/me lookups behind EnsureUserID and
+	// EnsureDeviceID.
+	lookupMu sync.Mutex
 }
 
-// New creates a Client.
+// Option configures a Client at construction.
+type Option func(*Client)
+
+// WithRecord writes every exchange, including authentication, to redacted
+// fixture files in dir.
+func WithRecord(dir string) Option {
+	return func(c *Client) {
+		c.Use(Record(dir))
+	}
+}
+
+// WithTrace writes every exchange, including authentication, to a redacted
+// HAR 1.2 file at path.
+func WithTrace(path string) Option {
+	return func(c *Client) {
+		c.Use(Trace(path))
+	}
+}
+
+// WithReplay serves every request from fixtures in dir instead of the
+// network. Authentication is skipped and the token cache is left untouched.
+func WithReplay(dir string) Option {
+	return func(c *Client) {
+		c.HTTP.Transport = NewReplayTransport(dir)
+		c.offline = true
+	}
+}
 
-func New(email, password, userID, clientID, clientSecret string) *Client {
+// WithPasswordFunc supplies the password when Password is empty, e.g. from
+// a password manager. fn runs only when the client has to sign in, at most
+// once per Client.
+func WithPasswordFunc(fn func(context.Context) (string, error)) Option {
+	return func(c *Client) {
+		c.passwordFunc = fn
+	}
+}
+
+// New creates a Client.
+func New(email, password, userID, clientID, clientSecret string, opts ...Option) *Client {
 	if clientID == "" {
 		clientID = defaultClientID
 	}
@@ -57,7 +114,7 @@ func New(email, password, userID, clientID, clientSecret string) *Client {
 		// Disable HTTP/2; Eight Sleep frontends sometimes hang on H2 with Go.
 		TLSNextProto: map[string]func(string, *tls.Conn) http.RoundTripper{},
 	}
-	return &Client{
+	c := &Client{
 		Email:        email,
 		Password:     password,
 		UserID:       userID,
@@ -65,20 +122,51 @@ func New(email, password, userID, clientID, clientSecret string) *Client {
 		ClientSecret: clientSecret,
 		HTTP:         &http.Client{Timeout: 20 * time.Second, Transport: tr},
 		BaseURL:      defaultBaseURL,
+		Retry:        DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Authenticate fetches bearer token. Tries OAuth token endpoint first; falls back to /login used by app.
 func (c *Client) Authenticate(ctx context.Context) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.authenticate(ctx)
+}
+
+// authenticate signs in. Callers must hold authMu.
+func (c *Client) authenticate(ctx context.Context) error {
+	if err := c.resolvePassword(ctx); err != nil {
+		return err
+	}
 	if err := c.authTokenEndpoint(ctx); err == nil {
 		return nil
 	}
 	return c.authLegacyLogin(ctx)
 }
 
+// resolvePassword fills an empty Password from passwordFunc. Callers must
+// hold authMu, which also guards Password once the client is shared.
+func (c *Client) resolvePassword(ctx context.Context) error {
+	if c.Password != "" || c.passwordFunc == nil {
+		return nil
+	}
+	pw, err := c.passwordFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("read password: %w", err)
+	}
+	c.Password = pw
+	return nil
+}
+
 // EnsureUserID populates UserID by calling /users/me if missing.
 func (c *Client) EnsureUserID(ctx context.Context) error {
-	if c.UserID != "" {
+	c.lookupMu.Lock()
+	defer c.lookupMu.Unlock()
+	if c.userID() != "" {
 		return nil
 	}
 	var res struct {
@@ -92,14 +180,21 @@ func (c *Client) EnsureUserID(ctx context.Context) error {
 	if res.User.UserID == "" {
 		return errors.New("userId not found")
 	}
+	c.mu.Lock()
 	c.UserID = res.User.UserID
+	c.mu.Unlock()
 	return nil
 }
 
 // EnsureDeviceID fetches current device id if not already set.
 func (c *Client) EnsureDeviceID(ctx context.Context) (string, error) {
-	if c.DeviceID != "" {
-		return c.DeviceID, nil
+	c.lookupMu.Lock()
+	defer c.lookupMu.Unlock()
+	c.mu.Lock()
+	id := c.DeviceID
+	c.mu.Unlock()
+	if id != "" {
+		return id, nil
 	}
 	var res struct {
 		User struct {
@@ -114,40 +209,61 @@ func (c *Client) EnsureDeviceID(ctx context.Context) (string, error) {
 	if res.User.CurrentDevice.ID == "" {
 		return "", errors.New("no current device id")
 	}
+	c.mu.Lock()
 	c.DeviceID = res.User.CurrentDevice.ID
-	return c.DeviceID, nil
+	c.mu.Unlock()
+	return res.User.CurrentDevice.ID, nil
 }
 
 func (c *Client) authTokenEndpoint(ctx context.Context) error {
-	payload := map[string]string{
+	return c.tokenGrant(ctx, map[string]string{
 		"client_id":     c.ClientID,
 		"client_secret": c.ClientSecret,
 		"grant_type":    "password",
 		"username":      c.Email,
 		"password":      c.Password,
-	}
+	})
+}
+
+// authRefreshToken exchanges a refresh token for a new access token.
+func (c *Client) authRefreshToken(ctx context.Context, refreshToken string) error {
+	return c.tokenGrant(ctx, map[string]string{
+		"client_id":     c.ClientID,
+		"client_secret": c.ClientSecret,
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+	})
+}
+
+// tokenGrant posts an OAuth grant to the token endpoint and stores the result.
+func (c *Client) tokenGrant(ctx context.Context, payload map[string]string) error {
 	body, _ := json.Marshal(payload)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL, bytes.NewReader(body))
+	tokenURL := c.AuthURL
+	if tokenURL == "" {
+		tokenURL = authURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.httpClient(false).Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode >= 300 {
-		b, _ := io.ReadAll(resp.Body)
-		log.Debug("token auth failed", "status", resp.Status, "headers", resp.Header, "body", string(b))
-		return fmt.Errorf("token auth failed: %s", resp.Status)
+		apiErr := newAPIError(req, resp, req.URL.Path)
+		log.Debug("token auth failed", "status", resp.Status, "headers", RedactHeaders(resp.Header), "body", string(RedactBody(apiErr.Body)))
+		return fmt.Errorf("token auth failed: %w", apiErr)
 	}
 
 	var res struct {
-		AccessToken string `json:"access_token"`
-		ExpiresIn   int    `json:"expires_in"`
-		UserID      string `json:"userId"`
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		UserID       string `json:"userId"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
 		return err
@@ -155,27 +271,23 @@ func (c *Client) authTokenEndpoint(ctx context.Context) error {
 	if res.AccessToken == "" {
 		return errors.New("empty access token")
 	}
-	c.token = res.AccessToken
 	if res.ExpiresIn == 0 {
 		res.ExpiresIn = 3600
 	}
-	c.tokenExp = time.Now().Add(time.Duration(res.ExpiresIn-60) * time.Second)
-	if c.UserID == "" {
-		c.UserID = res.UserID
-	}
-	if err := tokencache.Save(c.Identity(), c.token, c.tokenExp, c.UserID); err != nil {
-		log.Debug("failed to cache token", "error", err)
-	} else {
-		log.Debug("saved token to cache", "expires_at", c.tokenExp)
+	c.setToken(res.AccessToken, time.Now().Add(time.Duration(res.ExpiresIn-60)*time.Second), res.UserID)
+	if res.RefreshToken != "" || payload["grant_type"] != "refresh_token" {
+		// Keep the old refresh token if a refresh grant did not rotate it.
+		c.mu.Lock()
+		c.refreshToken = res.RefreshToken
+		c.mu.Unlock()
 	}
+	c.saveToken("saved token to cache")
 	return nil
 }
 
+// authLegacyLogin signs in via /login. Rate limiting is retried by the
+// pipeline's Retry middleware using c.Retry.
 func (c *Client) authLegacyLogin(ctx context.Context) error {
-	return c.authLegacyLoginWithRetry(ctx, 0)
-}
-
-func (c *Client) authLegacyLoginWithRetry(ctx context.Context, attempt int) error {
 	payload := map[string]string{
 		"email":    c.Email,
 		"password": c.Password,
@@ -185,31 +297,20 @@ func (c *Client) authLegacyLoginWithRetry(ctx context.Context, attempt int) erro
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("User-Agent", "okhttp/4.9.3")
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.httpClient(false).Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusTooManyRequests {
-		b, _ := io.ReadAll(resp.Body)
-		log.Debug("legacy login rate limited", "status", resp.Status, "body", string(b), "attempt", attempt)
-		if attempt < 3 {
-			// Exponential backoff: 2s, 4s, 8s
-			delay := time.Duration(2<<attempt) * time.Second
-			log.Debug("retrying legacy login after delay", "delay", delay)
-			time.Sleep(delay)
-			return c.authLegacyLoginWithRetry(ctx, attempt+1)
-		}
-		return fmt.Errorf("login rate limited after %d attempts: %s", attempt+1, string(b))
+		apiErr := newAPIError(req, resp, req.URL.Path)
+		log.Debug("legacy login rate limited", "status", resp.Status, "body", string(RedactBody(apiErr.Body)))
+		return fmt.Errorf("login rate limited after %d attempts: %w", max(c.Retry.MaxAttempts, 1), apiErr)
 	}
 	if resp.StatusCode >= 300 {
-		b, _ := io.ReadAll(resp.Body)
-		log.Debug("legacy login failed", "status", resp.Status, "headers", resp.Header, "body", string(b))
-		return fmt.Errorf("login failed: %s", string(b))
+		apiErr := newAPIError(req, resp, req.URL.Path)
+		log.Debug("legacy login failed", "status", resp.Status, "headers", RedactHeaders(resp.Header), "body", string(RedactBody(apiErr.Body)))
+		return fmt.Errorf("login failed: %w", apiErr)
 	}
 	var res struct {
 		Session struct {
@@ -224,172 +325,219 @@ func (c *Client) authLegacyLoginWithRetry(ctx context.Context, attempt int) erro
 	if res.Session.Token == "" {
 		return errors.New("empty session token")
 	}
-	c.token = res.Session.Token
+	exp := time.Now().Add(12 * time.Hour)
 	if res.Session.ExpirationDate != "" {
 		if t, err := time.Parse(time.RFC3339, res.Session.ExpirationDate); err == nil {
-			c.tokenExp = t
+			exp = t
 		}
 	}
-	if c.tokenExp.IsZero() {
-		c.tokenExp = time.Now().Add(12 * time.Hour)
-	}
+	c.setToken(res.Session.Token, exp, res.Session.UserID)
+	c.mu.Lock()
+	c.refreshToken = ""
+	c.mu.Unlock()
+	c.saveToken("saved token to cache (legacy)")
+	return nil
+}
+
+// setToken stores a new token and fills UserID if it is still unknown.
+func (c *Client) setToken(token string, exp time.Time, userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+	c.tokenExp = exp
 	if c.UserID == "" {
-		c.UserID = res.Session.UserID
+		c.UserID = userID
+	}
+}
+
+// validToken returns the in-memory token, or "" if it is missing or expired.
+func (c *Client) validToken() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Before(c.tokenExp) {
+		return c.token
+	}
+	return ""
+}
+
+// userID reads UserID under the client lock.
+func (c *Client) userID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.UserID
+}
+
+// CurrentUserID returns UserID under the client lock. Sign-in, re-auth,
+// and token refresh may fill UserID from another goroutine, so code sharing
+// a client should read it through this instead of the field.
+func (c *Client) CurrentUserID() string { return c.userID() }
+
+// saveToken persists the current token unless replaying fixtures.
+func (c *Client) saveToken(msg string) {
+	if c.offline {
+		return
 	}
-	if err := tokencache.Save(c.Identity(), c.token, c.tokenExp, c.UserID); err != nil {
+	c.mu.Lock()
+	t := tokencache.CachedToken{Token: c.token, ExpiresAt: c.tokenExp, UserID: c.UserID, RefreshToken: c.refreshToken}
+	c.mu.Unlock()
+	if err := tokencache.SaveToken(c.Identity(), t); err != nil {
 		log.Debug("failed to cache token", "error", err)
 	} else {
-		log.Debug("saved token to cache (legacy)", "expires_at", c.tokenExp)
+		log.Debug(msg, "expires_at", t.ExpiresAt)
 	}
-	return nil
 }
 
 func (c *Client) ensureToken(ctx context.Context) error {
-	if c.token != "" && time.Now().Before(c.tokenExp) {
-		log.Debug("using in-memory token", "expires_in", time.Until(c.tokenExp).Round(time.Second))
+	if c.validToken() != "" {
+		return nil
+	}
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.ensureTokenLocked(ctx)
+}
+
+// ensureTokenLocked loads or fetches a token. Callers must hold authMu, so
+// goroutines that queued behind a sign-in find its token and return early.
+func (c *Client) ensureTokenLocked(ctx context.Context) error {
+	if c.validToken() != "" {
+		log.Debug("using in-memory token")
+		return nil
+	}
+	if c.offline {
+		// Recorded tokens are redacted; any placeholder satisfies replay.
+		c.setToken("replay", time.Now().Add(24*time.Hour), "")
 		return nil
 	}
 	// Trust cached tokens without server validation. If token is invalid,
 	// the server will return 401 and we'll clear cache + re-authenticate.
-	if cached, err := tokencache.Load(c.Identity(), c.UserID); err == nil {
+	if cached, err := tokencache.Load(c.Identity(), c.userID()); err == nil {
 		log.Debug("loaded token from cache", "expires_at", cached.ExpiresAt, "user_id", cached.UserID)
-		c.token = cached.Token
-		c.tokenExp = cached.ExpiresAt
-		if cached.UserID != "" && c.UserID == "" {
-			c.UserID = cached.UserID
-		}
+		c.setToken(cached.Token, cached.ExpiresAt, cached.UserID)
+		c.mu.Lock()
+		c.refreshToken = cached.RefreshToken
+		c.mu.Unlock()
 		return nil
 	} else {
 		log.Debug("no cached token", "reason", err)
 	}
 	log.Debug("authenticating with server")
-	return c.Authenticate(ctx)
+	return c.authenticate(ctx)
+}
+
+// reauthenticate replaces a token the server rejected. Only the first
+// caller holding a given rejected token signs in again; concurrent callers
+// that saw the same 401 wait on authMu and pick up the fresh token.
+func (c *Client) reauthenticate(ctx context.Context, rejected string) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.mu.Lock()
+	stale := c.token == rejected
+	if stale {
+		c.token = ""
+	}
+	c.mu.Unlock()
+	if stale && !c.offline {
+		_ = tokencache.Clear(c.Identity())
+	}
+	return c.ensureTokenLocked(ctx)
 }
 
-// requireUser ensures UserID is populated.
-func (c *Client) requireUser(ctx context.Context) error {
-	if c.UserID != "" {
-		return nil
+// TokenExpiry reports when the current token expires; zero if none is loaded.
+func (c *Client) TokenExpiry() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == "" {
+		return time.Time{}
 	}
-	return c.EnsureUserID(ctx)
+	return c.tokenExp
 }
 
-func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
-	if err := c.ensureToken(ctx); err != nil {
-		return err
+// Refresh renews the token now, before it expires. It uses the refresh_token
+// grant when the auth endpoint issued a refresh token and falls back to a
+// full sign-in otherwise. The new token is written to the token cache.
+func (c *Client) Refresh(ctx context.Context) error {
+	if c.offline {
+		return nil
 	}
-	var rdr io.Reader
-	if body != nil {
-		b, err := json.Marshal(body)
-		if err != nil {
-			return err
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	c.mu.Lock()
+	refreshToken := c.refreshToken
+	c.mu.Unlock()
+	if refreshToken != "" {
+		err := c.authRefreshToken(ctx, refreshToken)
+		if err == nil {
+			return nil
 		}
-		rdr = bytes.NewReader(b)
+		log.Debug("refresh_token grant failed, signing in again", "error", err)
 	}
-	u := c.BaseURL + path
-	if len(query) > 0 {
-		u += "?" + query.Encode()
-	}
-	req, err := http.NewRequestWithContext(ctx, method, u, rdr)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("User-Agent", "okhttp/4.9.3")
-	// Note: Don't set Accept-Encoding manually; Go handles gzip automatically
+	return c.authenticate(ctx)
+}
 
-	resp, err := c.HTTP.Do(req)
-	if err != nil {
-		return err
+// bearer returns a usable token, signing in first if needed.
+func (c *Client) bearer(ctx context.Context) (string, error) {
+	if err := c.ensureToken(ctx); err != nil {
+		return "", err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusTooManyRequests {
-		time.Sleep(2 * time.Second)
-		return c.do(ctx, method, path, query, body, out)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token, nil
+}
+
+// requireUser ensures UserID is populated.
+func (c *Client) requireUser(ctx context.Context) error {
+	if c.userID() != "" {
+		return nil
 	}
-	if resp.StatusCode == http.StatusUnauthorized {
-		c.token = ""
-		_ = tokencache.Clear(c.Identity())
-		if err := c.ensureToken(ctx); err != nil {
-			return err
+	return c.EnsureUserID(ctx)
+}
+
+// apiHost selects which Eight Sleep API a request is sent to.
+type apiHost int
+
+const (
+	hostClientV1 apiHost = iota
+	hostClientV3
+	hostAppAPI
+	hostAppAPIV2
+)
+
+// baseURL resolves the base URL for an API host.
+func (c *Client) baseURL(h apiHost) string {
+	switch h {
+	case hostClientV3:
+		// Replace v1 with v3 in the base URL
+		return strings.Replace(c.BaseURL, "/v1", "/v3", 1)
+	case hostAppAPI:
+		if c.AppAPIBaseURL != "" {
+			return c.AppAPIBaseURL
 		}
-		return c.do(ctx, method, path, query, body, out)
+		return appAPIBaseURL
+	case hostAppAPIV2:
+		// The v2 app-api endpoints sit beside v1, not under it
+		return strings.Replace(c.baseURL(hostAppAPI), "/v1", "/v2", 1)
+	default:
+		return c.BaseURL
 	}
-	if resp.StatusCode >= 300 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("api %s %s: %s", method, path, string(b))
-	}
-	if out != nil {
-		return json.NewDecoder(resp.Body).Decode(out)
-	}
-	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	return c.doHost(ctx, hostClientV1, method, path, query, body, out)
 }
 
 // doV3 is like do but uses v3 API instead of v1.
 func (c *Client) doV3(ctx context.Context, method, path string, query url.Values, body any, out any) error {
-	if err := c.ensureToken(ctx); err != nil {
-		return err
-	}
-	var rdr io.Reader
-	if body != nil {
-		b, err := json.Marshal(body)
-		if err != nil {
-			return err
-		}
-		rdr = bytes.NewReader(b)
-	}
-	// Replace v1 with v3 in the base URL
-	baseV3 := strings.Replace(c.BaseURL, "/v1", "/v3", 1)
-	u := baseV3 + path
-	if len(query) > 0 {
-		u += "?" + query.Encode()
-	}
-	req, err := http.NewRequestWithContext(ctx, method, u, rdr)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("User-Agent", "okhttp/4.9.3")
-
-	resp, err := c.HTTP.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusTooManyRequests {
-		time.Sleep(2 * time.Second)
-		return c.doV3(ctx, method, path, query, body, out)
-	}
-	if resp.StatusCode == http.StatusUnauthorized {
-		c.token = ""
-		_ = tokencache.Clear(c.Identity())
-		if err := c.ensureToken(ctx); err != nil {
-			return err
-		}
-		return c.doV3(ctx, method, path, query, body, out)
-	}
-	if resp.StatusCode >= 300 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("api %s %s: %s", method, path, string(b))
-	}
-	if out != nil {
-		return json.NewDecoder(resp.Body).Decode(out)
-	}
-	return nil
+	return c.doHost(ctx, hostClientV3, method, path, query, body, out)
 }
 
 // doAppAPI is like do but uses app-api.8slp.net instead of client-api.8slp.net.
 // Many endpoints (alarms, base, bedtime, away mode) have migrated to this API.
 func (c *Client) doAppAPI(ctx context.Context, method, path string, query url.Values, body any, out any) error {
-	if err := c.ensureToken(ctx); err != nil {
-		return err
-	}
+	return c.doHost(ctx, hostAppAPI, method, path, query, body, out)
+}
+
+// doHost sends an authenticated JSON request through the middleware pipeline.
+func (c *Client) doHost(ctx context.Context, host apiHost, method, path string, query url.Values, body any, out any) error {
 	var rdr io.Reader
 	if body != nil {
 		b, err := json.Marshal(body)
@@ -398,11 +546,7 @@ func (c *Client) doAppAPI(ctx context.Context, method, path string, query url.Va
 		}
 		rdr = bytes.NewReader(b)
 	}
-	baseURL := c.AppAPIBaseURL
-	if baseURL == "" {
-		baseURL = appAPIBaseURL
-	}
-	u := baseURL + path
+	u := c.baseURL(host) + path
 	if len(query) > 0 {
 		u += "?" + query.Encode()
 	}
@@ -410,32 +554,14 @@ func (c *Client) doAppAPI(ctx context.Context, method, path string, query url.Va
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Connection", "keep-alive")
-	req.Header.Set("User-Agent", "okhttp/4.9.3")
 
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.httpClient(true).Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusTooManyRequests {
-		time.Sleep(2 * time.Second)
-		return c.doAppAPI(ctx, method, path, query, body, out)
-	}
-	if resp.StatusCode == http.StatusUnauthorized {
-		c.token = ""
-		_ = tokencache.Clear(c.Identity())
-		if err := c.ensureToken(ctx); err != nil {
-			return err
-		}
-		return c.doAppAPI(ctx, method, path, query, body, out)
-	}
 	if resp.StatusCode >= 300 {
-		b, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("api %s %s: %s", method, path, string(b))
+		return newAPIError(req, resp, path)
 	}
 	if out != nil {
 		return json.NewDecoder(resp.Body).Decode(out)
@@ -457,7 +583,7 @@ func (c *Client) setPower(ctx context.Context, on bool) error {
 	if err := c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/devices/power", c.UserID)
+	path := fmt.Sprintf("/users/%s/devices/power", c.userID())
 	body := map[string]bool{"on": on}
 	return c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -487,6 +613,7 @@ func (c *Client) Identity() tokencache.Identity {
 		BaseURL:  c.BaseURL,
 		ClientID: c.ClientID,
 		Email:    c.Email,
+		Profile:  c.Profile,
 	}
 }
 
@@ -498,7 +625,7 @@ func (c *Client) SetTemperature(ctx context.Context, level int) error {
 	if level < -100 || level > 100 {
 		return fmt.Errorf("level must be between -100 and 100")
 	}
-	path := fmt.Sprintf("/users/%s/temperature", c.UserID)
+	path := fmt.Sprintf("/users/%s/temperature", c.userID())
 	body := map[string]int{"currentLevel": level}
 	return c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
@@ -515,7 +642,7 @@ func (c *Client) SetTemperatureWithDuration(ctx context.Context, level int, dura
 	if durationMinutes < 1 {
 		return fmt.Errorf("duration must be at least 1 minute")
 	}
-	path := fmt.Sprintf("/users/%s/temperature", c.UserID)
+	path := fmt.Sprintf("/users/%s/temperature", c.userID())
 	body := map[string]any{
 		"currentLevel": level,
 		"timeBased": map[string]any{
@@ -539,7 +666,7 @@ func (c *Client) GetStatus(ctx context.Context) (*TempStatus, error) {
 	if err := c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/temperature", c.UserID)
+	path := fmt.Sprintf("/users/%s/temperature", c.userID())
 	var res TempStatus
 	if err := c.do(ctx, http.MethodGet, path, nil, nil, &res); err != nil {
 		return nil, err
@@ -614,7 +741,7 @@ func (c *Client) GetSleepDay(ctx context.Context, date string, timezone string)
 	q.Set("include-main", "false")
 	q.Set("include-all-sessions", "true")
 	q.Set("model-version", "v2")
-	path := fmt.Sprintf("/users/%s/trends", c.UserID)
+	path := fmt.Sprintf("/users/%s/trends", c.userID())
 	var res struct {
 		Days []SleepDay `json:"days"`
 	}
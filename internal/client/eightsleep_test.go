@@ -237,6 +237,43 @@ func TestAuthenticate_LegacyLogin(t *testing.T) {
 	}
 }
 
+func TestAuthenticateUsesPasswordFunc(t *testing.T) {
+	useTempKeyring(t)
+	var got []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, body["password"])
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"tok","expires_in":3600,"userId":"uid"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	calls := 0
+	c := New("test@example.com", "", "", "", "", WithPasswordFunc(func(context.Context) (string, error) {
+		calls++
+		return "from-manager", nil
+	}))
+	c.AuthURL = srv.URL + "/v1/tokens"
+	c.HTTP = srv.Client()
+
+	for range 2 {
+		if err := c.Authenticate(context.Background()); err != nil {
+			t.Fatalf("Authenticate: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("password func called %d times, want 1", calls)
+	}
+	if len(got) != 2 || got[0] != "from-manager" || got[1] != "from-manager" {
+		t.Errorf("passwords sent = %v", got)
+	}
+}
+
 func TestEnsureDeviceID(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
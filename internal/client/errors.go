@@ -0,0 +1,88 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by APIError via errors.Is.
+var (
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrForbidden      = errors.New("forbidden")
+	ErrNotFound       = errors.New("not found")
+	ErrRateLimited    = errors.New("rate limited")
+	ErrInvalidRequest = errors.New("invalid request")
+)
+
+// maxErrorBody caps how much of an error response is kept.
+const maxErrorBody = 64 << 10
+
+// APIError describes a non-2xx response from an Eight Sleep API.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	Host       string
+	Body       []byte // raw response body
+	Code       string // "code" field from a JSON error body, if any
+	Message    string // decoded error message, or the trimmed raw body
+}
+
+func (e *APIError) Error() string {
+	status := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message == "" {
+		return fmt.Sprintf("api %s %s: %s", e.Method, e.Path, status)
+	}
+	return fmt.Sprintf("api %s %s: %s: %s", e.Method, e.Path, status, e.Message)
+}
+
+// Is lets callers match on sentinels such as ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInvalidRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	}
+	return false
+}
+
+// newAPIError reads resp's body and builds an APIError for the request.
+func newAPIError(req *http.Request, resp *http.Response, path string) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		Path:       path,
+		Host:       req.URL.Host,
+		Body:       body,
+	}
+	var decoded struct {
+		Code    any    `json:"code"`
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+	if json.Unmarshal(body, &decoded) == nil {
+		if decoded.Code != nil {
+			e.Code = fmt.Sprint(decoded.Code)
+		}
+		e.Message = decoded.Message
+		if e.Message == "" {
+			e.Message = decoded.Error
+		}
+	}
+	if e.Message == "" {
+		e.Message = strings.TrimSpace(string(body))
+	}
+	return e
+}
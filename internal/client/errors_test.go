@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIError_Sentinels(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadRequest, ErrInvalidRequest},
+		{http.StatusUnprocessableEntity, ErrInvalidRequest},
+	}
+	all := []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited, ErrInvalidRequest}
+	for _, tt := range tests {
+		err := error(&APIError{StatusCode: tt.status})
+		for _, sentinel := range all {
+			if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+				t.Errorf("status %d: errors.Is(%v) = %v", tt.status, sentinel, got)
+			}
+		}
+	}
+}
+
+func TestDoHost_ReturnsAPIError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/uid-123/temperature", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"NotFound","message":"no such user"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "uid-123", "", "")
+	c.BaseURL = srv.URL
+	c.token = "t"
+	c.tokenExp = time.Now().Add(time.Hour)
+	c.HTTP = srv.Client()
+
+	_, err := c.GetStatus(context.Background())
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Method != http.MethodGet || apiErr.Path != "/users/uid-123/temperature" {
+		t.Errorf("unexpected request info: %s %s", apiErr.Method, apiErr.Path)
+	}
+	if apiErr.Host != strings.TrimPrefix(srv.URL, "http://") {
+		t.Errorf("expected host %s, got %s", srv.URL, apiErr.Host)
+	}
+	if apiErr.Code != "NotFound" || apiErr.Message != "no such user" {
+		t.Errorf("unexpected decoded body: code=%q message=%q", apiErr.Code, apiErr.Message)
+	}
+}
+
+func TestAPIError_PlainTextBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Cannot GET /v1/users/x/schedules\n"))
+	}))
+	defer srv.Close()
+
+	c := New("email", "pass", "uid", "", "")
+	c.BaseURL = srv.URL
+	c.token = "t"
+	c.tokenExp = time.Now().Add(time.Hour)
+	c.HTTP = srv.Client()
+
+	err := c.do(context.Background(), http.MethodGet, "/users/x/schedules", nil, nil, nil)
+	want := "api GET /users/x/schedules: 404 Not Found: Cannot GET /v1/users/x/schedules"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+}
@@ -16,7 +16,7 @@ func (f *FeaturesActions) GetFeatureFlags(ctx context.Context) (any, error) {
 	if err := f.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/release-features", f.c.UserID)
+	path := fmt.Sprintf("/users/%s/release-features", f.c.userID())
 	var res any
 	err := f.c.do(ctx, http.MethodGet, path, nil, nil, &res)
 	return res, err
@@ -0,0 +1,283 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fixture is one recorded request/response exchange. Credentials, tokens,
+// and email addresses are redacted before a fixture is written.
+type Fixture struct {
+	Request    FixtureRequest  `json:"request"`
+	Response   FixtureResponse `json:"response"`
+	RecordedAt time.Time       `json:"recorded_at"`
+}
+
+// FixtureRequest is the recorded side of an outgoing request.
+type FixtureRequest struct {
+	Method   string          `json:"method"`
+	URL      string          `json:"url"`
+	Header   http.Header     `json:"header,omitempty"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	BodyText string          `json:"body_text,omitempty"` // non-JSON bodies
+}
+
+// FixtureResponse is the recorded server reply.
+type FixtureResponse struct {
+	StatusCode int             `json:"status"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	BodyText   string          `json:"body_text,omitempty"` // non-JSON bodies
+}
+
+// matchKey identifies requests that replay the same fixture.
+func (r FixtureRequest) matchKey() (exact, loose string) {
+	u, err := http.NewRequest(r.Method, r.URL, nil)
+	if err != nil {
+		return r.Method + " " + r.URL, r.Method + " " + r.URL
+	}
+	return requestKey(u)
+}
+
+// requestKey keys on the redacted URL, which is all a fixture stores, so a
+// live request carrying a token in its query still finds its fixture.
+func requestKey(req *http.Request) (exact, loose string) {
+	u, err := url.Parse(RedactURL(req.URL))
+	if err != nil {
+		u = req.URL
+	}
+	loose = req.Method + " " + u.Host + u.Path
+	return loose + "?" + u.Query().Encode(), loose
+}
+
+// Record writes every exchange passing through it to dir as one JSON file,
+// numbered in the order requests were sent. Register it with c.Use so it
+// sees the request exactly as it goes on the wire.
+func Record(dir string) Middleware {
+	rec := &recorder{dir: dir}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			reqBody, err := readRequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			respBody, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+			if err := rec.save(newFixture(req, reqBody, resp, respBody)); err != nil {
+				return nil, fmt.Errorf("record fixture: %w", err)
+			}
+			return resp, nil
+		})
+	}
+}
+
+type recorder struct {
+	dir  string
+	mu   sync.Mutex
+	next int
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9.]+`)
+
+func (r *recorder) save(f Fixture) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.next == 0 {
+		if err := os.MkdirAll(r.dir, 0o700); err != nil {
+			return err
+		}
+		// Continue numbering after fixtures left by earlier runs.
+		existing, _ := filepath.Glob(filepath.Join(r.dir, "*.json"))
+		r.next = len(existing) + 1
+	}
+	u, _ := http.NewRequest(f.Request.Method, f.Request.URL, nil)
+	slug := strings.Trim(unsafeFileChars.ReplaceAllString(u.URL.Host+u.URL.Path, "-"), "-")
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	name := fmt.Sprintf("%04d-%s-%s.json", r.next, f.Request.Method, slug)
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, name), append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+	r.next++
+	return nil
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func newFixture(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) Fixture {
+	f := Fixture{
+		Request: FixtureRequest{
+			Method: req.Method,
+			URL:    RedactURL(req.URL),
+			Header: RedactHeaders(req.Header),
+		},
+		Response: FixtureResponse{
+			StatusCode: resp.StatusCode,
+			Header:     RedactHeaders(resp.Header),
+		},
+		RecordedAt: time.Now().UTC(),
+	}
+	// The body is stored decoded, so wire encoding headers no longer apply.
+	f.Response.Header.Del("Content-Encoding")
+	f.Response.Header.Del("Content-Length")
+	f.Request.Body, f.Request.BodyText = fixtureBody(reqBody)
+	f.Response.Body, f.Response.BodyText = fixtureBody(respBody)
+	return f
+}
+
+func fixtureBody(body []byte) (json.RawMessage, string) {
+	if len(body) == 0 {
+		return nil, ""
+	}
+	if json.Valid(body) {
+		return RedactBody(body), ""
+	}
+	return nil, RedactText(string(body))
+}
+
+// ReplayTransport answers requests from fixtures written by Record without
+// touching the network. Requests match on method, host, path, and query,
+// falling back to method, host, and path. Repeated requests step through
+// matching fixtures in recorded order and then keep returning the last one.
+type ReplayTransport struct {
+	Dir string
+
+	once     sync.Once
+	err      error
+	mu       sync.Mutex
+	fixtures []Fixture
+	used     []bool
+}
+
+// NewReplayTransport returns a transport serving the fixtures in dir.
+func NewReplayTransport(dir string) *ReplayTransport {
+	return &ReplayTransport{Dir: dir}
+}
+
+func (t *ReplayTransport) load() {
+	paths, err := filepath.Glob(filepath.Join(t.Dir, "*.json"))
+	if err != nil {
+		t.err = err
+		return
+	}
+	if len(paths) == 0 {
+		t.err = fmt.Errorf("replay: no fixtures in %s", t.Dir)
+		return
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			t.err = err
+			return
+		}
+		var f Fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			t.err = fmt.Errorf("replay: parse %s: %w", filepath.Base(p), err)
+			return
+		}
+		t.fixtures = append(t.fixtures, f)
+	}
+	t.used = make([]bool, len(t.fixtures))
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.once.Do(t.load)
+	if t.err != nil {
+		return nil, t.err
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	f, ok := t.match(req)
+	if !ok {
+		return nil, fmt.Errorf("replay: no fixture for %s %s", req.Method, req.URL.Redacted())
+	}
+	body := []byte(f.Response.Body)
+	if f.Response.BodyText != "" {
+		body = []byte(f.Response.BodyText)
+	}
+	header := f.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.Response.StatusCode, http.StatusText(f.Response.StatusCode)),
+		StatusCode:    f.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *ReplayTransport) match(req *http.Request) (Fixture, bool) {
+	exact, loose := requestKey(req)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, useExact := range []bool{true, false} {
+		last := -1
+		for i, f := range t.fixtures {
+			fe, fl := f.Request.matchKey()
+			if (useExact && fe != exact) || (!useExact && fl != loose) {
+				continue
+			}
+			if !t.used[i] {
+				t.used[i] = true
+				return f, true
+			}
+			last = i
+		}
+		if last >= 0 {
+			return t.fixtures[last], true
+		}
+	}
+	return Fixture{}, false
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixtureServer answers the token, client-api, and app-api endpoints that
+// TestRecordReplay exercises.
+func fixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"secret-token","expires_in":3600,"userId":"uid"}`))
+	})
+	mux.HandleFunc("/v1/users/me", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"user":{"userId":"uid","email":"sleeper@example.com","currentDevice":{"id":"dev1"}}}`))
+	})
+	mux.HandleFunc("/v1/users/uid/temperature", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"currentLevel":-20,"currentState":{"type":"smart"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRecordReplay(t *testing.T) {
+	useTempKeyring(t)
+	srv := fixtureServer(t)
+	dir := t.TempDir()
+
+	rec := New("sleeper@example.com", "hunter2", "", "", "", WithRecord(dir))
+	rec.HTTP = srv.Client()
+	rec.HTTP.Transport = rewriteHost(rec.HTTP.Transport, srv.URL)
+
+	ctx := context.Background()
+	if _, err := rec.EnsureDeviceID(ctx); err != nil {
+		t.Fatalf("ensure device: %v", err)
+	}
+	var temp struct {
+		CurrentLevel int `json:"currentLevel"`
+	}
+	if err := rec.doAppAPI(ctx, http.MethodGet, "/users/uid/temperature", nil, nil, &temp); err != nil {
+		t.Fatalf("app api: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Fatalf("expected 3 fixtures (auth, client-api, app-api), got %d", len(files))
+	}
+	var all strings.Builder
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all.Write(data)
+	}
+	for _, secret := range []string{"hunter2", "secret-token", "sleeper@example.com", defaultClientSecret} {
+		if strings.Contains(all.String(), secret) {
+			t.Errorf("fixture leaks %q", secret)
+		}
+	}
+	for _, host := range []string{"auth-api.8slp.net", "client-api.8slp.net", "app-api.8slp.net"} {
+		if !strings.Contains(all.String(), host) {
+			t.Errorf("expected an exchange with %s", host)
+		}
+	}
+
+	srv.Close()
+	play := New("", "", "", "", "", WithReplay(dir))
+	dev, err := play.EnsureDeviceID(ctx)
+	if err != nil || dev != "dev1" {
+		t.Fatalf("replay device: %q %v", dev, err)
+	}
+	temp.CurrentLevel = 0
+	if err := play.doAppAPI(ctx, http.MethodGet, "/users/uid/temperature", nil, nil, &temp); err != nil {
+		t.Fatalf("replay app api: %v", err)
+	}
+	if temp.CurrentLevel != -20 {
+		t.Fatalf("expected replayed level -20, got %d", temp.CurrentLevel)
+	}
+}
+
+func TestReplay_Unmatched(t *testing.T) {
+	dir := t.TempDir()
+	fixture := `{"request":{"method":"GET","url":"https://client-api.8slp.net/v1/users/me"},"response":{"status":200,"body":{"user":{"userId":"uid"}}}}`
+	if err := os.WriteFile(filepath.Join(dir, "0001-GET-me.json"), []byte(fixture), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := New("", "", "", "", "", WithReplay(dir))
+	err := c.do(context.Background(), http.MethodGet, "/users/uid/alarms", nil, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "no fixture") {
+		t.Fatalf("expected missing fixture error, got %v", err)
+	}
+}
+
+func TestReplay_StepsThroughRepeats(t *testing.T) {
+	dir := t.TempDir()
+	first := `{"request":{"method":"GET","url":"https://client-api.8slp.net/v1/ping"},"response":{"status":429,"body":{"message":"slow down"}}}`
+	second := `{"request":{"method":"GET","url":"https://client-api.8slp.net/v1/ping"},"response":{"status":200,"body":{"ok":true}}}`
+	os.WriteFile(filepath.Join(dir, "0001-GET-ping.json"), []byte(first), 0o600)
+	os.WriteFile(filepath.Join(dir, "0002-GET-ping.json"), []byte(second), 0o600)
+
+	tr := NewReplayTransport(dir)
+	statuses := []int{}
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://client-api.8slp.net/v1/ping", nil)
+		resp, err := tr.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("round trip: %v", err)
+		}
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if statuses[0] != 429 || statuses[1] != 200 || statuses[2] != 200 {
+		t.Fatalf("unexpected replay order %v", statuses)
+	}
+}
+
+func TestRecord_RedactsQuery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+
+	hc := &http.Client{Transport: Chain(srv.Client().Transport, Record(dir))}
+	resp, err := hc.Get(srv.URL + "/v1/ping?access_token=secret-token&from=2025-01-01")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 fixture, got %d", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "secret-token") {
+		t.Fatalf("fixture leaks query token: %s", data)
+	}
+
+	srv.Close()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/ping?access_token=other-token&from=2025-01-01", nil)
+	if _, err := NewReplayTransport(dir).RoundTrip(req); err != nil {
+		t.Fatalf("replay with a different token: %v", err)
+	}
+}
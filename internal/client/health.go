@@ -31,7 +31,7 @@ func (h *HealthActions) HealthCheckpoints(ctx context.Context, sourceID string,
 	if err := h.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/health-integrations/sources/%s/checkpoints", h.c.UserID, sourceID)
+	path := fmt.Sprintf("/users/%s/health-integrations/sources/%s/checkpoints", h.c.userID(), sourceID)
 	return h.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -40,6 +40,6 @@ func (h *HealthActions) UploadHealthData(ctx context.Context, sourceID string, b
 	if err := h.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/health-integrations/sources/%s", h.c.UserID, sourceID)
+	path := fmt.Sprintf("/users/%s/health-integrations/sources/%s", h.c.userID(), sourceID)
 	return h.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -10,81 +10,157 @@ type HouseholdActions struct{ c *Client }
 
 func (c *Client) Household() *HouseholdActions { return &HouseholdActions{c: c} }
 
-func (h *HouseholdActions) Summary(ctx context.Context) (any, error) {
+// Household is a group of pods and the people who share them.
+type Household struct {
+	ID      string         `json:"householdId"`
+	Name    string         `json:"name"`
+	Sets    []HouseholdSet `json:"sets"`
+	OwnerID string         `json:"ownerId"`
+}
+
+// HouseholdSet places a household member on one side of a pod.
+type HouseholdSet struct {
+	ID       string `json:"setId"`
+	DeviceID string `json:"deviceId"`
+	UserID   string `json:"userId"`
+	Side     string `json:"side"`
+}
+
+// HouseholdSummary lists the households the user belongs to.
+type HouseholdSummary struct {
+	Households []Household `json:"households"`
+	RawResponse
+}
+
+// HouseholdReturn is a scheduled return to a device set after time away.
+type HouseholdReturn struct {
+	SetID      string `json:"setId"`
+	ReturnDate string `json:"returnDate"`
+}
+
+// HouseholdSchedule is the user's scheduled return; empty when none is set.
+type HouseholdSchedule struct {
+	Schedule HouseholdReturn `json:"schedule"`
+	RawResponse
+}
+
+// CurrentSet is the device and side the user is sleeping on now.
+type CurrentSet struct {
+	CurrentSet HouseholdSet `json:"currentSet"`
+	RawResponse
+}
+
+// HouseholdInvitation is a pending invitation to join a household.
+type HouseholdInvitation struct {
+	HouseholdID string `json:"householdId"`
+	InvitedBy   string `json:"invitedBy"`
+	Email       string `json:"email"`
+	Status      string `json:"status"`
+}
+
+// HouseholdInvitations lists the user's pending invitations.
+type HouseholdInvitations struct {
+	Invitations []HouseholdInvitation `json:"invitations"`
+	RawResponse
+}
+
+// HouseholdDevice is a pod registered to a household.
+type HouseholdDevice struct {
+	ID          string `json:"deviceId"`
+	HouseholdID string `json:"householdId"`
+	Name        string `json:"name"`
+	LeftUserID  string `json:"leftUserId"`
+	RightUserID string `json:"rightUserId"`
+}
+
+// HouseholdDevices lists the pods in the user's households.
+type HouseholdDevices struct {
+	Devices []HouseholdDevice `json:"devices"`
+	RawResponse
+}
+
+// HouseholdUser is a member or guest of a household.
+type HouseholdUser struct {
+	ID        string `json:"userId"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+}
+
+// HouseholdUsers lists the members of the user's households.
+type HouseholdUsers struct {
+	Users []HouseholdUser `json:"users"`
+	RawResponse
+}
+
+// HouseholdGuests lists the guests of the user's households.
+type HouseholdGuests struct {
+	Guests []HouseholdUser `json:"guests"`
+	RawResponse
+}
+
+func (h *HouseholdActions) Summary(ctx context.Context) (*HouseholdSummary, error) {
 	if err := h.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/household/users/%s/summary", h.c.UserID)
-	var res any
-	err := h.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/household/users/%s/summary", h.c.userID())
+	return fetchModel[HouseholdSummary](ctx, h.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (h *HouseholdActions) Schedule(ctx context.Context) (any, error) {
+func (h *HouseholdActions) Schedule(ctx context.Context) (*HouseholdSchedule, error) {
 	if err := h.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/household/users/%s/schedule", h.c.UserID)
-	var res any
-	err := h.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/household/users/%s/schedule", h.c.userID())
+	return fetchModel[HouseholdSchedule](ctx, h.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (h *HouseholdActions) CurrentSet(ctx context.Context) (any, error) {
+func (h *HouseholdActions) CurrentSet(ctx context.Context) (*CurrentSet, error) {
 	if err := h.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/household/users/%s/current-set", h.c.UserID)
-	var res any
-	err := h.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/household/users/%s/current-set", h.c.userID())
+	return fetchModel[CurrentSet](ctx, h.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (h *HouseholdActions) Invitations(ctx context.Context) (any, error) {
+func (h *HouseholdActions) Invitations(ctx context.Context) (*HouseholdInvitations, error) {
 	if err := h.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/household/users/%s/invitations", h.c.UserID)
-	var res any
-	err := h.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/household/users/%s/invitations", h.c.userID())
+	return fetchModel[HouseholdInvitations](ctx, h.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (h *HouseholdActions) Devices(ctx context.Context) (any, error) {
+func (h *HouseholdActions) Devices(ctx context.Context) (*HouseholdDevices, error) {
 	if err := h.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/household/users/%s/devices", h.c.UserID)
-	var res any
-	err := h.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/household/users/%s/devices", h.c.userID())
+	return fetchModel[HouseholdDevices](ctx, h.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (h *HouseholdActions) Users(ctx context.Context) (any, error) {
+func (h *HouseholdActions) Users(ctx context.Context) (*HouseholdUsers, error) {
 	if err := h.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/household/users/%s/users", h.c.UserID)
-	var res any
-	err := h.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/household/users/%s/users", h.c.userID())
+	return fetchModel[HouseholdUsers](ctx, h.c, hostClientV1, http.MethodGet, path, nil)
 }
 
-func (h *HouseholdActions) Guests(ctx context.Context) (any, error) {
+func (h *HouseholdActions) Guests(ctx context.Context) (*HouseholdGuests, error) {
 	if err := h.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/household/users/%s/guests", h.c.UserID)
-	var res any
-	err := h.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/household/users/%s/guests", h.c.userID())
+	return fetchModel[HouseholdGuests](ctx, h.c, hostClientV1, http.MethodGet, path, nil)
 }
 
 func (h *HouseholdActions) SetCurrentSet(ctx context.Context, body map[string]any) error {
 	if err := h.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/household/users/%s/current-set", h.c.UserID)
+	path := fmt.Sprintf("/household/users/%s/current-set", h.c.userID())
 	return h.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
@@ -92,7 +168,7 @@ func (h *HouseholdActions) ClearCurrentSet(ctx context.Context) error {
 	if err := h.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/household/users/%s/current-set", h.c.UserID)
+	path := fmt.Sprintf("/household/users/%s/current-set", h.c.userID())
 	return h.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
 }
 
@@ -100,7 +176,7 @@ func (h *HouseholdActions) SetReturnDate(ctx context.Context, body map[string]an
 	if err := h.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/household/users/%s/schedule", h.c.UserID)
+	path := fmt.Sprintf("/household/users/%s/schedule", h.c.userID())
 	return h.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
 
@@ -108,7 +184,7 @@ func (h *HouseholdActions) RemoveReturnDate(ctx context.Context, setID string) e
 	if err := h.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/household/users/%s/schedule/%s", h.c.UserID, setID)
+	path := fmt.Sprintf("/household/users/%s/schedule/%s", h.c.userID(), setID)
 	return h.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
 }
 
@@ -20,7 +20,7 @@ func (i *InsightsActions) LLMInsights(ctx context.Context, from, to string) (any
 	if err := i.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/llm-insights", i.c.UserID)
+	path := fmt.Sprintf("/users/%s/llm-insights", i.c.userID())
 	q := url.Values{"from": []string{from}, "to": []string{to}}
 	var res any
 	err := i.c.do(ctx, http.MethodGet, path, q, nil, &res)
@@ -32,7 +32,7 @@ func (i *InsightsActions) CreateLLMInsightsBatch(ctx context.Context, body map[s
 	if err := i.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/llm-insights/batch", i.c.UserID)
+	path := fmt.Sprintf("/users/%s/llm-insights/batch", i.c.userID())
 	return i.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
 
@@ -41,7 +41,7 @@ func (i *InsightsActions) LLMInsightsSettings(ctx context.Context) (any, error)
 	if err := i.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/llm-insights/settings", i.c.UserID)
+	path := fmt.Sprintf("/users/%s/llm-insights/settings", i.c.userID())
 	var res any
 	err := i.c.do(ctx, http.MethodGet, path, nil, nil, &res)
 	return res, err
@@ -52,7 +52,7 @@ func (i *InsightsActions) UpdateLLMInsightsSettings(ctx context.Context, body ma
 	if err := i.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/llm-insights/settings", i.c.UserID)
+	path := fmt.Sprintf("/users/%s/llm-insights/settings", i.c.userID())
 	return i.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
@@ -61,6 +61,6 @@ func (i *InsightsActions) SubmitLLMInsightFeedback(ctx context.Context, insightI
 	if err := i.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/llm-insights/%s/feedback", i.c.UserID, insightID)
+	path := fmt.Sprintf("/users/%s/llm-insights/%s/feedback", i.c.userID(), insightID)
 	return i.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
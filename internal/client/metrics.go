@@ -2,9 +2,11 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type MetricsActions struct{ c *Client }
@@ -24,7 +26,7 @@ func (m *MetricsActions) Trends(ctx context.Context, from, to, tz string, out an
 	q.Set("include-main", "false")
 	q.Set("include-all-sessions", "true")
 	q.Set("model-version", "v2")
-	path := fmt.Sprintf("/users/%s/trends", m.c.UserID)
+	path := fmt.Sprintf("/users/%s/trends", m.c.userID())
 	return m.c.do(ctx, http.MethodGet, path, q, nil, out)
 }
 
@@ -32,7 +34,7 @@ func (m *MetricsActions) Intervals(ctx context.Context, sessionID string, out an
 	if err := m.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/intervals/%s", m.c.UserID, sessionID)
+	path := fmt.Sprintf("/users/%s/intervals/%s", m.c.userID(), sessionID)
 	return m.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -40,7 +42,7 @@ func (m *MetricsActions) Summary(ctx context.Context, out any) error {
 	if err := m.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/metrics/summary", m.c.UserID)
+	path := fmt.Sprintf("/users/%s/metrics/summary", m.c.userID())
 	return m.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -50,7 +52,7 @@ func (m *MetricsActions) Aggregate(ctx context.Context, out any) error {
 	}
 	q := url.Values{}
 	q.Set("v2", "true")
-	path := fmt.Sprintf("/users/%s/metrics/aggregate", m.c.UserID)
+	path := fmt.Sprintf("/users/%s/metrics/aggregate", m.c.userID())
 	return m.c.do(ctx, http.MethodGet, path, q, nil, out)
 }
 
@@ -58,7 +60,7 @@ func (m *MetricsActions) Insights(ctx context.Context, out any) error {
 	if err := m.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/insights", m.c.UserID)
+	path := fmt.Sprintf("/users/%s/insights", m.c.userID())
 	return m.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -66,7 +68,7 @@ func (m *MetricsActions) UpdateSession(ctx context.Context, sessionID string, bo
 	if err := m.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/intervals/%s", m.c.UserID, sessionID)
+	path := fmt.Sprintf("/users/%s/intervals/%s", m.c.userID(), sessionID)
 	return m.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
@@ -74,7 +76,7 @@ func (m *MetricsActions) DeleteSession(ctx context.Context, sessionID string) er
 	if err := m.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/intervals/%s", m.c.UserID, sessionID)
+	path := fmt.Sprintf("/users/%s/intervals/%s", m.c.userID(), sessionID)
 	return m.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
 }
 
@@ -82,6 +84,70 @@ func (m *MetricsActions) SendFeedback(ctx context.Context, body map[string]any)
 	if err := m.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/feedback", m.c.UserID)
+	path := fmt.Sprintf("/users/%s/feedback", m.c.userID())
 	return m.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
+
+// SleepInterval is one sleep session with its sensor time series.
+type SleepInterval struct {
+	ID         string          `json:"id"`
+	Start      string          `json:"ts"`
+	Score      float64         `json:"score"`
+	Incomplete bool            `json:"incomplete"` // session still in progress
+	Stages     []Stage         `json:"stages"`
+	Timeseries SleepTimeseries `json:"timeseries"`
+}
+
+// SleepTimeseries holds the per-session sensor readings, oldest first.
+type SleepTimeseries struct {
+	HeartRate       []SeriesPoint `json:"heartRate"`
+	HRV             []SeriesPoint `json:"hrv"`
+	RespiratoryRate []SeriesPoint `json:"respiratoryRate"`
+	TempBedC        []SeriesPoint `json:"tempBedC"`
+	TempRoomC       []SeriesPoint `json:"tempRoomC"`
+}
+
+// SeriesPoint is one [timestamp, value] sample.
+type SeriesPoint struct {
+	Time  time.Time
+	Value float64
+}
+
+// UnmarshalJSON decodes the API's ["2024-01-02T03:04:05.000Z", 61.5] pairs.
+// Samples of any other shape are left zero rather than failing the response.
+func (p *SeriesPoint) UnmarshalJSON(b []byte) error {
+	var pair []json.RawMessage
+	if json.Unmarshal(b, &pair) != nil || len(pair) != 2 {
+		return nil
+	}
+	var ts string
+	if json.Unmarshal(pair[0], &ts) == nil {
+		p.Time, _ = time.Parse(time.RFC3339, ts)
+	}
+	_ = json.Unmarshal(pair[1], &p.Value)
+	return nil
+}
+
+// Latest returns the newest sample in s, if any.
+func Latest(s []SeriesPoint) (SeriesPoint, bool) {
+	if len(s) == 0 {
+		return SeriesPoint{}, false
+	}
+	return s[len(s)-1], true
+}
+
+// SleepIntervals lists a user's sleep sessions, newest first.
+type SleepIntervals struct {
+	RawResponse
+	Intervals []SleepInterval `json:"intervals"`
+}
+
+// UserIntervals fetches recent sleep sessions for any user on the account,
+// e.g. the partner on the other side of the pod.
+func (c *Client) UserIntervals(ctx context.Context, userID string) (*SleepIntervals, error) {
+	if err := c.ensureToken(ctx); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/users/%s/intervals", userID)
+	return fetchModel[SleepIntervals](ctx, c, hostClientV1, http.MethodGet, path, nil)
+}
@@ -200,3 +200,37 @@ func TestMetricsActions_SendFeedback(t *testing.T) {
 		t.Errorf("expected POST, got %s", capturedMethod)
 	}
 }
+
+func TestUserIntervals(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/users/partner-1/intervals", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"intervals":[
+			{"id":"s2","incomplete":true,"stages":[{"stage":"deep","duration":600}],
+			 "timeseries":{"heartRate":[["2026-01-02T03:00:00.000Z",58],["2026-01-02T03:05:00.000Z",56.5]],"hrv":[["bad"]]}},
+			{"id":"s1","score":84,"incomplete":false}
+		]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "", "", "")
+	c.BaseURL = srv.URL
+	c.token = "t"
+	c.tokenExp = time.Now().Add(time.Hour)
+	c.HTTP = srv.Client()
+
+	res, err := c.UserIntervals(context.Background(), "partner-1")
+	if err != nil {
+		t.Fatalf("UserIntervals error: %v", err)
+	}
+	if len(res.Intervals) != 2 || res.Intervals[1].Score != 84 || !res.Intervals[0].Incomplete {
+		t.Fatalf("unexpected intervals %+v", res.Intervals)
+	}
+	hr, ok := Latest(res.Intervals[0].Timeseries.HeartRate)
+	if !ok || hr.Value != 56.5 || hr.Time.Minute() != 5 {
+		t.Errorf("latest heart rate = %+v", hr)
+	}
+	if hrv, _ := Latest(res.Intervals[0].Timeseries.HRV); hrv.Value != 0 {
+		t.Errorf("malformed sample should decode as zero, got %+v", hrv)
+	}
+}
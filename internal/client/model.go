@@ -0,0 +1,75 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/charmbracelet/log"
+)
+
+// RawResponse is embedded in typed response models. Raw holds the response
+// body exactly as the API sent it, so callers can reach fields a model does
+// not cover yet without re-fetching.
+type RawResponse struct {
+	Raw json.RawMessage `json:"-"`
+}
+
+func (r *RawResponse) setRaw(b json.RawMessage) { r.Raw = b }
+
+type rawModel[T any] interface {
+	*T
+	setRaw(json.RawMessage)
+}
+
+// fetchModel sends a request and decodes the reply into a new T, keeping the
+// full body in its Raw field.
+func fetchModel[T any, PT rawModel[T]](ctx context.Context, c *Client, host apiHost, method, path string, query url.Values) (*T, error) {
+	return fetchDecoded[T, PT](ctx, c, host, method, path, query, false)
+}
+
+// fetchResult is fetchModel for endpoints that wrap their payload in a
+// {"result": ...} envelope.
+func fetchResult[T any, PT rawModel[T]](ctx context.Context, c *Client, host apiHost, method, path string, query url.Values) (*T, error) {
+	return fetchDecoded[T, PT](ctx, c, host, method, path, query, true)
+}
+
+func fetchDecoded[T any, PT rawModel[T]](ctx context.Context, c *Client, host apiHost, method, path string, query url.Values, enveloped bool) (*T, error) {
+	var raw json.RawMessage
+	if err := c.doHost(ctx, host, method, path, query, nil, &raw); err != nil {
+		return nil, err
+	}
+	res := new(T)
+	if err := decodeModel(raw, res, enveloped); err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	PT(res).setRaw(raw)
+	return res, nil
+}
+
+// decodeModel unmarshals an API body into out. When enveloped is set the
+// payload is taken from the body's "result" object; a body without one is
+// decoded as is. A field whose type differs from the model is left at its
+// zero value and logged at debug level; the rest of the body still decodes,
+// so drift in a field nobody reads does not break the call.
+func decodeModel(raw json.RawMessage, out any, enveloped bool) error {
+	body := raw
+	if enveloped {
+		var env struct {
+			Result json.RawMessage `json:"result"`
+		}
+		if json.Unmarshal(raw, &env) == nil && bytes.HasPrefix(bytes.TrimSpace(env.Result), []byte("{")) {
+			body = env.Result
+		}
+	}
+	err := json.Unmarshal(body, out)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		log.Debug("response field type mismatch", "field", typeErr.Field, "got", typeErr.Value, "want", typeErr.Type)
+		return nil
+	}
+	return err
+}
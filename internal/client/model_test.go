@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDecodeModel_UnwrapsResultEnvelope(t *testing.T) {
+	var out DeviceOnline
+	if err := decodeModel([]byte(`{"result":{"online":true,"lastHeard":"2026-01-01T00:00:00Z"}}`), &out, true); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !out.Online || out.LastHeard != "2026-01-01T00:00:00Z" {
+		t.Errorf("envelope not unwrapped: %+v", out)
+	}
+}
+
+func TestDecodeModel_KeepsResultFieldWithoutEnvelope(t *testing.T) {
+	var out struct {
+		Result struct {
+			Online bool `json:"online"`
+		} `json:"result"`
+	}
+	if err := decodeModel([]byte(`{"result":{"online":true}}`), &out, false); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !out.Result.Online {
+		t.Errorf("result field unwrapped for a plain endpoint: %+v", out)
+	}
+}
+
+func TestDecodeModel_ToleratesUnexpectedTypes(t *testing.T) {
+	var out AudioPlayerState
+	if err := decodeModel([]byte(`{"state":"playing","volume":"loud","trackId":"t1"}`), &out, false); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if out.State != "playing" || out.TrackID != "t1" || out.Volume != 0 {
+		t.Errorf("expected other fields decoded and volume zero, got %+v", out)
+	}
+}
+
+func TestDecodeModel_RejectsMalformedJSON(t *testing.T) {
+	var out AudioPlayerState
+	if err := decodeModel([]byte(`{"state":`), &out, false); err == nil {
+		t.Fatal("expected syntax error")
+	}
+}
+
+func TestFetchModel_KeepsRawBody(t *testing.T) {
+	body := `{"state":"playing","trackId":"t1","queue":["t2","t3"]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	c := New("email", "pass", "uid-123", "", "")
+	c.BaseURL = srv.URL
+	c.token = "t"
+	c.tokenExp = time.Now().Add(time.Hour)
+	c.HTTP = srv.Client()
+
+	res, err := c.Audio().PlayerState(context.Background())
+	if err != nil {
+		t.Fatalf("PlayerState: %v", err)
+	}
+	if res.State != "playing" {
+		t.Errorf("state = %q", res.State)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(res.Raw, &raw); err != nil {
+		t.Fatalf("raw not JSON: %v", err)
+	}
+	if _, ok := raw["queue"]; !ok {
+		t.Errorf("raw body lost fields the model does not cover: %s", res.Raw)
+	}
+
+	// Raw is an escape hatch, not part of the model's own encoding.
+	enc, _ := json.Marshal(res)
+	var reencoded map[string]any
+	json.Unmarshal(enc, &reencoded)
+	if _, ok := reencoded["Raw"]; ok {
+		t.Errorf("Raw leaked into JSON encoding: %s", enc)
+	}
+}
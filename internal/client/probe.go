@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ProbeResult is the outcome of one request sent by Probe.
+type ProbeResult struct {
+	API     string // "auth-api", "client-api v1", "client-api v3", "app-api"
+	URL     string // request URL, redacted
+	Status  int    // HTTP status; 0 when no response arrived
+	Latency time.Duration
+	// ServerTime is the response Date header; zero when absent.
+	ServerTime time.Time
+	// LocalTime is the local clock halfway through the request, for
+	// comparing against ServerTime.
+	LocalTime time.Time
+	Err       error
+}
+
+// Probe sends one cheap GET to each Eight Sleep API the client uses. The
+// auth API is probed without credentials, so any response short of a 5xx
+// counts as reachable. The others are authenticated, signing in first if
+// there is no usable token, so a 401 there means the credentials or token
+// were rejected.
+func (c *Client) Probe(ctx context.Context) []ProbeResult {
+	tokenURL := c.AuthURL
+	if tokenURL == "" {
+		tokenURL = authURL
+	}
+	results := []ProbeResult{c.probe(ctx, "auth-api", tokenURL, false)}
+	if err := c.requireUser(ctx); err != nil {
+		// Every authenticated probe would fail the same way.
+		for _, api := range []string{"client-api v1", "client-api v3", "app-api"} {
+			results = append(results, ProbeResult{API: api, Err: err})
+		}
+		return results
+	}
+	uid := c.userID()
+	return append(results,
+		c.probe(ctx, "client-api v1", c.baseURL(hostClientV1)+"/users/me", true),
+		c.probe(ctx, "client-api v3", c.baseURL(hostClientV3)+fmt.Sprintf("/users/%s/subscriptions", uid), true),
+		c.probe(ctx, "app-api", c.baseURL(hostAppAPI)+fmt.Sprintf("/users/%s/away-mode", uid), true),
+	)
+}
+
+func (c *Client) probe(ctx context.Context, api, u string, authenticated bool) ProbeResult {
+	res := ProbeResult{API: api, URL: u}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	res.URL = RedactURL(req.URL)
+	start := time.Now()
+	resp, err := c.httpClient(authenticated).Do(req)
+	end := time.Now()
+	res.Latency = end.Sub(start)
+	res.LocalTime = start.Add(res.Latency / 2)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	defer resp.Body.Close()
+	res.Status = resp.StatusCode
+	if t, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		res.ServerTime = t
+	}
+	// The bare token endpoint answers GET with a 4xx; only 5xx is a failure.
+	if resp.StatusCode >= 500 || (authenticated && resp.StatusCode >= 300) {
+		res.Err = newAPIError(req, resp, req.URL.Path)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return res
+}
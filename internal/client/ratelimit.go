@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/steipete/eightctl/internal/filelock"
+)
+
+// FileLimiter is a token bucket whose state lives in a locked file, so every
+// eightctl process on the machine (CLI, daemon, mqtt, hubitat) draws from
+// the same request budget.
+type FileLimiter struct {
+	Path  string  // state file; also used as the lock
+	Rate  float64 // tokens added per second
+	Burst float64 // bucket capacity
+
+	now func() time.Time
+}
+
+type bucketState struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// NewFileLimiter returns a limiter allowing rps requests per second on
+// average with bursts of up to burst requests.
+func NewFileLimiter(path string, rps float64, burst int) *FileLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &FileLimiter{Path: path, Rate: rps, Burst: float64(burst), now: time.Now}
+}
+
+// Wait reserves one token and sleeps until it is available. Reservations
+// may drive the bucket negative, which queues callers in arrival order
+// instead of letting them race for the next refill. A wait cut short by ctx
+// hands its token back so the callers queued behind it move up.
+func (l *FileLimiter) Wait(ctx context.Context) error {
+	if l.Rate <= 0 {
+		return nil
+	}
+	wait, err := l.reserve()
+	if err != nil {
+		// A broken state file must not block API access.
+		log.Debug("rate limiter unavailable", "path", l.Path, "error", err)
+		return nil
+	}
+	if wait <= 0 {
+		return nil
+	}
+	log.Debug("rate limiter delaying request", "wait", wait.Round(time.Millisecond), "max_rps", l.Rate)
+	if err := sleepCtx(ctx, wait); err != nil {
+		if rerr := l.refund(); rerr != nil {
+			log.Debug("rate limiter refund failed", "path", l.Path, "error", rerr)
+		}
+		return err
+	}
+	return nil
+}
+
+// reserve takes one token and returns how long until it is available.
+func (l *FileLimiter) reserve() (time.Duration, error) {
+	var tokens float64
+	err := l.update(func(st *bucketState) {
+		st.Tokens--
+		tokens = st.Tokens
+	})
+	if err != nil || tokens >= 0 {
+		return 0, err
+	}
+	return time.Duration(-tokens / l.Rate * float64(time.Second)), nil
+}
+
+// refund returns a reserved token that was never used.
+func (l *FileLimiter) refund() error {
+	return l.update(func(st *bucketState) {
+		st.Tokens = math.Min(l.Burst, st.Tokens+1)
+	})
+}
+
+// update refills the bucket up to now, applies fn, and writes the state
+// back, all under the file lock.
+func (l *FileLimiter) update(fn func(*bucketState)) error {
+	f, err := filelock.Open(l.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	now := l.now()
+	st := bucketState{Tokens: l.Burst, Updated: now}
+	if data, err := io.ReadAll(f); err == nil && len(data) > 0 {
+		if err := json.Unmarshal(data, &st); err != nil {
+			st = bucketState{Tokens: l.Burst, Updated: now}
+		}
+	}
+	if elapsed := now.Sub(st.Updated).Seconds(); elapsed > 0 {
+		st.Tokens = math.Min(l.Burst, st.Tokens+elapsed*l.Rate)
+	}
+	st.Updated = now
+	fn(&st)
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err = f.WriteAt(data, 0)
+	return err
+}
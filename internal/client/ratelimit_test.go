@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileLimiter_ReserveQueuesBeyondBurst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewFileLimiter(path, 2, 2)
+	l.now = func() time.Time { return now }
+
+	want := []time.Duration{0, 0, 500 * time.Millisecond, time.Second}
+	for i, w := range want {
+		got, err := l.reserve()
+		if err != nil {
+			t.Fatalf("reserve %d: %v", i, err)
+		}
+		if got != w {
+			t.Errorf("reserve %d: expected wait %v, got %v", i, w, got)
+		}
+	}
+
+	// Two seconds later the debt is repaid and one token has accumulated.
+	now = now.Add(2 * time.Second)
+	if got, _ := l.reserve(); got != 0 {
+		t.Errorf("expected refill after idle period, got wait %v", got)
+	}
+}
+
+func TestFileLimiter_SharedBetweenInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	var mu sync.Mutex
+	var waits []time.Duration
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// A fresh limiter per goroutine stands in for a separate process.
+			l := NewFileLimiter(path, 1, 1)
+			l.now = clock
+			d, err := l.reserve()
+			if err != nil {
+				t.Errorf("reserve: %v", err)
+				return
+			}
+			mu.Lock()
+			waits = append(waits, d)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	var total time.Duration
+	for _, d := range waits {
+		total += d
+	}
+	// One free token, then 1s, 2s, 3s of queueing: 6s in total.
+	if total != 6*time.Second {
+		t.Fatalf("expected shared budget (6s total wait), got %v from %v", total, waits)
+	}
+}
+
+func TestFileLimiter_WaitHonorsContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	l := NewFileLimiter(path, 0.1, 1)
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("first wait: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected context error while throttled")
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("wait ignored context cancellation")
+	}
+}
+
+func TestFileLimiter_CanceledWaitRefundsToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewFileLimiter(path, 1, 1)
+	l.now = func() time.Time { return now }
+
+	if _, err := l.reserve(); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected context error while throttled")
+	}
+	// The canceled caller's token is back, so the next one queues for 1s, not 2s.
+	if got, _ := l.reserve(); got != time.Second {
+		t.Fatalf("expected 1s wait after refund, got %v", got)
+	}
+}
+
+func TestFileLimiter_Disabled(t *testing.T) {
+	l := NewFileLimiter(filepath.Join(t.TempDir(), "x", "ratelimit.json"), 0, 1)
+	for i := 0; i < 10; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+}
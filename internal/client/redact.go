@@ -0,0 +1,112 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// redacted replaces secrets in logs, traces, and recorded fixtures.
+const redacted = "REDACTED"
+
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// sensitiveFields are JSON keys whose values are masked wherever they appear.
+var sensitiveFields = map[string]bool{
+	"password":      true,
+	"client_secret": true,
+	"access_token":  true,
+	"refresh_token": true,
+	"token":         true,
+	"email":         true,
+	"username":      true,
+	"accesstoken":   true,
+	"refreshtoken":  true,
+	"clientsecret":  true,
+}
+
+var (
+	bearerPattern = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/=-]+`)
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// RedactHeaders returns a copy of h with credentials masked.
+func RedactHeaders(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, redacted)
+		}
+	}
+	return out
+}
+
+// RedactBody masks sensitive fields in a JSON body at any depth. Bodies that
+// are not JSON get RedactText instead.
+func RedactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return []byte(RedactText(string(body)))
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if sensitiveFields[strings.ToLower(k)] {
+				if _, isString := val.(string); isString {
+					t[k] = redacted
+					continue
+				}
+			}
+			t[k] = redactValue(val)
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = redactValue(t[i])
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// RedactText masks bearer tokens and email addresses in free-form text such
+// as HTML error pages or form bodies.
+func RedactText(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "Bearer "+redacted)
+	return emailPattern.ReplaceAllString(s, redacted)
+}
+
+// RedactURL renders u with its password and any sensitive query parameters
+// masked.
+func RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	out := *u
+	q := out.Query()
+	changed := false
+	for k := range q {
+		if sensitiveFields[strings.ToLower(k)] {
+			q.Set(k, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		out.RawQuery = q.Encode()
+	}
+	return RedactText(out.Redacted())
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret")
+	h.Set("Accept", "application/json")
+
+	out := RedactHeaders(h)
+	if out.Get("Authorization") != "REDACTED" {
+		t.Errorf("expected authorization redacted, got %q", out.Get("Authorization"))
+	}
+	if out.Get("Accept") != "application/json" {
+		t.Errorf("expected other headers preserved")
+	}
+	if h.Get("Authorization") != "Bearer secret" {
+		t.Errorf("original header mutated")
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	in := `{"email":"a@b.c","password":"pw","user":{"userId":"u1","email":"x@y.z"},"sessions":[{"token":"t1"}],"count":3}`
+	var got map[string]any
+	if err := json.Unmarshal(RedactBody([]byte(in)), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got["email"] != "REDACTED" || got["password"] != "REDACTED" {
+		t.Errorf("top-level secrets not redacted: %v", got)
+	}
+	user := got["user"].(map[string]any)
+	if user["email"] != "REDACTED" || user["userId"] != "u1" {
+		t.Errorf("nested fields wrong: %v", user)
+	}
+	session := got["sessions"].([]any)[0].(map[string]any)
+	if session["token"] != "REDACTED" {
+		t.Errorf("array element not redacted: %v", session)
+	}
+	if got["count"] != float64(3) {
+		t.Errorf("non-sensitive field changed: %v", got["count"])
+	}
+}
+
+func TestRedactBody_NotJSON(t *testing.T) {
+	in := []byte("Cannot GET /v1/foo")
+	if string(RedactBody(in)) != string(in) {
+		t.Error("expected non-JSON body unchanged")
+	}
+}
+
+func TestRedactText(t *testing.T) {
+	in := "Authorization: Bearer abc.def-123 for sleeper@example.com"
+	want := "Authorization: Bearer REDACTED for REDACTED"
+	if got := RedactText(in); got != want {
+		t.Errorf("RedactText = %q, want %q", got, want)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	u, _ := url.Parse("https://user:pw@api.example.com/v1/users?email=a%40b.c&limit=5")
+	got := RedactURL(u)
+	if strings.Contains(got, "pw@") || strings.Contains(got, "a%40b.c") || !strings.Contains(got, "limit=5") {
+		t.Errorf("RedactURL = %q", got)
+	}
+	if !strings.Contains(got, "email=REDACTED") {
+		t.Errorf("email query not masked: %q", got)
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Refresher defaults.
+const (
+	DefaultRefreshLead       = 10 * time.Minute
+	DefaultRefreshRetryDelay = time.Minute
+	DefaultRefreshAlertAfter = 3
+)
+
+// Refresher renews a long-running process's token before it expires, so the
+// daemon and bridges never send a burst of requests with a dead token at
+// rollover. Zero fields use the Default* constants.
+type Refresher struct {
+	Client     *Client
+	Lead       time.Duration // renew this long before the token expires
+	RetryDelay time.Duration // wait after a failed renewal, doubled up to Lead
+	AlertAfter int           // consecutive failures before OnFailure fires
+
+	// OnFailure, if set, is called every AlertAfter consecutive failures
+	// in addition to the warning log.
+	OnFailure func(err error, failures int)
+}
+
+// Run blocks until ctx is cancelled, renewing the token as it nears expiry.
+func (r *Refresher) Run(ctx context.Context) error {
+	lead := durationOr(r.Lead, DefaultRefreshLead)
+	retryDelay := durationOr(r.RetryDelay, DefaultRefreshRetryDelay)
+	alertAfter := r.AlertAfter
+	if alertAfter <= 0 {
+		alertAfter = DefaultRefreshAlertAfter
+	}
+
+	failures := 0
+	refreshed := false
+	for {
+		var wait time.Duration
+		exp := r.Client.TokenExpiry()
+		switch {
+		case failures > 0:
+			wait = min(retryDelay<<min(failures-1, 16), lead)
+		case exp.IsZero():
+			wait = 0
+		default:
+			remaining := time.Until(exp)
+			// Tokens that live shorter than lead renew at half-life.
+			wait = max(remaining-lead, remaining/2)
+			if refreshed && wait <= 0 {
+				// A server handing out already-expired tokens must not spin us.
+				wait = retryDelay
+			}
+		}
+		if err := sleepCtx(ctx, max(wait, 0)); err != nil {
+			return nil
+		}
+
+		var err error
+		if exp.IsZero() {
+			// Nothing loaded yet: use the cache before forcing a sign-in.
+			err = r.Client.ensureToken(ctx)
+		} else {
+			err = r.Client.Refresh(ctx)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			if failures > 0 {
+				log.Info("token refresh recovered", "after_failures", failures)
+			}
+			failures = 0
+			refreshed = true
+			log.Debug("token refreshed", "expires_at", r.Client.TokenExpiry())
+			continue
+		}
+
+		failures++
+		log.Debug("token refresh failed", "attempt", failures, "error", err)
+		if failures%alertAfter == 0 {
+			log.Warn("token refresh keeps failing", "failures", failures, "expires_at", r.Client.TokenExpiry(), "error", err)
+			if r.OnFailure != nil {
+				r.OnFailure(err, failures)
+			}
+		}
+	}
+}
+
+func durationOr(d, def time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return def
+}
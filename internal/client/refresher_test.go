@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+// grantServer records the grant types it receives and issues rotating tokens.
+type grantServer struct {
+	mu     sync.Mutex
+	grants []string
+	fail   bool
+}
+
+func (g *grantServer) handler(w http.ResponseWriter, r *http.Request) {
+	var body map[string]string
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.grants = append(g.grants, body["grant_type"])
+	if g.fail {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	if body["grant_type"] == "refresh_token" && body["refresh_token"] != "refresh-1" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token":  "access-" + body["grant_type"],
+		"refresh_token": "refresh-1",
+		"expires_in":    3600,
+		"userId":        "uid",
+	})
+}
+
+func (g *grantServer) seen() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]string(nil), g.grants...)
+}
+
+func newGrantClient(t *testing.T, g *grantServer) *Client {
+	t.Helper()
+	useTempKeyring(t)
+	srv := httptest.NewServer(http.HandlerFunc(g.handler))
+	t.Cleanup(srv.Close)
+	c := New("email", "pass", "", "", "")
+	c.BaseURL = srv.URL + "/v1"
+	c.AuthURL = srv.URL + "/v1/tokens"
+	c.HTTP = srv.Client()
+	return c
+}
+
+func TestRefresh_UsesRefreshTokenGrant(t *testing.T) {
+	g := &grantServer{}
+	c := newGrantClient(t, g)
+	ctx := context.Background()
+
+	if err := c.Authenticate(ctx); err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	got := g.seen()
+	if len(got) != 2 || got[0] != "password" || got[1] != "refresh_token" {
+		t.Fatalf("unexpected grants %v", got)
+	}
+
+	cached, err := tokencache.Load(c.Identity(), "")
+	if err != nil {
+		t.Fatalf("load cache: %v", err)
+	}
+	if cached.Token != "access-refresh_token" || cached.RefreshToken != "refresh-1" {
+		t.Fatalf("renewed token not written back: %+v", cached)
+	}
+}
+
+func TestRefresh_FallsBackToPassword(t *testing.T) {
+	g := &grantServer{}
+	c := newGrantClient(t, g)
+	c.refreshToken = "revoked"
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	got := g.seen()
+	if len(got) != 2 || got[0] != "refresh_token" || got[1] != "password" {
+		t.Fatalf("expected refresh then password grant, got %v", got)
+	}
+}
+
+func TestRefresher_RenewsAheadOfExpiry(t *testing.T) {
+	g := &grantServer{}
+	c := newGrantClient(t, g)
+	c.token = "old"
+	c.tokenExp = time.Now().Add(200 * time.Millisecond)
+	c.refreshToken = "refresh-1"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		(&Refresher{Client: c, Lead: 150 * time.Millisecond}).Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(g.seen()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if got := g.seen(); len(got) != 1 || got[0] != "refresh_token" {
+		t.Fatalf("expected one refresh grant, got %v", got)
+	}
+	if tok, _ := c.bearer(context.Background()); tok != "access-refresh_token" {
+		t.Fatalf("token not renewed, got %q", tok)
+	}
+}
+
+func TestRefresher_AlertsOnRepeatedFailure(t *testing.T) {
+	g := &grantServer{fail: true}
+	c := newGrantClient(t, g)
+	c.Retry.MaxAttempts = 1
+	c.token = "old"
+	c.tokenExp = time.Now().Add(20 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	alerts := make(chan int, 1)
+	r := &Refresher{
+		Client:     c,
+		RetryDelay: time.Millisecond,
+		AlertAfter: 2,
+		OnFailure: func(err error, failures int) {
+			select {
+			case alerts <- failures:
+			default:
+			}
+		},
+	}
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case n := <-alerts:
+		if n != 2 {
+			t.Errorf("expected alert after 2 failures, got %d", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("no failure alert")
+	}
+	// Stop the refresher before the test keyring is torn down.
+	cancel()
+	<-done
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// RetryPolicy bounds how rate-limited (429) requests are retried.
+type RetryPolicy struct {
+	MaxAttempts int           // total tries including the first; <=1 disables retries
+	BaseDelay   time.Duration // delay before the first retry, doubled each attempt
+	MaxDelay    time.Duration // upper bound for any single wait, including Retry-After
+	Jitter      float64       // fraction of the delay added at random (0.2 = up to +20%)
+}
+
+// DefaultRetryPolicy waits 2s, 4s, 8s between four attempts.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   2 * time.Second,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.2,
+}
+
+// Delay returns how long to wait before retry number attempt (1-based).
+// A Retry-After header on resp takes precedence over exponential backoff.
+func (p RetryPolicy) Delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return p.clamp(d)
+		}
+	}
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.Jitter > 0 {
+		d += time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return p.clamp(d)
+}
+
+func (p RetryPolicy) clamp(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// parseRetryAfter understands both delay-seconds and HTTP-date forms.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t.Sub(now), true
+	}
+	return 0, false
+}
+
+// Retry resends 429 responses according to p, honoring Retry-After and
+// aborting as soon as the request context is cancelled. Once attempts are
+// exhausted the last 429 response is returned to the caller.
+func Retry(p RetryPolicy) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			for attempt := 1; ; attempt++ {
+				resp, err := next.RoundTrip(req)
+				if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= p.MaxAttempts {
+					return resp, err
+				}
+				retry, err := rewind(req)
+				if err != nil {
+					return resp, nil
+				}
+				delay := p.Delay(attempt, resp)
+				drain(resp)
+				log.Debug("rate limited, retrying", "method", req.Method, "url", RedactURL(req.URL), "attempt", attempt, "delay", delay)
+				if err := sleepCtx(req.Context(), delay); err != nil {
+					return nil, err
+				}
+				req = retry
+			}
+		})
+	}
+}
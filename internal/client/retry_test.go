@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_DelayBackoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 5 * time.Second}, // capped
+		{10, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.Delay(tt.attempt, nil); got != tt.want {
+			t.Errorf("attempt %d: expected %v, got %v", tt.attempt, tt.want, got)
+		}
+	}
+}
+
+func TestRetryPolicy_DelayJitter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, Jitter: 0.5}
+	for i := 0; i < 50; i++ {
+		d := p.Delay(1, nil)
+		if d < time.Second || d > 1500*time.Millisecond {
+			t.Fatalf("jittered delay out of range: %v", d)
+		}
+	}
+}
+
+func TestRetryPolicy_DelayRetryAfter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if got := p.Delay(1, resp); got != 7*time.Second {
+		t.Errorf("expected Retry-After seconds honored, got %v", got)
+	}
+
+	resp.Header.Set("Retry-After", "120")
+	if got := p.Delay(1, resp); got != 10*time.Second {
+		t.Errorf("expected Retry-After capped at MaxDelay, got %v", got)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(3*time.Second).UTC().Format(http.TimeFormat))
+	if got := p.Delay(1, resp); got <= time.Second || got > 3*time.Second {
+		t.Errorf("expected HTTP-date Retry-After honored, got %v", got)
+	}
+}
+
+func TestRetry_ReplaysBody(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond}
+	hc := &http.Client{Transport: Chain(srv.Client().Transport, Retry(p))}
+	resp, err := hc.Post(srv.URL, "application/json", strings.NewReader(`{"on":true}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 after retry, got %d", resp.StatusCode)
+	}
+	if len(bodies) != 2 || bodies[1] != `{"on":true}` {
+		t.Fatalf("expected body replayed on retry, got %q", bodies)
+	}
+}
+
+func TestRetry_MaxAttempts(t *testing.T) {
+	count := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	p := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+	hc := &http.Client{Transport: Chain(srv.Client().Transport, Retry(p))}
+	resp, err := hc.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected final 429 returned, got %d", resp.StatusCode)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 attempts, got %d", count)
+	}
+}
+
+func TestRetry_ContextCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 5 * time.Second}
+	hc := &http.Client{Transport: Chain(srv.Client().Transport, Retry(p))}
+
+	start := time.Now()
+	if _, err := hc.Do(req); err == nil {
+		t.Fatal("expected error after context cancellation")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("retry ignored context, took %v", elapsed)
+	}
+}
+
+func TestAuthLegacyLogin_RateLimitedGivesUp(t *testing.T) {
+	count := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "", "", "")
+	c.BaseURL = srv.URL
+	c.HTTP = srv.Client()
+	c.Retry = RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+
+	err := c.authLegacyLogin(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "rate limited after 2 attempts") {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 login attempts, got %d", count)
+	}
+}
@@ -19,7 +19,7 @@ func (c *Client) ListSchedules(ctx context.Context) ([]TemperatureSchedule, erro
 	if err := c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/temperature/schedules", c.UserID)
+	path := fmt.Sprintf("/users/%s/temperature/schedules", c.userID())
 	var res struct {
 		Schedules []TemperatureSchedule `json:"schedules"`
 	}
@@ -33,7 +33,7 @@ func (c *Client) CreateSchedule(ctx context.Context, s TemperatureSchedule) (*Te
 	if err := c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/temperature/schedules", c.UserID)
+	path := fmt.Sprintf("/users/%s/temperature/schedules", c.userID())
 	var res struct {
 		Schedule TemperatureSchedule `json:"schedule"`
 	}
@@ -47,7 +47,7 @@ func (c *Client) UpdateSchedule(ctx context.Context, id string, patch map[string
 	if err := c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/temperature/schedules/%s", c.UserID, id)
+	path := fmt.Sprintf("/users/%s/temperature/schedules/%s", c.userID(), id)
 	var res struct {
 		Schedule TemperatureSchedule `json:"schedule"`
 	}
@@ -61,6 +61,6 @@ func (c *Client) DeleteSchedule(ctx context.Context, id string) error {
 	if err := c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/temperature/schedules/%s", c.UserID, id)
+	path := fmt.Sprintf("/users/%s/temperature/schedules/%s", c.userID(), id)
 	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
 }
@@ -13,15 +13,28 @@ type SettingsActions struct{ c *Client }
 // Settings helper accessor.
 func (c *Client) Settings() *SettingsActions { return &SettingsActions{c: c} }
 
+// TapAction is what a tap gesture on the pod cover does.
+type TapAction struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// TapSettings maps tap gestures to actions for one device.
+type TapSettings struct {
+	Enabled   bool      `json:"enabled"`
+	DoubleTap TapAction `json:"doubleTap"`
+	TripleTap TapAction `json:"tripleTap"`
+	QuadTap   TapAction `json:"quadTap"`
+	RawResponse
+}
+
 // TapSettings returns tap gesture settings for the specified device.
-func (s *SettingsActions) TapSettings(ctx context.Context, deviceID string) (any, error) {
+func (s *SettingsActions) TapSettings(ctx context.Context, deviceID string) (*TapSettings, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/devices/%s/tap-settings", s.c.UserID, deviceID)
-	var res any
-	err := s.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/devices/%s/tap-settings", s.c.userID(), deviceID)
+	return fetchModel[TapSettings](ctx, s.c, hostClientV1, http.MethodGet, path, nil)
 }
 
 // UpdateTapSettings updates tap gesture settings for the specified device.
@@ -29,92 +42,172 @@ func (s *SettingsActions) UpdateTapSettings(ctx context.Context, deviceID string
 	if err := s.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/devices/%s/tap-settings", s.c.UserID, deviceID)
+	path := fmt.Sprintf("/users/%s/devices/%s/tap-settings", s.c.userID(), deviceID)
 	return s.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
+// TapEvent is one recorded tap gesture.
+type TapEvent struct {
+	Timestamp string `json:"timestamp"`
+	Gesture   string `json:"gesture"`
+	Action    string `json:"action"`
+	Side      string `json:"side"`
+}
+
+// TapHistory lists recent tap gestures.
+type TapHistory struct {
+	History []TapEvent `json:"history"`
+	RawResponse
+}
+
 // TapHistory returns the user's tap gesture history.
-func (s *SettingsActions) TapHistory(ctx context.Context, from string) (any, error) {
+func (s *SettingsActions) TapHistory(ctx context.Context, from string) (*TapHistory, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/tap-history", s.c.UserID)
+	path := fmt.Sprintf("/users/%s/tap-history", s.c.userID())
 	q := url.Values{"from": []string{from}}
-	var res any
-	err := s.c.do(ctx, http.MethodGet, path, q, nil, &res)
-	return res, err
+	return fetchModel[TapHistory](ctx, s.c, hostClientV1, http.MethodGet, path, q)
+}
+
+// LevelSuggestion is a recommended temperature level for a sleep stage.
+type LevelSuggestion struct {
+	Stage          string `json:"stage"`
+	CurrentLevel   int    `json:"currentLevel"`
+	SuggestedLevel int    `json:"suggestedLevel"`
+}
+
+// LevelSuggestions lists the user's temperature suggestions.
+type LevelSuggestions struct {
+	Suggestions []LevelSuggestion `json:"suggestions"`
+	RawResponse
 }
 
 // LevelSuggestions returns temperature level suggestions for the user.
-func (s *SettingsActions) LevelSuggestions(ctx context.Context) (any, error) {
+func (s *SettingsActions) LevelSuggestions(ctx context.Context) (*LevelSuggestions, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/level-suggestions", s.c.UserID)
-	var res any
-	err := s.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/level-suggestions", s.c.userID())
+	return fetchModel[LevelSuggestions](ctx, s.c, hostClientV1, http.MethodGet, path, nil)
+}
+
+// BlanketRecommendation suggests bedding for a temperature range.
+type BlanketRecommendation struct {
+	Blanket string `json:"blanket"`
+	Season  string `json:"season"`
+	Reason  string `json:"reason"`
+}
+
+// BlanketRecommendations lists the user's bedding recommendations.
+type BlanketRecommendations struct {
+	Recommendations []BlanketRecommendation `json:"recommendations"`
+	RawResponse
 }
 
 // BlanketRecommendations returns blanket temperature recommendations.
-func (s *SettingsActions) BlanketRecommendations(ctx context.Context) (any, error) {
+func (s *SettingsActions) BlanketRecommendations(ctx context.Context) (*BlanketRecommendations, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/recommendations/blanket", s.c.UserID)
-	var res any
-	err := s.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/recommendations/blanket", s.c.userID())
+	return fetchModel[BlanketRecommendations](ctx, s.c, hostClientV1, http.MethodGet, path, nil)
+}
+
+// Perk is a member benefit offered to the user.
+type Perk struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+// Perks lists the user's member perks.
+type Perks struct {
+	Perks []Perk `json:"perks"`
+	RawResponse
 }
 
 // Perks returns member perks for the user.
-func (s *SettingsActions) Perks(ctx context.Context) (any, error) {
+func (s *SettingsActions) Perks(ctx context.Context) (*Perks, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/perks", s.c.UserID)
-	var res any
-	err := s.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/perks", s.c.userID())
+	return fetchModel[Perks](ctx, s.c, hostClientV1, http.MethodGet, path, nil)
+}
+
+// ReferralLink is the user's personal referral URL.
+type ReferralLink struct {
+	Link string `json:"link"`
+	RawResponse
 }
 
 // GetReferralLink generates/retrieves the user's personal referral link (v2 API).
-func (s *SettingsActions) GetReferralLink(ctx context.Context) (any, error) {
+func (s *SettingsActions) GetReferralLink(ctx context.Context) (*ReferralLink, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/v2/users/%s/referral/personal-referral-link", s.c.UserID)
-	var res any
-	err := s.c.do(ctx, http.MethodPut, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/v2/users/%s/referral/personal-referral-link", s.c.userID())
+	return fetchModel[ReferralLink](ctx, s.c, hostClientV1, http.MethodPut, path, nil)
+}
+
+// ReferralCampaign is an active referral offer.
+type ReferralCampaign struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	EndDate     string `json:"endDate"`
+}
+
+// ReferralCampaigns lists active referral offers.
+type ReferralCampaigns struct {
+	Campaigns []ReferralCampaign `json:"campaigns"`
+	RawResponse
 }
 
 // ReferralCampaigns returns available referral campaigns (v2 API).
-func (s *SettingsActions) ReferralCampaigns(ctx context.Context) (any, error) {
+func (s *SettingsActions) ReferralCampaigns(ctx context.Context) (*ReferralCampaigns, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/v2/users/%s/referral/campaigns", s.c.UserID)
-	var res any
-	err := s.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/v2/users/%s/referral/campaigns", s.c.userID())
+	return fetchModel[ReferralCampaigns](ctx, s.c, hostClientV1, http.MethodGet, path, nil)
+}
+
+// Purchase is an order tracked for the account.
+type Purchase struct {
+	ID        string `json:"id"`
+	Product   string `json:"product"`
+	Status    string `json:"status"`
+	OrderDate string `json:"orderDate"`
+}
+
+// Purchases lists the account's tracked orders.
+type Purchases struct {
+	Purchases []Purchase `json:"purchases"`
+	RawResponse
 }
 
 // Purchases returns purchase tracker information.
-func (s *SettingsActions) Purchases(ctx context.Context) (any, error) {
-	var res any
-	err := s.c.do(ctx, http.MethodGet, "/purchase-tracker", nil, nil, &res)
-	return res, err
+func (s *SettingsActions) Purchases(ctx context.Context) (*Purchases, error) {
+	return fetchModel[Purchases](ctx, s.c, hostClientV1, http.MethodGet, "/purchase-tracker", nil)
+}
+
+// MaintenanceInsertStatus reports whether the pod's maintenance insert is due.
+type MaintenanceInsertStatus struct {
+	Status      string `json:"status"`
+	LastChanged string `json:"lastChanged"`
+	NextDue     string `json:"nextDue"`
+	RawResponse
 }
 
 // MaintenanceInsertStatus returns device maintenance insert status.
-func (s *SettingsActions) MaintenanceInsertStatus(ctx context.Context) (any, error) {
+func (s *SettingsActions) MaintenanceInsertStatus(ctx context.Context) (*MaintenanceInsertStatus, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/user/%s/device_maintenance/maintenance_insert", s.c.UserID)
+	path := fmt.Sprintf("/user/%s/device_maintenance/maintenance_insert", s.c.userID())
 	q := url.Values{"v": []string{"2"}}
-	var res any
-	err := s.c.do(ctx, http.MethodGet, path, q, nil, &res)
-	return res, err
+	return fetchModel[MaintenanceInsertStatus](ctx, s.c, hostClientV1, http.MethodGet, path, q)
 }
@@ -15,7 +15,7 @@ func (s *SMSActions) GetSettings(ctx context.Context) (any, error) {
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/sms/users/%s", s.c.UserID)
+	path := fmt.Sprintf("/sms/users/%s", s.c.userID())
 	var res any
 	err := s.c.do(ctx, http.MethodGet, path, nil, nil, &res)
 	return res, err
@@ -26,7 +26,7 @@ func (s *SMSActions) UpdateSettings(ctx context.Context, settings any) (any, err
 	if err := s.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/sms/users/%s", s.c.UserID)
+	path := fmt.Sprintf("/sms/users/%s", s.c.userID())
 	var res any
 	err := s.c.do(ctx, http.MethodPut, path, nil, settings, &res)
 	return res, err
@@ -37,7 +37,7 @@ func (s *SMSActions) RequestVerificationCode(ctx context.Context, phoneNumber st
 	if err := s.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/sms/users/%s/verify", s.c.UserID)
+	path := fmt.Sprintf("/sms/users/%s/verify", s.c.userID())
 	body := map[string]any{"phoneNumber": phoneNumber}
 	return s.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -47,7 +47,7 @@ func (s *SMSActions) VerifyPhoneNumber(ctx context.Context, phoneNumber, code st
 	if err := s.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/sms/users/%s/verify", s.c.UserID)
+	path := fmt.Sprintf("/sms/users/%s/verify", s.c.userID())
 	body := map[string]any{"phoneNumber": phoneNumber, "code": code}
 	return s.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
@@ -20,7 +20,7 @@ func (s *SubscriptionsActions) Subscriptions(ctx context.Context, out any) error
 	if err := s.c.requireUser(ctx); err != nil {
 		return err
 	}
-	return s.c.doV3(ctx, http.MethodGet, fmt.Sprintf("/users/%s/subscriptions", s.c.UserID), nil, nil, out)
+	return s.c.doV3(ctx, http.MethodGet, fmt.Sprintf("/users/%s/subscriptions", s.c.userID()), nil, nil, out)
 }
 
 // CreateTemporarySubscription creates a temporary subscription.
@@ -29,7 +29,7 @@ func (s *SubscriptionsActions) CreateTemporarySubscription(ctx context.Context,
 	if err := s.c.requireUser(ctx); err != nil {
 		return err
 	}
-	return s.c.doV3(ctx, http.MethodPost, fmt.Sprintf("/users/%s/subscriptions/temporary", s.c.UserID), nil, body, nil)
+	return s.c.doV3(ctx, http.MethodPost, fmt.Sprintf("/users/%s/subscriptions/temporary", s.c.userID()), nil, body, nil)
 }
 
 // RedeemSubscription redeems a subscription code.
@@ -38,5 +38,5 @@ func (s *SubscriptionsActions) RedeemSubscription(ctx context.Context, body map[
 	if err := s.c.requireUser(ctx); err != nil {
 		return err
 	}
-	return s.c.doV3(ctx, http.MethodPost, fmt.Sprintf("/users/%s/subscriptions/redeem", s.c.UserID), nil, body, nil)
+	return s.c.doV3(ctx, http.MethodPost, fmt.Sprintf("/users/%s/subscriptions/redeem", s.c.userID()), nil, body, nil)
 }
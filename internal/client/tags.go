@@ -24,7 +24,7 @@ func (t *TagsActions) GetTags(ctx context.Context, from, to string) (any, error)
 	if to != "" {
 		q.Set("to", to)
 	}
-	path := fmt.Sprintf("/users/%s/tags", t.c.UserID)
+	path := fmt.Sprintf("/users/%s/tags", t.c.userID())
 	var res any
 	err := t.c.do(ctx, http.MethodGet, path, q, nil, &res)
 	return res, err
@@ -36,7 +36,7 @@ func (t *TagsActions) SaveTags(ctx context.Context, day string, tags any) error
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/days/%s/tags", t.c.UserID, day)
+	path := fmt.Sprintf("/users/%s/days/%s/tags", t.c.userID(), day)
 	return t.c.do(ctx, http.MethodPut, path, nil, tags, nil)
 }
 
@@ -47,7 +47,7 @@ func (t *TagsActions) GetPresenceTags(ctx context.Context) (any, error) {
 	if err := t.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/truth-tags", t.c.UserID)
+	path := fmt.Sprintf("/users/%s/truth-tags", t.c.userID())
 	var res any
 	err := t.c.do(ctx, http.MethodGet, path, nil, nil, &res)
 	return res, err
@@ -58,7 +58,7 @@ func (t *TagsActions) CreatePresenceTag(ctx context.Context, tag any) (any, erro
 	if err := t.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/truth-tags", t.c.UserID)
+	path := fmt.Sprintf("/users/%s/truth-tags", t.c.userID())
 	var res any
 	err := t.c.do(ctx, http.MethodPost, path, nil, tag, &res)
 	return res, err
@@ -69,7 +69,7 @@ func (t *TagsActions) UpdatePresenceTag(ctx context.Context, tagID string, tag a
 	if err := t.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/truth-tags/%s", t.c.UserID, tagID)
+	path := fmt.Sprintf("/users/%s/truth-tags/%s", t.c.userID(), tagID)
 	var res any
 	err := t.c.do(ctx, http.MethodPut, path, nil, tag, &res)
 	return res, err
@@ -80,6 +80,6 @@ func (t *TagsActions) DeletePresenceTag(ctx context.Context, tagID string) error
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/truth-tags/%s", t.c.UserID, tagID)
+	path := fmt.Sprintf("/users/%s/truth-tags/%s", t.c.userID(), tagID)
 	return t.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
 }
@@ -74,7 +74,7 @@ func (t *TempModes) TempEvents(ctx context.Context, from, to string, out any) er
 	if to != "" {
 		q.Set("to", to)
 	}
-	path := fmt.Sprintf("/users/%s/temp-events", t.c.UserID)
+	path := fmt.Sprintf("/users/%s/temp-events", t.c.userID())
 	return t.c.do(ctx, http.MethodGet, path, q, nil, out)
 }
 
@@ -82,7 +82,7 @@ func (t *TempModes) simplePost(ctx context.Context, suffix string) error {
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s%s", t.c.UserID, suffix)
+	path := fmt.Sprintf("/users/%s%s", t.c.userID(), suffix)
 	return t.c.do(ctx, http.MethodPost, path, nil, map[string]string{}, nil)
 }
 
@@ -90,7 +90,7 @@ func (t *TempModes) simpleGet(ctx context.Context, suffix string, out any) error
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s%s", t.c.UserID, suffix)
+	path := fmt.Sprintf("/users/%s%s", t.c.userID(), suffix)
 	return t.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -98,7 +98,7 @@ func (t *TempModes) simplePut(ctx context.Context, suffix string, body any) erro
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s%s", t.c.UserID, suffix)
+	path := fmt.Sprintf("/users/%s%s", t.c.userID(), suffix)
 	return t.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
@@ -106,6 +106,6 @@ func (t *TempModes) simpleDelete(ctx context.Context, suffix string) error {
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s%s", t.c.UserID, suffix)
+	path := fmt.Sprintf("/users/%s%s", t.c.userID(), suffix)
 	return t.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
 }
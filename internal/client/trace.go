@@ -0,0 +1,386 @@
+package client
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// HAR 1.2 types, trimmed to the fields eightctl fills in.
+// See http://www.softwareishard.com/blog/har-12-spec/.
+type (
+	harLog struct {
+		Log harBody `json:"log"`
+	}
+	harBody struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	}
+	harCreator struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	harEntry struct {
+		StartedDateTime string      `json:"startedDateTime"`
+		Time            float64     `json:"time"`
+		Request         harRequest  `json:"request"`
+		Response        harResponse `json:"response"`
+		Cache           struct{}    `json:"cache"`
+		Timings         harTimings  `json:"timings"`
+		Comment         string      `json:"comment,omitempty"`
+	}
+	harRequest struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		QueryString []harNameValue `json:"queryString"`
+		PostData    *harPostData   `json:"postData,omitempty"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+	harResponse struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Cookies     []harNameValue `json:"cookies"`
+		Headers     []harNameValue `json:"headers"`
+		Content     harContent     `json:"content"`
+		RedirectURL string         `json:"redirectURL"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int            `json:"bodySize"`
+	}
+	harNameValue struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	}
+	harPostData struct {
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text"`
+	}
+	harContent struct {
+		Size     int    `json:"size"`
+		MimeType string `json:"mimeType"`
+		Text     string `json:"text,omitempty"`
+	}
+	// harTimings are in milliseconds; -1 means the phase did not happen,
+	// e.g. dns/connect/ssl on a reused connection.
+	harTimings struct {
+		Blocked float64 `json:"blocked"`
+		DNS     float64 `json:"dns"`
+		Connect float64 `json:"connect"`
+		SSL     float64 `json:"ssl"`
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+	}
+)
+
+// Trace writes every exchange passing through it to a HAR 1.2 file at path,
+// with timings. Headers, bodies, and URLs are redacted like fixtures. Each
+// exchange is appended in place of the closing brackets, which are written
+// back after it, so the file stays valid if the process is killed and a
+// long-running command does not hold its history in memory. Clients in one
+// process tracing to the same path share a file. A failed trace write is
+// logged once and never fails the request it describes.
+func Trace(path string) Middleware {
+	t := openTracer(path)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			reqBody, err := readRequestBody(req)
+			if err != nil {
+				return nil, err
+			}
+			ph := &phases{}
+			start := time.Now()
+			resp, err := next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), ph.clientTrace())))
+			if err != nil {
+				t.record(harEntryFor(req, reqBody, nil, nil, start, ph, time.Now(), err))
+				return nil, err
+			}
+			respBody, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			end := time.Now()
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(respBody))
+			t.record(harEntryFor(req, reqBody, resp, respBody, start, ph, end, nil))
+			return resp, nil
+		})
+	}
+}
+
+var (
+	tracersMu sync.Mutex
+	tracers   = map[string]*tracer{}
+)
+
+// openTracer returns the process-wide tracer for path. The first call in a
+// process starts a fresh file.
+func openTracer(path string) *tracer {
+	tracersMu.Lock()
+	defer tracersMu.Unlock()
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if t, ok := tracers[path]; ok {
+		return t
+	}
+	t := &tracer{path: path}
+	tracers[path] = t
+	return t
+}
+
+type tracer struct {
+	path   string
+	mu     sync.Mutex
+	n      int   // entries written
+	end    int64 // offset of the closing brackets
+	warned bool  // a write error has been logged
+}
+
+// harEntryIndent is the indentation of an entry in the entries array.
+const harEntryIndent = "      "
+
+// harClose closes the entries array and the document.
+const harClose = "\n    ]\n  }\n}\n"
+
+// record appends e, warning about the first failure only so a full disk
+// does not flood the log on every request.
+func (t *tracer) record(e harEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.add(e); err != nil && !t.warned {
+		t.warned = true
+		log.Warn("write trace failed; requests continue untraced", "path", t.path, "error", err)
+	}
+}
+
+// add writes e to the file. Callers must hold mu.
+func (t *tracer) add(e harEntry) error {
+	entry, err := json.MarshalIndent(e, harEntryIndent, "  ")
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if t.n == 0 {
+		head, err := harHead()
+		if err != nil {
+			return err
+		}
+		if dir := filepath.Dir(t.path); dir != "" {
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				return err
+			}
+		}
+		buf.Write(head)
+	} else {
+		buf.WriteByte(',')
+	}
+	buf.WriteString("\n" + harEntryIndent)
+	buf.Write(entry)
+	flags := os.O_WRONLY | os.O_CREATE
+	if t.n == 0 {
+		flags |= os.O_TRUNC // first exchange of this process starts a fresh file
+	}
+	f, err := os.OpenFile(t.path, flags, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.WriteAt(append(buf.Bytes(), harClose...), t.end); err != nil {
+		return err
+	}
+	t.end += int64(buf.Len())
+	t.n++
+	return f.Close()
+}
+
+// harHead returns the document up to and including the entries array's
+// opening bracket.
+func harHead() ([]byte, error) {
+	doc := harLog{Log: harBody{
+		Version: "1.2",
+		Creator: harCreator{Name: "eightctl", Version: buildVersion()},
+		Entries: []harEntry{},
+	}}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	i := bytes.LastIndex(data, []byte("[]"))
+	if i < 0 {
+		return nil, fmt.Errorf("unexpected HAR header %s", data)
+	}
+	return data[:i+1], nil
+}
+
+func buildVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "devel"
+}
+
+// phases records connection milestones reported by httptrace.
+type phases struct {
+	mu                       sync.Mutex
+	getConn, gotConn         time.Time
+	dnsStart, dnsDone        time.Time
+	connectStart, connectEnd time.Time
+	tlsStart, tlsDone        time.Time
+	wroteRequest, firstByte  time.Time
+}
+
+func (p *phases) clientTrace() *httptrace.ClientTrace {
+	mark := func(t *time.Time) {
+		p.mu.Lock()
+		if t.IsZero() {
+			*t = time.Now()
+		}
+		p.mu.Unlock()
+	}
+	return &httptrace.ClientTrace{
+		GetConn:              func(string) { mark(&p.getConn) },
+		GotConn:              func(httptrace.GotConnInfo) { mark(&p.gotConn) },
+		DNSStart:             func(httptrace.DNSStartInfo) { mark(&p.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { mark(&p.dnsDone) },
+		ConnectStart:         func(string, string) { mark(&p.connectStart) },
+		ConnectDone:          func(string, string, error) { mark(&p.connectEnd) },
+		TLSHandshakeStart:    func() { mark(&p.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { mark(&p.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { mark(&p.wroteRequest) },
+		GotFirstResponseByte: func() { mark(&p.firstByte) },
+	}
+}
+
+// timings splits start..end into HAR phases. Phases the transport did not
+// report (e.g. replayed fixtures) leave the whole duration in wait.
+func (p *phases) timings(start, end time.Time) harTimings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	span := func(from, to time.Time) float64 {
+		if from.IsZero() || to.IsZero() {
+			return -1
+		}
+		return ms(to.Sub(from))
+	}
+	t := harTimings{
+		Blocked: -1,
+		DNS:     span(p.dnsStart, p.dnsDone),
+		Connect: span(p.connectStart, p.connectEnd),
+		SSL:     span(p.tlsStart, p.tlsDone),
+	}
+	if t.SSL > 0 && t.Connect >= 0 {
+		// HAR 1.2 includes ssl in connect.
+		t.Connect += t.SSL
+	}
+	if p.wroteRequest.IsZero() || p.firstByte.IsZero() {
+		t.Send, t.Wait, t.Receive = 0, ms(end.Sub(start)), 0
+		return t
+	}
+	if !p.gotConn.IsZero() {
+		t.Blocked = ms(p.gotConn.Sub(start))
+		// HAR counts dns/connect/ssl separately from blocked.
+		for _, d := range []float64{t.DNS, t.Connect} {
+			if d > 0 {
+				t.Blocked -= d
+			}
+		}
+		if t.Blocked < 0 {
+			t.Blocked = 0
+		}
+		t.Send = ms(p.wroteRequest.Sub(p.gotConn))
+	}
+	t.Wait = ms(p.firstByte.Sub(p.wroteRequest))
+	t.Receive = ms(end.Sub(p.firstByte))
+	return t
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func harEntryFor(req *http.Request, reqBody []byte, resp *http.Response, respBody []byte, start time.Time, ph *phases, end time.Time, rtErr error) harEntry {
+	e := harEntry{
+		StartedDateTime: start.UTC().Format("2006-01-02T15:04:05.000Z"),
+		Time:            ms(end.Sub(start)),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         RedactURL(req.URL),
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(req.Header),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: ph.timings(start, end),
+	}
+	if ru, err := req.URL.Parse(RedactURL(req.URL)); err == nil {
+		for k, vs := range ru.Query() {
+			for _, v := range vs {
+				e.Request.QueryString = append(e.Request.QueryString, harNameValue{Name: k, Value: v})
+			}
+		}
+		sort.Slice(e.Request.QueryString, func(i, j int) bool { return e.Request.QueryString[i].Name < e.Request.QueryString[j].Name })
+	}
+	if len(reqBody) > 0 {
+		e.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: string(RedactBody(reqBody))}
+	}
+	if rtErr != nil {
+		e.Comment = RedactText(rtErr.Error())
+		return e
+	}
+	e.Response.Status = resp.StatusCode
+	e.Response.StatusText = http.StatusText(resp.StatusCode)
+	e.Response.HTTPVersion = resp.Proto
+	if e.Response.HTTPVersion == "" {
+		e.Response.HTTPVersion = "HTTP/1.1"
+	}
+	e.Response.Headers = harHeaders(resp.Header)
+	e.Response.BodySize = len(respBody)
+	e.Response.Content = harContent{
+		Size:     len(respBody),
+		MimeType: resp.Header.Get("Content-Type"),
+		Text:     string(RedactBody(respBody)),
+	}
+	return e
+}
+
+func harHeaders(h http.Header) []harNameValue {
+	out := []harNameValue{}
+	red := RedactHeaders(h)
+	keys := make([]string, 0, len(red))
+	for k := range red {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range red[k] {
+			out = append(out, harNameValue{Name: k, Value: v})
+		}
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestTrace_WritesRedactedHAR(t *testing.T) {
+	useTempKeyring(t)
+	srv := fixtureServer(t)
+	path := filepath.Join(t.TempDir(), "trace.har")
+
+	c := New("sleeper@example.com", "hunter2", "", "", "", WithTrace(path))
+	c.HTTP = srv.Client()
+	c.HTTP.Transport = rewriteHost(c.HTTP.Transport, srv.URL)
+	if _, err := c.EnsureDeviceID(context.Background()); err != nil {
+		t.Fatalf("ensure device: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read trace: %v", err)
+	}
+	for _, secret := range []string{"hunter2", "secret-token", "sleeper@example.com", c.ClientSecret} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("trace leaks %q", secret)
+		}
+	}
+
+	var har harLog
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatalf("parse HAR: %v", err)
+	}
+	if har.Log.Version != "1.2" || har.Log.Creator.Name != "eightctl" {
+		t.Errorf("unexpected HAR header %+v", har.Log)
+	}
+	if len(har.Log.Entries) != 2 {
+		t.Fatalf("expected auth and /users/me entries, got %d", len(har.Log.Entries))
+	}
+	auth, me := har.Log.Entries[0], har.Log.Entries[1]
+	if auth.Request.Method != "POST" || auth.Request.PostData == nil || !strings.Contains(auth.Request.PostData.Text, `"password":"REDACTED"`) {
+		t.Errorf("auth request not recorded with redacted body: %+v", auth.Request)
+	}
+	if !strings.HasSuffix(me.Request.URL, "/v1/users/me") || me.Response.Status != 200 {
+		t.Errorf("unexpected second entry %s -> %d", me.Request.URL, me.Response.Status)
+	}
+	var authHeader string
+	for _, h := range me.Request.Headers {
+		if h.Name == "Authorization" {
+			authHeader = h.Value
+		}
+	}
+	if authHeader != "REDACTED" {
+		t.Errorf("authorization header = %q, want REDACTED", authHeader)
+	}
+	if me.Time <= 0 || me.Timings.Wait < 0 || me.Timings.Receive < 0 {
+		t.Errorf("missing timings: time=%v %+v", me.Time, me.Timings)
+	}
+}
+
+func TestTrace_SharedAcrossClients(t *testing.T) {
+	useTempKeyring(t)
+	srv := fixtureServer(t)
+	path := filepath.Join(t.TempDir(), "trace.har")
+
+	for range 2 {
+		c := New("sleeper@example.com", "hunter2", "", "", "", WithTrace(path))
+		c.HTTP = srv.Client()
+		c.HTTP.Transport = rewriteHost(c.HTTP.Transport, srv.URL)
+		if err := c.Authenticate(context.Background()); err != nil {
+			t.Fatalf("authenticate: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var har harLog
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatal(err)
+	}
+	if len(har.Log.Entries) != 2 {
+		t.Errorf("expected both clients' exchanges in one file, got %d entries", len(har.Log.Entries))
+	}
+}
+
+func TestTracer_AppendsInPlace(t *testing.T) {
+	tr := &tracer{path: filepath.Join(t.TempDir(), "trace.har")}
+	var entries []harEntry
+	for i := range 3 {
+		e := harEntry{StartedDateTime: "2026-01-01T00:00:00.000Z", Time: float64(i)}
+		if err := tr.add(e); err != nil {
+			t.Fatalf("add %d: %v", i, err)
+		}
+		entries = append(entries, e)
+	}
+
+	// Appending must produce exactly what marshaling the whole log would.
+	want, _ := json.MarshalIndent(harLog{Log: harBody{
+		Version: "1.2",
+		Creator: harCreator{Name: "eightctl", Version: buildVersion()},
+		Entries: entries,
+	}}, "", "  ")
+	got, err := os.ReadFile(tr.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want)+"\n" {
+		t.Fatalf("trace differs from a full rewrite:\n%s", got)
+	}
+}
+
+func TestTrace_WriteFailureKeepsResponse(t *testing.T) {
+	// A regular file where the trace directory should be makes every write fail.
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	rt := Trace(filepath.Join(blocker, "trace.har"))(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"ok":true}`))}, nil
+	}))
+	for i := range 2 {
+		req, _ := http.NewRequest(http.MethodPut, "https://client.example/v1/users/uid/temperature", strings.NewReader(`{"currentLevel":10}`))
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("request %d failed because of the trace: %v", i, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != `{"ok":true}` {
+			t.Fatalf("request %d body = %q", i, body)
+		}
+	}
+	if n := strings.Count(logs.String(), "write trace failed"); n != 1 {
+		t.Fatalf("expected one warning, got %d:\n%s", n, logs.String())
+	}
+}
@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Middleware wraps a RoundTripper with cross-cutting behavior.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// Chain wraps base with middleware. The first middleware is the outermost,
+// so it sees the request first and the response last.
+func Chain(base http.RoundTripper, mws ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(mws) - 1; i >= 0; i-- {
+		base = mws[i](base)
+	}
+	return base
+}
+
+// Limiter blocks until a request may be sent.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Use appends middleware to the client pipeline. Custom middleware runs
+// inside the built-in stack, right before the request hits the wire.
+func (c *Client) Use(mws ...Middleware) {
+	c.pipeMu.Lock()
+	defer c.pipeMu.Unlock()
+	c.middleware = append(c.middleware, mws...)
+	c.pipelines = nil
+}
+
+// httpClient returns an http.Client running the full middleware pipeline on top
+// of c.HTTP's transport. Auth endpoints pass authenticated=false so they skip
+// bearer injection and 401 handling. The pipeline is built on first use and
+// kept until Use adds middleware, so HTTP, Retry, and Limiter must be set
+// before the first request.
+func (c *Client) httpClient(authenticated bool) *http.Client {
+	c.pipeMu.Lock()
+	defer c.pipeMu.Unlock()
+	if hc := c.pipelines[authenticated]; hc != nil {
+		return hc
+	}
+	hc := c.buildPipeline(authenticated)
+	if c.pipelines == nil {
+		c.pipelines = map[bool]*http.Client{}
+	}
+	c.pipelines[authenticated] = hc
+	return hc
+}
+
+func (c *Client) buildPipeline(authenticated bool) *http.Client {
+	hc := http.Client{Timeout: 20 * time.Second}
+	if c.HTTP != nil {
+		hc = *c.HTTP
+	}
+	mws := []Middleware{AppHeaders(), Retry(c.Retry)}
+	if authenticated {
+		mws = append(mws, c.authMiddleware())
+	}
+	mws = append(mws, RateLimit(c.Limiter), Logging())
+	mws = append(mws, c.middleware...)
+	hc.Transport = Chain(hc.Transport, mws...)
+	return &hc
+}
+
+// AppHeaders sets the headers the Android app sends on every request.
+func AppHeaders() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			if req.Header.Get("Content-Type") == "" {
+				req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+			}
+			req.Header.Set("Accept", "application/json")
+			req.Header.Set("Connection", "keep-alive")
+			req.Header.Set("User-Agent", "okhttp/4.9.3")
+			// Note: Don't set Accept-Encoding manually; Go handles gzip automatically
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// authMiddleware injects the bearer token and re-authenticates once on 401.
+// Concurrent 401s for the same token share a single re-authentication.
+func (c *Client) authMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			token, err := c.bearer(ctx)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := next.RoundTrip(withBearer(req, token))
+			if err != nil || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+			retry, err := rewind(req)
+			if err != nil {
+				return resp, nil
+			}
+			drain(resp)
+			log.Debug("token rejected, re-authenticating", "url", RedactURL(req.URL))
+			if err := c.reauthenticate(ctx, token); err != nil {
+				return nil, err
+			}
+			token, err = c.bearer(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(withBearer(retry, token))
+		})
+	}
+}
+
+// RateLimit waits on l before each request. A nil limiter disables limiting.
+func RateLimit(l Limiter) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if l == nil {
+			return next
+		}
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := l.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// Logging emits a debug line per exchange with sensitive headers redacted.
+func Logging() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			elapsed := time.Since(start).Round(time.Millisecond)
+			if err != nil {
+				log.Debug("http error", "method", req.Method, "url", RedactURL(req.URL), "duration", elapsed, "error", err)
+				return nil, err
+			}
+			log.Debug("http", "method", req.Method, "url", RedactURL(req.URL), "status", resp.StatusCode, "duration", elapsed,
+				"request_headers", RedactHeaders(req.Header))
+			return resp, nil
+		})
+	}
+}
+
+func withBearer(req *http.Request, token string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+// rewind returns a copy of req with a fresh body so it can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	out := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return out, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be replayed")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	out.Body = body
+	return out, nil
+}
+
+// drain discards and closes a response body so the connection can be reused.
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
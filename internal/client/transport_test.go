@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/keyring"
+
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+// useTempKeyring points the token cache at a throwaway file keyring.
+func useTempKeyring(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	restore := tokencache.SetOpenKeyringForTest(func() (keyring.Keyring, error) {
+		return keyring.Open(keyring.Config{
+			ServiceName:      "eightctl-test",
+			AllowedBackends:  []keyring.BackendType{keyring.FileBackend},
+			FileDir:          filepath.Join(dir, "keyring"),
+			FilePasswordFunc: func(string) (string, error) { return "test-pass", nil },
+		})
+	})
+	t.Cleanup(restore)
+}
+
+// rewriteHost sends every request to target, so hard-coded hosts such as the
+// auth API land on the test server.
+func rewriteHost(next http.RoundTripper, target string) http.RoundTripper {
+	u, _ := url.Parse(target)
+	return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = u.Scheme
+		req.URL.Host = u.Host
+		req.Host = u.Host
+		return next.RoundTrip(req)
+	})
+}
+
+func TestChainOrder(t *testing.T) {
+	var order []string
+	mark := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	base := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		order = append(order, "base")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.invalid/", nil)
+	if _, err := Chain(base, mark("a"), mark("b")).RoundTrip(req); err != nil {
+		t.Fatalf("round trip: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "a,b,base" {
+		t.Fatalf("unexpected order %s", got)
+	}
+}
+
+func TestAppHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer srv.Close()
+
+	hc := &http.Client{Transport: Chain(srv.Client().Transport, AppHeaders())}
+	resp, err := hc.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+
+	if got.Get("User-Agent") != "okhttp/4.9.3" {
+		t.Errorf("expected app user agent, got %q", got.Get("User-Agent"))
+	}
+	if got.Get("Content-Type") != "application/json; charset=UTF-8" {
+		t.Errorf("expected json content type, got %q", got.Get("Content-Type"))
+	}
+}
+
+type countingLimiter struct{ n int }
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.n++
+	return nil
+}
+
+func TestRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	l := &countingLimiter{}
+	hc := &http.Client{Transport: Chain(srv.Client().Transport, RateLimit(l))}
+	for i := 0; i < 3; i++ {
+		resp, err := hc.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if l.n != 3 {
+		t.Fatalf("expected limiter consulted 3 times, got %d", l.n)
+	}
+}
+
+func TestAuthMiddleware_InjectsBearer(t *testing.T) {
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+	}))
+	defer srv.Close()
+
+	c := New("email", "pass", "uid", "", "")
+	c.token = "tok-abc"
+	c.tokenExp = time.Now().Add(time.Hour)
+
+	hc := &http.Client{Transport: Chain(srv.Client().Transport, c.authMiddleware())}
+	resp, err := hc.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+	if auth != "Bearer tok-abc" {
+		t.Fatalf("expected bearer header, got %q", auth)
+	}
+}
+
+func TestDoHost_ReauthOn401(t *testing.T) {
+	useTempKeyring(t)
+
+	var logins int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"session":{"userId":"uid","token":"fresh","expirationDate":"2099-01-01T00:00:00Z"}}`))
+	})
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := New("email", "pass", "uid", "", "")
+	c.BaseURL = srv.URL
+	c.token = "stale"
+	c.tokenExp = time.Now().Add(time.Hour)
+	c.HTTP = srv.Client()
+	c.HTTP.Transport = rewriteHost(c.HTTP.Transport, srv.URL)
+
+	var out struct {
+		OK bool `json:"ok"`
+	}
+	if err := c.do(context.Background(), http.MethodGet, "/ping", nil, nil, &out); err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	if !out.OK || logins != 1 {
+		t.Fatalf("expected one re-login and success, got ok=%v logins=%d", out.OK, logins)
+	}
+}
+
+func TestDoHost_BaseURLPerHost(t *testing.T) {
+	c := New("email", "pass", "uid", "", "")
+	c.BaseURL = "https://client.example/v1"
+	c.AppAPIBaseURL = "https://app.example/v1"
+
+	tests := map[apiHost]string{
+		hostClientV1: "https://client.example/v1",
+		hostClientV3: "https://client.example/v3",
+		hostAppAPI:   "https://app.example/v1",
+		hostAppAPIV2: "https://app.example/v2",
+	}
+	for host, want := range tests {
+		if got := c.baseURL(host); got != want {
+			t.Errorf("host %d: expected %s, got %s", host, want, got)
+		}
+	}
+}
+
+func TestHTTPClient_BuiltOnceUntilUse(t *testing.T) {
+	var built int
+	counting := func(next http.RoundTripper) http.RoundTripper {
+		built++
+		return next
+	}
+	c := New("email", "pass", "uid", "", "")
+	c.Use(counting)
+	for i := 0; i < 3; i++ {
+		c.httpClient(true)
+	}
+	if built != 1 {
+		t.Fatalf("expected pipeline built once, got %d", built)
+	}
+	c.Use(func(next http.RoundTripper) http.RoundTripper { return next })
+	c.httpClient(true)
+	if built != 2 {
+		t.Fatalf("expected Use to rebuild the pipeline, got %d builds", built)
+	}
+}
@@ -11,21 +11,34 @@ type TravelActions struct{ c *Client }
 
 func (c *Client) Travel() *TravelActions { return &TravelActions{c: c} }
 
-func (t *TravelActions) Trips(ctx context.Context) (any, error) {
+// Trip is a planned journey used to build a jet lag plan.
+type Trip struct {
+	ID          string `json:"id"`
+	Destination string `json:"destination"`
+	StartDate   string `json:"startDate"`
+	EndDate     string `json:"endDate"`
+	Timezone    string `json:"timezone"`
+}
+
+// TripList lists the user's trips.
+type TripList struct {
+	Trips []Trip `json:"trips"`
+	RawResponse
+}
+
+func (t *TravelActions) Trips(ctx context.Context) (*TripList, error) {
 	if err := t.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/travel/trips", t.c.UserID)
-	var res any
-	err := t.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/travel/trips", t.c.userID())
+	return fetchModel[TripList](ctx, t.c, hostClientV1, http.MethodGet, path, nil)
 }
 
 func (t *TravelActions) CreateTrip(ctx context.Context, body map[string]any) error {
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/travel/trips", t.c.UserID)
+	path := fmt.Sprintf("/users/%s/travel/trips", t.c.userID())
 	return t.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
 
@@ -33,7 +46,7 @@ func (t *TravelActions) CreatePlan(ctx context.Context, tripID string, body map[
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/travel/trips/%s/plans", t.c.UserID, tripID)
+	path := fmt.Sprintf("/users/%s/travel/trips/%s/plans", t.c.userID(), tripID)
 	return t.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
 
@@ -41,7 +54,7 @@ func (t *TravelActions) UpdatePlan(ctx context.Context, planID string, body map[
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/travel/plans/%s", t.c.UserID, planID)
+	path := fmt.Sprintf("/users/%s/travel/plans/%s", t.c.userID(), planID)
 	return t.c.do(ctx, http.MethodPatch, path, nil, body, nil)
 }
 
@@ -49,49 +62,95 @@ func (t *TravelActions) DeleteTrip(ctx context.Context, tripID string) error {
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/travel/trips/%s", t.c.UserID, tripID)
+	path := fmt.Sprintf("/users/%s/travel/trips/%s", t.c.userID(), tripID)
 	return t.c.do(ctx, http.MethodDelete, path, nil, nil, nil)
 }
 
-func (t *TravelActions) Plans(ctx context.Context, tripID string) (any, error) {
+// JetLagPlan is a day-by-day adjustment plan for a trip.
+type JetLagPlan struct {
+	ID     string `json:"id"`
+	TripID string `json:"tripId"`
+	Name   string `json:"name"`
+	Date   string `json:"date"`
+}
+
+// JetLagPlans lists the plans for a trip.
+type JetLagPlans struct {
+	Plans []JetLagPlan `json:"plans"`
+	RawResponse
+}
+
+func (t *TravelActions) Plans(ctx context.Context, tripID string) (*JetLagPlans, error) {
 	if err := t.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/travel/trips/%s/plans", t.c.UserID, tripID)
-	var res any
-	err := t.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/travel/trips/%s/plans", t.c.userID(), tripID)
+	return fetchModel[JetLagPlans](ctx, t.c, hostClientV1, http.MethodGet, path, nil)
+}
+
+// PlanTask is a single step in a jet lag plan, such as light or sleep timing.
+type PlanTask struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	StartTime string `json:"startTime"`
+	Completed bool   `json:"completed"`
+}
+
+// PlanTasks lists the tasks in a jet lag plan.
+type PlanTasks struct {
+	Tasks []PlanTask `json:"tasks"`
+	RawResponse
 }
 
-func (t *TravelActions) PlanTasks(ctx context.Context, planID string) (any, error) {
+func (t *TravelActions) PlanTasks(ctx context.Context, planID string) (*PlanTasks, error) {
 	if err := t.c.requireUser(ctx); err != nil {
 		return nil, err
 	}
-	path := fmt.Sprintf("/users/%s/travel/plans/%s/tasks", t.c.UserID, planID)
-	var res any
-	err := t.c.do(ctx, http.MethodGet, path, nil, nil, &res)
-	return res, err
+	path := fmt.Sprintf("/users/%s/travel/plans/%s/tasks", t.c.userID(), planID)
+	return fetchModel[PlanTasks](ctx, t.c, hostClientV1, http.MethodGet, path, nil)
+}
+
+// Airport is an airport search result.
+type Airport struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	City     string `json:"city"`
+	Country  string `json:"country"`
+	Timezone string `json:"timezone"`
 }
 
-func (t *TravelActions) AirportSearch(ctx context.Context, query string) (any, error) {
+// AirportResults lists airports matching a search.
+type AirportResults struct {
+	Airports []Airport `json:"airports"`
+	RawResponse
+}
+
+func (t *TravelActions) AirportSearch(ctx context.Context, query string) (*AirportResults, error) {
 	q := url.Values{"query": []string{query}}
-	var res any
-	err := t.c.do(ctx, http.MethodGet, "/travel/airport-search", q, nil, &res)
-	return res, err
+	return fetchModel[AirportResults](ctx, t.c, hostClientV1, http.MethodGet, "/travel/airport-search", q)
+}
+
+// FlightStatus is the schedule and status of a flight.
+type FlightStatus struct {
+	FlightNumber     string `json:"flightNumber"`
+	Status           string `json:"status"`
+	DepartureAirport string `json:"departureAirport"`
+	ArrivalAirport   string `json:"arrivalAirport"`
+	DepartureTime    string `json:"departureTime"`
+	ArrivalTime      string `json:"arrivalTime"`
+	RawResponse
 }
 
-func (t *TravelActions) FlightStatus(ctx context.Context, flight string) (any, error) {
+func (t *TravelActions) FlightStatus(ctx context.Context, flight string) (*FlightStatus, error) {
 	q := url.Values{"flightNumber": []string{flight}}
-	var res any
-	err := t.c.do(ctx, http.MethodGet, "/travel/flight-status", q, nil, &res)
-	return res, err
+	return fetchModel[FlightStatus](ctx, t.c, hostClientV1, http.MethodGet, "/travel/flight-status", q)
 }
 
 func (t *TravelActions) GetTrip(ctx context.Context, tripID string, out any) error {
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/travel/trips/%s", t.c.UserID, tripID)
+	path := fmt.Sprintf("/users/%s/travel/trips/%s", t.c.userID(), tripID)
 	return t.c.do(ctx, http.MethodGet, path, nil, nil, out)
 }
 
@@ -99,7 +158,7 @@ func (t *TravelActions) UpdateTrip(ctx context.Context, tripID string, body map[
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/travel/trips/%s", t.c.UserID, tripID)
+	path := fmt.Sprintf("/users/%s/travel/trips/%s", t.c.userID(), tripID)
 	return t.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
@@ -107,6 +166,6 @@ func (t *TravelActions) UpdatePlanTasks(ctx context.Context, planID string, body
 	if err := t.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/travel/plans/%s/tasks", t.c.UserID, planID)
+	path := fmt.Sprintf("/users/%s/travel/plans/%s/tasks", t.c.userID(), planID)
 	return t.c.do(ctx, http.MethodPatch, path, nil, body, nil)
 }
@@ -17,7 +17,7 @@ func TestTravelActions_Trips(t *testing.T) {
 			t.Errorf("expected GET, got %s", r.Method)
 		}
 		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"trips":[]}`))
+		w.Write([]byte(`{"trips":[{"id":"trip-1","destination":"NYC","startDate":"2026-03-01"}]}`))
 	})
 	srv := httptest.NewServer(mux)
 	defer srv.Close()
@@ -32,8 +32,8 @@ func TestTravelActions_Trips(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Trips error: %v", err)
 	}
-	if res == nil {
-		t.Error("expected non-nil response")
+	if len(res.Trips) != 1 || res.Trips[0].Destination != "NYC" || res.Trips[0].StartDate != "2026-03-01" {
+		t.Errorf("unexpected trips %+v", res.Trips)
 	}
 }
 
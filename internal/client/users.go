@@ -30,7 +30,7 @@ func (u *UserActions) UpdateUser(ctx context.Context, body map[string]any) error
 	if err := u.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s", u.c.UserID)
+	path := fmt.Sprintf("/users/%s", u.c.userID())
 	return u.c.do(ctx, http.MethodPut, path, nil, body, nil)
 }
 
@@ -39,7 +39,7 @@ func (u *UserActions) UpdateEmail(ctx context.Context, body map[string]any) erro
 	if err := u.c.requireUser(ctx); err != nil {
 		return err
 	}
-	path := fmt.Sprintf("/users/%s/email", u.c.UserID)
+	path := fmt.Sprintf("/users/%s/email", u.c.userID())
 	return u.c.do(ctx, http.MethodPost, path, nil, body, nil)
 }
 
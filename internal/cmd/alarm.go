@@ -24,7 +24,7 @@ var alarmListCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		alarms, err := cl.ListAlarms(context.Background())
 		if err != nil {
 			return err
@@ -69,7 +69,7 @@ var alarmCreateCmd = &cobra.Command{
 				weekDays[dayNames[d]] = true
 			}
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		alarm := client.Alarm{
 			Enabled: !viper.GetBool("disabled"),
 			Time:    timeStr,
@@ -138,7 +138,7 @@ var alarmUpdateCmd = &cobra.Command{
 		if len(patch) == 0 {
 			return fmt.Errorf("no fields to update")
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if _, err := cl.UpdateAlarm(context.Background(), args[0], patch); err != nil {
 			return err
 		}
@@ -155,7 +155,7 @@ var alarmDeleteCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if err := cl.DeleteAlarm(context.Background(), args[0]); err != nil {
 			return err
 		}
@@ -196,7 +196,7 @@ var alarmSnoozeCmd = &cobra.Command{Use: "snooze <id>", Args: cobra.ExactArgs(1)
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Alarms().Snooze(context.Background(), args[0])
 }}
 
@@ -204,7 +204,7 @@ var alarmDismissCmd = &cobra.Command{Use: "dismiss <id>", Args: cobra.ExactArgs(
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Alarms().Dismiss(context.Background(), args[0])
 }}
 
@@ -212,7 +212,7 @@ var alarmDismissAllCmd = &cobra.Command{Use: "dismiss-all", RunE: func(cmd *cobr
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Alarms().DismissAll(context.Background())
 }}
 
@@ -220,7 +220,7 @@ var alarmVibeCmd = &cobra.Command{Use: "vibration-test", RunE: func(cmd *cobra.C
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Alarms().VibrationTest(context.Background())
 }}
 
@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/output"
 )
 
@@ -17,7 +16,7 @@ var audioTracksCmd = &cobra.Command{Use: "tracks", RunE: func(cmd *cobra.Command
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	tracks, err := cl.Audio().Tracks(context.Background())
 	if err != nil {
 		return err
@@ -38,26 +37,29 @@ var audioCategoriesCmd = &cobra.Command{Use: "categories", RunE: func(cmd *cobra
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Audio().Categories(context.Background())
 	if err != nil {
 		return err
 	}
-	rows := []map[string]any{{"data": res}}
-	return output.Print(output.Format(viper.GetString("output")), []string{"data"}, rows)
+	rows := make([]map[string]any, 0, len(res.Categories))
+	for _, c := range res.Categories {
+		rows = append(rows, map[string]any{"id": c.ID, "name": c.Name})
+	}
+	return printModel("data", modelView{res.Raw, []string{"id", "name"}, rows})
 }}
 
 var audioStateCmd = &cobra.Command{Use: "state", RunE: func(cmd *cobra.Command, args []string) error {
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Audio().PlayerState(context.Background())
 	if err != nil {
 		return err
 	}
-	rows := []map[string]any{{"state": res}}
-	return output.Print(output.Format(viper.GetString("output")), []string{"state"}, rows)
+	row := map[string]any{"state": res.State, "track": res.TrackID, "volume": res.Volume, "position": res.Position}
+	return printModel("state", modelView{res.Raw, []string{"state", "track", "volume", "position"}, []map[string]any{row}})
 }}
 
 var audioPlayCmd = &cobra.Command{Use: "play", RunE: func(cmd *cobra.Command, args []string) error {
@@ -65,7 +67,7 @@ var audioPlayCmd = &cobra.Command{Use: "play", RunE: func(cmd *cobra.Command, ar
 		return err
 	}
 	track := viper.GetString("track")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Audio().Play(context.Background(), track)
 }}
 
@@ -73,7 +75,7 @@ var audioPauseCmd = &cobra.Command{Use: "pause", RunE: func(cmd *cobra.Command,
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Audio().Pause(context.Background())
 }}
 
@@ -82,7 +84,7 @@ var audioSeekCmd = &cobra.Command{Use: "seek", RunE: func(cmd *cobra.Command, ar
 		return err
 	}
 	pos := viper.GetInt("position")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Audio().Seek(context.Background(), pos)
 }}
 
@@ -91,7 +93,7 @@ var audioVolumeCmd = &cobra.Command{Use: "volume", RunE: func(cmd *cobra.Command
 		return err
 	}
 	lvl := viper.GetInt("level")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Audio().Volume(context.Background(), lvl)
 }}
 
@@ -99,7 +101,7 @@ var audioPairCmd = &cobra.Command{Use: "pair", RunE: func(cmd *cobra.Command, ar
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Audio().Pair(context.Background())
 }}
 
@@ -107,13 +109,13 @@ var audioNextCmd = &cobra.Command{Use: "next", RunE: func(cmd *cobra.Command, ar
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Audio().RecommendedNext(context.Background())
 	if err != nil {
 		return err
 	}
-	rows := []map[string]any{{"next": res}}
-	return output.Print(output.Format(viper.GetString("output")), []string{"next"}, rows)
+	row := map[string]any{"track": res.TrackID, "title": res.Title}
+	return printModel("next", modelView{res.Raw, []string{"track", "title"}, []map[string]any{row}})
 }}
 
 var audioFavoritesCmd = &cobra.Command{Use: "favorites", Short: "Favorite tracks"}
@@ -122,12 +124,16 @@ var audioFavListCmd = &cobra.Command{Use: "list", RunE: func(cmd *cobra.Command,
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Audio().Favorites(context.Background())
 	if err != nil {
 		return err
 	}
-	return output.Print(output.Format(viper.GetString("output")), []string{"favorites"}, []map[string]any{{"favorites": res}})
+	rows := make([]map[string]any, 0, len(res.Favorites))
+	for _, id := range res.Favorites {
+		rows = append(rows, map[string]any{"track": id})
+	}
+	return printModel("favorites", modelView{res.Raw, []string{"track"}, rows})
 }}
 
 var audioFavAddCmd = &cobra.Command{Use: "add", RunE: func(cmd *cobra.Command, args []string) error {
@@ -138,7 +144,7 @@ var audioFavAddCmd = &cobra.Command{Use: "add", RunE: func(cmd *cobra.Command, a
 	if id == "" {
 		return fmt.Errorf("--track required")
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Audio().AddFavorite(context.Background(), id)
 }}
 
@@ -150,7 +156,7 @@ var audioFavRemoveCmd = &cobra.Command{Use: "remove", RunE: func(cmd *cobra.Comm
 	if id == "" {
 		return fmt.Errorf("--track required")
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Audio().RemoveFavorite(context.Background(), id)
 }}
 
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/output"
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage cached authentication tokens",
+	Long: `Every combination of base URL, client ID, email, and profile caches its
+own token in the keyring. These commands list and clear those tokens, print
+the current one for other tools, and force a fresh sign-in.`,
+}
+
+var authListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every cached identity and when its token expires",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := tokencache.List()
+		if err != nil {
+			return fmt.Errorf("list tokens: %w", err)
+		}
+		now := time.Now()
+		rows := make([]map[string]any, 0, len(entries))
+		for _, e := range entries {
+			rows = append(rows, map[string]any{
+				"profile":    e.Profile,
+				"base_url":   e.BaseURL,
+				"client_id":  e.ClientID,
+				"email":      e.Email,
+				"user_id":    e.UserID,
+				"expires_at": e.ExpiresAt.Format(time.RFC3339),
+				"expired":    now.After(e.ExpiresAt),
+			})
+		}
+
+		fields := viper.GetStringSlice("fields")
+		rows = output.FilterFields(rows, fields)
+		headers := fields
+		if len(headers) == 0 {
+			headers = []string{"profile", "base_url", "client_id", "email", "user_id", "expires_at", "expired"}
+		}
+		return output.Print(output.Format(viper.GetString("output")), headers, rows)
+	},
+}
+
+var authClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove cached tokens for every identity or one email",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all := viper.GetBool("auth.all")
+		email := strings.ToLower(strings.TrimSpace(viper.GetString("auth.email")))
+		if !all && email == "" {
+			return fmt.Errorf("pass --all or --email")
+		}
+		n, err := tokencache.ClearWhere(func(id tokencache.Identity) bool {
+			return all || id.Email == email
+		})
+		if err != nil {
+			return fmt.Errorf("clear tokens: %w", err)
+		}
+		fmt.Printf("Cleared %d cached token(s)\n", n)
+		return nil
+	},
+}
+
+var authTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Print the current access token for use by other tools",
+	Long: `Prints the cached access token for the current identity, signing in
+first if none is cached. --format env prints shell assignments:
+
+  eval "$(eightctl auth token)"
+
+--format json prints token, user_id, and expires_at.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := viper.GetString("auth.format")
+		if format != "env" && format != "json" {
+			return fmt.Errorf("unknown format %q (want env or json)", format)
+		}
+		cached, err := currentToken(context.Background())
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(map[string]any{
+				"token":      cached.Token,
+				"user_id":    cached.UserID,
+				"expires_at": cached.ExpiresAt.Format(time.RFC3339),
+			})
+		}
+		fmt.Printf("export EIGHTCTL_TOKEN=%s\n", cached.Token)
+		fmt.Printf("export EIGHTCTL_USER_ID=%s\n", cached.UserID)
+		fmt.Printf("export EIGHTCTL_TOKEN_EXPIRES_AT=%s\n", cached.ExpiresAt.Format(time.RFC3339))
+		return nil
+	},
+}
+
+var authRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Sign in again and replace the cached token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requirePassword(); err != nil {
+			return err
+		}
+		cl := newClient()
+		if err := cl.Authenticate(context.Background()); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		remaining := time.Until(cl.TokenExpiry()).Round(time.Minute)
+		fmt.Printf("Re-authenticated as %s (token expires in %s)\n", cl.UserID, remaining)
+		return nil
+	},
+}
+
+// currentToken returns the cached token for the current identity, signing
+// in and caching a new one when there is none.
+func currentToken(ctx context.Context) (*tokencache.CachedToken, error) {
+	cl := newClient()
+	if cached, err := tokencache.Load(cl.Identity(), viper.GetString("user_id")); err == nil {
+		return cached, nil
+	}
+	if err := requirePassword(); err != nil {
+		return nil, err
+	}
+	if err := cl.Authenticate(ctx); err != nil {
+		return nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	cached, err := tokencache.Load(cl.Identity(), cl.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("read cached token: %w", err)
+	}
+	return cached, nil
+}
+
+func init() {
+	authClearCmd.Flags().Bool("all", false, "remove every cached token")
+	authClearCmd.Flags().String("email", "", "remove cached tokens for this email only")
+	authClearCmd.MarkFlagsMutuallyExclusive("all", "email")
+	viper.BindPFlag("auth.all", authClearCmd.Flags().Lookup("all"))
+	viper.BindPFlag("auth.email", authClearCmd.Flags().Lookup("email"))
+
+	authTokenCmd.Flags().String("format", "env", "output format: env|json")
+	viper.BindPFlag("auth.format", authTokenCmd.Flags().Lookup("format"))
+
+	authCmd.AddCommand(authListCmd, authClearCmd, authTokenCmd, authRefreshCmd)
+	rootCmd.AddCommand(authCmd)
+}
@@ -7,23 +7,54 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/steipete/eightctl/internal/client"
-	"github.com/steipete/eightctl/internal/output"
 )
 
 var autopilotCmd = &cobra.Command{Use: "autopilot", Short: "Autopilot settings", Hidden: true} // Verified broken 2026-01-29: Cannot GET
 
 var (
-	autopilotDetailsCmd = simpleAutopilot("details", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Autopilot().Details(ctx) })
-	autopilotHistoryCmd = simpleAutopilot("history", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Autopilot().History(ctx) })
-	autopilotRecapCmd   = simpleAutopilot("recap", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Autopilot().Recap(ctx) })
+	autopilotDetailsCmd = simpleAutopilot("details", func(cl *client.Client, ctx context.Context) (modelView, error) {
+		res, err := cl.Autopilot().Details(ctx)
+		if err != nil {
+			return modelView{}, err
+		}
+		return modelView{res.Raw, []string{"enabled", "mode", "snore_mitigation"}, []map[string]any{{
+			"enabled":          res.Enabled,
+			"mode":             res.Mode,
+			"snore_mitigation": res.SnoringMitigation.Enabled,
+		}}}, nil
+	})
+	autopilotHistoryCmd = simpleAutopilot("history", func(cl *client.Client, ctx context.Context) (modelView, error) {
+		res, err := cl.Autopilot().History(ctx)
+		if err != nil {
+			return modelView{}, err
+		}
+		return modelView{res.Raw, autopilotAdjustmentHeaders, autopilotAdjustmentRows(res.History)}, nil
+	})
+	autopilotRecapCmd = simpleAutopilot("recap", func(cl *client.Client, ctx context.Context) (modelView, error) {
+		res, err := cl.Autopilot().Recap(ctx)
+		if err != nil {
+			return modelView{}, err
+		}
+		return modelView{res.Raw, autopilotAdjustmentHeaders, autopilotAdjustmentRows(res.Recap.Adjustments)}, nil
+	})
 )
 
+var autopilotAdjustmentHeaders = []string{"timestamp", "stage", "from", "to", "reason"}
+
+func autopilotAdjustmentRows(adjs []client.AutopilotAdjustment) []map[string]any {
+	rows := make([]map[string]any, 0, len(adjs))
+	for _, a := range adjs {
+		rows = append(rows, map[string]any{"timestamp": a.Timestamp, "stage": a.Stage, "from": a.FromLevel, "to": a.ToLevel, "reason": a.Reason})
+	}
+	return rows
+}
+
 var autopilotLevelCmd = &cobra.Command{Use: "level-suggestions", RunE: func(cmd *cobra.Command, args []string) error {
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
 	enabled := viper.GetBool("enabled")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Autopilot().SetLevelSuggestions(context.Background(), enabled)
 }}
 
@@ -32,21 +63,21 @@ var autopilotSnoreCmd = &cobra.Command{Use: "snore-mitigation", RunE: func(cmd *
 		return err
 	}
 	enabled := viper.GetBool("enabled")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Autopilot().SetSnoreMitigation(context.Background(), enabled)
 }}
 
-func simpleAutopilot(name string, fn func(*client.Client, context.Context) (any, error)) *cobra.Command {
+func simpleAutopilot(name string, fn func(*client.Client, context.Context) (modelView, error)) *cobra.Command {
 	return &cobra.Command{Use: name, RunE: func(cmd *cobra.Command, args []string) error {
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		res, err := fn(cl, context.Background())
 		if err != nil {
 			return err
 		}
-		return output.Print(output.Format(viper.GetString("output")), []string{name}, []map[string]any{{name: res}})
+		return printModel(name, res)
 	}}
 }
 
@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/output"
 )
 
@@ -23,7 +22,7 @@ var awayModeShowCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		status, err := cl.AwayMode().Get(context.Background())
 		if err != nil {
 			return err
@@ -43,7 +42,7 @@ var awayModeOnCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if err := cl.AwayMode().Enable(context.Background()); err != nil {
 			return err
 		}
@@ -59,7 +58,7 @@ var awayModeOffCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if err := cl.AwayMode().Disable(context.Background()); err != nil {
 			return err
 		}
@@ -7,9 +7,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-
-	"github.com/steipete/eightctl/internal/client"
-	"github.com/steipete/eightctl/internal/output"
 )
 
 // ErrNoAdjustableBase indicates the user doesn't have an adjustable base.
@@ -32,7 +29,7 @@ var baseInfoCmd = &cobra.Command{Use: "info", Short: "Show base status", RunE: f
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Base().Info(context.Background())
 	if err != nil {
 		if isNoBaseError(err) {
@@ -40,7 +37,8 @@ var baseInfoCmd = &cobra.Command{Use: "info", Short: "Show base status", RunE: f
 		}
 		return err
 	}
-	return output.Print(output.Format(viper.GetString("output")), []string{"info"}, []map[string]any{{"info": res}})
+	row := map[string]any{"model": res.Model, "firmware": res.Firmware, "head": res.TorsoAngle, "foot": res.LegAngle, "preset": res.Preset, "moving": res.InMotion}
+	return printModel("info", modelView{res.Raw, []string{"model", "firmware", "head", "foot", "preset", "moving"}, []map[string]any{row}})
 }}
 
 var baseAngleCmd = &cobra.Command{Use: "angle", Short: "Set head/foot angle", RunE: func(cmd *cobra.Command, args []string) error {
@@ -49,7 +47,7 @@ var baseAngleCmd = &cobra.Command{Use: "angle", Short: "Set head/foot angle", Ru
 	}
 	head := viper.GetInt("head")
 	foot := viper.GetInt("foot")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	if err := cl.Base().SetAngle(context.Background(), head, foot); err != nil {
 		if isNoBaseError(err) {
 			return ErrNoAdjustableBase
@@ -63,7 +61,7 @@ var basePresetsCmd = &cobra.Command{Use: "presets", Short: "List available prese
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Base().Presets(context.Background())
 	if err != nil {
 		if isNoBaseError(err) {
@@ -71,7 +69,11 @@ var basePresetsCmd = &cobra.Command{Use: "presets", Short: "List available prese
 		}
 		return err
 	}
-	return output.Print(output.Format(viper.GetString("output")), []string{"presets"}, []map[string]any{{"presets": res}})
+	rows := make([]map[string]any, 0, len(res.Presets))
+	for _, p := range res.Presets {
+		rows = append(rows, map[string]any{"name": p.Name, "head": p.TorsoAngle, "foot": p.LegAngle})
+	}
+	return printModel("presets", modelView{res.Raw, []string{"name", "head", "foot"}, rows})
 }}
 
 var basePresetRunCmd = &cobra.Command{Use: "preset-run", Short: "Run a preset", RunE: func(cmd *cobra.Command, args []string) error {
@@ -79,7 +81,7 @@ var basePresetRunCmd = &cobra.Command{Use: "preset-run", Short: "Run a preset",
 		return err
 	}
 	name := viper.GetString("name")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	if err := cl.Base().RunPreset(context.Background(), name); err != nil {
 		if isNoBaseError(err) {
 			return ErrNoAdjustableBase
@@ -93,7 +95,7 @@ var baseTestCmd = &cobra.Command{Use: "test", Short: "Run vibration test", RunE:
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	if err := cl.Base().VibrationTest(context.Background()); err != nil {
 		if isNoBaseError(err) {
 			return ErrNoAdjustableBase
@@ -107,7 +109,7 @@ var baseStopCmd = &cobra.Command{Use: "stop", Short: "Stop base movement", RunE:
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	if err := cl.Base().StopMovement(context.Background()); err != nil {
 		if isNoBaseError(err) {
 			return ErrNoAdjustableBase
@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/output"
 )
 
@@ -23,7 +22,7 @@ var bedtimeShowCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		schedule, err := cl.Bedtime().Get(context.Background())
 		if err != nil {
 			return err
@@ -46,7 +45,7 @@ var bedtimeEnableCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if err := cl.Bedtime().Enable(context.Background()); err != nil {
 			return err
 		}
@@ -62,7 +61,7 @@ var bedtimeDisableCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if err := cl.Bedtime().Disable(context.Background()); err != nil {
 			return err
 		}
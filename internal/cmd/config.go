@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/steipete/eightctl/internal/config"
+	"github.com/steipete/eightctl/internal/daemon"
+	"github.com/steipete/eightctl/internal/output"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Create, inspect, and check the config file",
+	Long: `The config file is ~/.config/eightctl/config.yaml unless --config points
+elsewhere. These commands keep working when the file has errors, so
+'config validate' and 'config set' can fix it.`,
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a new config file interactively",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configFile()
+		if err != nil {
+			return err
+		}
+		// With --force the old file stays until every answer is in.
+		if _, err := os.Stat(path); err == nil && !viper.GetBool("config-init.force") {
+			return fmt.Errorf("%s already exists; edit it with 'eightctl config set' or pass --force", path)
+		}
+
+		in := bufio.NewReader(os.Stdin)
+		email, err := ask(in, "Email", "")
+		if err != nil {
+			return err
+		}
+		if email == "" {
+			return fmt.Errorf("email is required")
+		}
+		values := []config.KeyValue{{Key: "email", Value: email}}
+
+		fmt.Println("How should eightctl get your password?")
+		fmt.Println("  1) keyring: run 'eightctl login --save-password' afterwards")
+		fmt.Println("  2) password_command: e.g. 'pass show eightsleep'")
+		fmt.Println("  3) password in this file")
+		switch choice, err := ask(in, "Choice", "1"); {
+		case err != nil:
+			return err
+		case choice == "1":
+		case choice == "2":
+			command, err := ask(in, "Command", "")
+			if err != nil {
+				return err
+			}
+			values = append(values, config.KeyValue{Key: "password_command", Value: command})
+		case choice == "3":
+			fmt.Print("Password: ")
+			pw, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println()
+			if err != nil {
+				return fmt.Errorf("read password: %w", err)
+			}
+			values = append(values, config.KeyValue{Key: "password", Value: string(pw)})
+		default:
+			return fmt.Errorf("unknown choice %q", choice)
+		}
+
+		tz, err := ask(in, "Timezone (IANA name or local)", "local")
+		if err != nil {
+			return err
+		}
+		if tz != "local" {
+			if _, err := time.LoadLocation(tz); err != nil {
+				return fmt.Errorf("unknown timezone %q", tz)
+			}
+		}
+		out, err := ask(in, "Output format (table, json, csv)", "table")
+		if err != nil {
+			return err
+		}
+		switch output.Format(out) {
+		case output.FormatTable, output.FormatJSON, output.FormatCSV:
+		default:
+			return fmt.Errorf("unknown output format %q", out)
+		}
+		values = append(values, config.KeyValue{Key: "timezone", Value: tz}, config.KeyValue{Key: "output", Value: out})
+
+		set := values[:0]
+		for _, kv := range values {
+			if s, _ := kv.Value.(string); s != "" {
+				set = append(set, kv)
+			}
+		}
+		if err := config.WriteKeys(path, set); err != nil {
+			return fmt.Errorf("write config: %w", err)
+		}
+		fmt.Printf("Wrote %s (mode 0600)\n", path)
+		return nil
+	},
+}
+
+// setting is one key 'config show' reports.
+type setting struct {
+	key    string
+	flag   string // persistent flag that sets it, if any
+	secret bool
+}
+
+var settings = []setting{
+	{key: "profile", flag: "profile"},
+	{key: "email", flag: "email"},
+	{key: "password", flag: "password", secret: true},
+	{key: "password_command"},
+	{key: "password_file"},
+	{key: "user_id", flag: "user-id"},
+	{key: "client_id", flag: "client-id"},
+	{key: "client_secret", flag: "client-secret", secret: true},
+	{key: "timezone", flag: "timezone"},
+	{key: "output", flag: "output"},
+	{key: "fields", flag: "fields"},
+	{key: "verbose", flag: "verbose"},
+	{key: "device", flag: "device"},
+	{key: "max_rps", flag: "max-rps"},
+	{key: "retry.max_attempts", flag: "retry-attempts"},
+	{key: "retry.base_delay"},
+	{key: "retry.max_delay", flag: "retry-max-delay"},
+	{key: "base_url"},
+	{key: "app_api_base_url"},
+	{key: "auth_url"},
+	{key: "keyring_backend"},
+	{key: "latitude"},
+	{key: "longitude"},
+	{key: "mqtt.broker"},
+	{key: "mqtt.topic-prefix"},
+	{key: "mqtt.device-name"},
+	{key: "mqtt.client-id"},
+	{key: "mqtt.mqtt-username"},
+	{key: "mqtt.mqtt-password", secret: true},
+	{key: "mqtt.poll-interval"},
+	{key: "hubitat.port"},
+	{key: "hubitat.poll-interval"},
+	{key: "exporter.listen"},
+	{key: "exporter.cache-ttl"},
+	{key: "serve.services"},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective settings and where each comes from",
+	Long: `Shows every setting after merging flags, EIGHTCTL_* environment variables,
+the active profile, and the config file. Secrets are masked. The source
+column names where the value came from: flag, env, profile, file, or
+default. Flags of the long-running commands apply only to those commands
+and are not shown.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fileKeys := map[string]bool{}
+		if loadedConfig.File != "" {
+			keys, err := config.FileKeys(loadedConfig.File)
+			if err != nil {
+				return err
+			}
+			fileKeys = keys
+		}
+		profile := viper.GetString("profile")
+		prof := loadedConfig.Profiles[profile]
+
+		rows := make([]map[string]any, 0, len(settings))
+		for _, s := range settings {
+			value := fmt.Sprint(viper.Get(s.key))
+			if s.key == "fields" || s.key == "serve.services" {
+				value = strings.Join(viper.GetStringSlice(s.key), ",")
+			}
+			if s.secret && value != "" {
+				value = "********"
+			}
+			source := "default"
+			env := "EIGHTCTL_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(s.key))
+			switch {
+			case s.flag != "" && rootCmd.PersistentFlags().Changed(s.flag):
+				source = "flag --" + s.flag
+			case envSet(env):
+				source = "env " + env
+			case profile != "" && s.key != "profile" && prof.Sets(s.key):
+				source = "profile " + profile
+			case fileKeys[s.key]:
+				source = "file"
+			}
+			rows = append(rows, map[string]any{"key": s.key, "value": value, "source": source})
+		}
+
+		fields := viper.GetStringSlice("fields")
+		rows = output.FilterFields(rows, fields)
+		headers := fields
+		if len(headers) == 0 {
+			headers = []string{"key", "value", "source"}
+		}
+		return output.Print(output.Format(viper.GetString("output")), headers, rows)
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for unknown keys and invalid values",
+	Long: `Checks the config file against the settings eightctl knows: misspelled
+keys, values of the wrong type, unknown timezones and output formats,
+profiles and devices, the mqtt, hubitat, exporter, and serve sections, and
+every daemon schedule item. Exits 1 if anything is wrong.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := configFile()
+		if err != nil {
+			return err
+		}
+		problems, err := validateConfigFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no config file at %s; create one with 'eightctl config init'", path)
+		}
+		if err != nil {
+			return err
+		}
+		if len(problems) == 0 {
+			fmt.Printf("%s is valid\n", path)
+			return nil
+		}
+		for _, p := range problems {
+			fmt.Println(p.Error())
+		}
+		return fmt.Errorf("%d problem(s) in %s", len(problems), path)
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a key in the config file",
+	Long: `Writes one value into the config file, keeping other keys and comments.
+Keys are dotted paths such as timezone, mqtt.broker, or
+profiles.work.email. Lists take comma-separated values.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		value, err := config.ParseValue(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		path, err := configFile()
+		if err != nil {
+			return err
+		}
+		if err := config.SetKey(path, args[0], value); err != nil {
+			return fmt.Errorf("update config: %w", err)
+		}
+		fmt.Printf("Set %s in %s\n", args[0], path)
+		return nil
+	},
+}
+
+func init() {
+	configInitCmd.Flags().Bool("force", false, "replace an existing config file")
+	viper.BindPFlag("config-init.force", configInitCmd.Flags().Lookup("force"))
+
+	configCmd.AddCommand(configInitCmd, configShowCmd, configValidateCmd, configSetCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// configFile is the file the config commands work on: the one loaded, else
+// --config, else the default location.
+func configFile() (string, error) {
+	if loadedConfig.File != "" {
+		return loadedConfig.File, nil
+	}
+	if path := viper.GetString("config"); path != "" {
+		return path, nil
+	}
+	return config.DefaultFile()
+}
+
+// validateConfigFile runs config.Validate and checks the daemon schedule,
+// if the file has one.
+func validateConfigFile(path string) ([]config.Problem, error) {
+	problems, err := config.Validate(path)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		items, err := daemon.ParseSchedule(data)
+		if err == nil {
+			// Decode errors were reported above.
+			cfg, _ := config.Load(path, true)
+			err = daemon.CheckPlace(items, daemon.Place{Latitude: cfg.Latitude, Longitude: cfg.Longitude})
+		}
+		if err != nil && !errors.Is(err, daemon.ErrNoSchedule) {
+			for _, line := range strings.Split(err.Error(), "\n") {
+				problems = append(problems, config.Problem{Msg: line})
+			}
+		}
+	}
+	return problems, nil
+}
+
+// isConfigCmd reports whether cmd is 'config' or one of its subcommands.
+func isConfigCmd(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == configCmd {
+			return true
+		}
+	}
+	return false
+}
+
+func envSet(name string) bool {
+	_, ok := os.LookupEnv(name)
+	return ok
+}
+
+// ask prompts for one line, returning def when the answer is empty.
+func ask(in *bufio.Reader, label, def string) (string, error) {
+	if def != "" {
+		fmt.Printf("%s [%s]: ", label, def)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+	line, err := in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read %s: %w", strings.ToLower(label), err)
+	}
+	if line = strings.TrimSpace(line); line != "" {
+		return line, nil
+	}
+	return def, nil
+}
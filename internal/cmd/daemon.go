@@ -5,14 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"gopkg.in/yaml.v3"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/daemon"
+	"github.com/steipete/eightctl/internal/model"
+	"github.com/steipete/eightctl/internal/output"
+	"github.com/steipete/eightctl/internal/state"
 )
 
 var daemonCmd = &cobra.Command{
@@ -22,64 +24,239 @@ var daemonCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cfgData, err := readConfigSchedule()
+		return runServices("daemon")
+	},
+}
+
+// startDaemon runs the schedule from the config file until stopped.
+func startDaemon(ctx context.Context, env *serviceEnv) (*service, error) {
+	cfgData, err := readConfigSchedule()
+	if err != nil {
+		return nil, err
+	}
+	items, err := daemon.ParseSchedule(cfgData)
+	if err != nil {
+		return nil, err
+	}
+	place, err := daemonPlace()
+	if err != nil {
+		return nil, err
+	}
+	if err := daemon.CheckPlace(items, place); err != nil {
+		return nil, err
+	}
+	r := &daemon.Runner{
+		Items:     items,
+		Client:    env.client,
+		Pod:       &lazyPod{env: env},
+		Timezone:  place.Location,
+		Latitude:  place.Latitude,
+		Longitude: place.Longitude,
+		DryRun:    viper.GetBool("dry-run"),
+		Sync:      viper.GetBool("sync-state"),
+		PIDFile:   defaultPIDFile(viper.GetString("pid-file")),
+		StateFile: defaultStateFile(viper.GetString("state-file")),
+		Changed:   env.invalidate,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	failed := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(failed)
+		if err := r.Run(ctx); err != nil {
+			failed <- err
+		}
+	}()
+	fmt.Printf("daemon started with %d items\n", len(items))
+
+	return &service{
+		reload: func() error {
+			data, err := readConfigSchedule()
+			if err != nil {
+				return err
+			}
+			items, err := daemon.ParseSchedule(data)
+			if err != nil {
+				return err
+			}
+			// The timezone and coordinates stay those the daemon started with.
+			if err := daemon.CheckPlace(items, place); err != nil {
+				return err
+			}
+			r.SetItems(items)
+			logger.Info("schedule reloaded", "items", len(items))
+			return nil
+		},
+		stop: func() error {
+			cancel()
+			<-done
+			return nil
+		},
+		failed: failed,
+	}, nil
+}
+
+var daemonNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the next firings of the daemon schedule",
+	Long: `Lists the next runs of the schedule in the config file, in the configured
+timezone, without contacting the API.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := readConfigSchedule()
 		if err != nil {
 			return err
 		}
-		items, err := parseSchedule(cfgData)
+		items, err := daemon.ParseSchedule(data)
 		if err != nil {
 			return err
 		}
-		tzName := viper.GetString("timezone")
-		loc := time.Local
-		if tzName != "local" {
-			loc, err = time.LoadLocation(tzName)
-			if err != nil {
-				return fmt.Errorf("load timezone: %w", err)
+		place, err := daemonPlace()
+		if err != nil {
+			return err
+		}
+		if err := daemon.CheckPlace(items, place); err != nil {
+			return err
+		}
+		firings, err := daemon.Upcoming(items, time.Now(), place, viper.GetInt("daemon-next.count"))
+		if err != nil {
+			return err
+		}
+
+		rows := make([]map[string]any, 0, len(firings))
+		for _, f := range firings {
+			temp := f.Item.Temperature
+			if f.Item.Action == "ramp" {
+				temp = fmt.Sprintf("%s to %s over %s", f.Item.From, f.Item.To, f.Item.Over)
 			}
+			rows = append(rows, map[string]any{
+				"at":          f.At.Format(time.RFC3339),
+				"day":         f.At.Format("Mon"),
+				"item":        f.Index,
+				"side":        f.Item.Side,
+				"action":      f.Item.Action,
+				"temperature": temp,
+			})
 		}
-		r := daemon.Runner{
-			Items:    items,
-			Client:   client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret")),
-			Timezone: loc,
-			DryRun:   viper.GetBool("dry-run"),
-			Sync:     viper.GetBool("sync-state"),
-			PIDFile:  defaultPIDFile(viper.GetString("pid-file")),
+		fields := viper.GetStringSlice("fields")
+		rows = output.FilterFields(rows, fields)
+		headers := fields
+		if len(headers) == 0 {
+			headers = []string{"at", "day", "item", "side", "action", "temperature"}
 		}
-		ctx := context.Background()
-		fmt.Printf("daemon started with %d items\n", len(items))
-		return r.Run(ctx)
+		return output.Print(output.Format(viper.GetString("output")), headers, rows)
 	},
 }
 
 func init() {
+	daemonNextCmd.Flags().IntP("count", "n", 10, "number of firings to show")
+	viper.BindPFlag("daemon-next.count", daemonNextCmd.Flags().Lookup("count"))
+	daemonCmd.AddCommand(daemonNextCmd)
+
 	daemonCmd.Flags().Bool("dry-run", false, "log actions without executing")
 	daemonCmd.Flags().Bool("sync-state", false, "(reserved) sync device state")
 	daemonCmd.Flags().String("pid-file", "", "pid file path (default ~/.config/eightctl/daemon.pid)")
+	daemonCmd.Flags().String("state-file", "", "file recording which firings ran (default ~/.config/eightctl/daemon-state.json)")
 	viper.BindPFlag("dry-run", daemonCmd.Flags().Lookup("dry-run"))
 	viper.BindPFlag("sync-state", daemonCmd.Flags().Lookup("sync-state"))
 	viper.BindPFlag("pid-file", daemonCmd.Flags().Lookup("pid-file"))
+	viper.BindPFlag("state-file", daemonCmd.Flags().Lookup("state-file"))
 }
 
-func readConfigSchedule() ([]byte, error) {
-	cfg := viper.ConfigFileUsed()
-	if cfg == "" {
-		return nil, fmt.Errorf("no config file loaded; specify --config")
+// lazyPod finds the daemon's pod manager the first time an item acts on a
+// named side, so a schedule that never does, or a dry run, makes no device
+// lookups.
+type lazyPod struct {
+	env *serviceEnv
+	mu  sync.Mutex
+	m   *state.Manager
+}
+
+func (p *lazyPod) manager(ctx context.Context) (*state.Manager, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.m == nil {
+		m, err := daemonPod(ctx, p.env)
+		if err != nil {
+			return nil, err
+		}
+		p.m = m
+	}
+	return p.m, nil
+}
+
+func (p *lazyPod) GetState(ctx context.Context) (*model.DeviceState, error) {
+	m, err := p.manager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetState(ctx)
+}
+
+func (p *lazyPod) SetTemperature(ctx context.Context, side model.Side, level int) error {
+	m, err := p.manager(ctx)
+	if err != nil {
+		return err
+	}
+	return m.SetTemperature(ctx, side, level)
+}
+
+func (p *lazyPod) TurnOn(ctx context.Context, side model.Side) error {
+	m, err := p.manager(ctx)
+	if err != nil {
+		return err
 	}
-	return os.ReadFile(cfg)
+	return m.TurnOn(ctx, side)
 }
 
-func parseSchedule(data []byte) ([]daemon.ScheduleItem, error) {
-	var raw struct {
-		Schedule []daemon.ScheduleItem `yaml:"schedule"`
+func (p *lazyPod) TurnOff(ctx context.Context, side model.Side) error {
+	m, err := p.manager(ctx)
+	if err != nil {
+		return err
 	}
-	if err := yaml.Unmarshal(data, &raw); err != nil {
+	return m.TurnOff(ctx, side)
+}
+
+// daemonPod returns the pod manager for the device the daemon's client
+// controls, shared with the other services so they see one cache.
+func daemonPod(ctx context.Context, env *serviceEnv) (*state.Manager, error) {
+	id, err := env.client.EnsureDeviceID(ctx)
+	if err != nil {
 		return nil, err
 	}
-	if len(raw.Schedule) == 0 {
-		return nil, fmt.Errorf("no schedule entries found")
+	for _, m := range env.managers {
+		if m.DeviceID() == id {
+			return m, nil
+		}
+	}
+	// The devices section can leave out the account's current pod.
+	logger.Warn("daemon pod is not among the served devices; tracking it separately", "device", id)
+	return state.NewManager(env.client, id), nil
+}
+
+func readConfigSchedule() ([]byte, error) {
+	if loadedConfig.File == "" {
+		return nil, fmt.Errorf("no config file loaded; specify --config")
+	}
+	return os.ReadFile(loadedConfig.File)
+}
+
+// daemonPlace returns the timezone and coordinates the schedule runs at.
+func daemonPlace() (daemon.Place, error) {
+	p := daemon.Place{
+		Location:  time.Local,
+		Latitude:  viper.GetFloat64("latitude"),
+		Longitude: viper.GetFloat64("longitude"),
+	}
+	if tzName := viper.GetString("timezone"); tzName != "" && tzName != "local" {
+		loc, err := time.LoadLocation(tzName)
+		if err != nil {
+			return daemon.Place{}, fmt.Errorf("load timezone: %w", err)
+		}
+		p.Location = loc
 	}
-	return raw.Schedule, nil
+	return p, nil
 }
 
 func defaultPIDFile(flagValue string) string {
@@ -92,3 +269,14 @@ func defaultPIDFile(flagValue string) string {
 	}
 	return filepath.Join(home, ".config", "eightctl", "daemon.pid")
 }
+
+func defaultStateFile(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "eightctl", "daemon-state.json")
+}
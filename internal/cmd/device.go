@@ -4,15 +4,11 @@ import (
 	"context"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-
-	"github.com/steipete/eightctl/internal/client"
-	"github.com/steipete/eightctl/internal/output"
 )
 
 var deviceCmd = &cobra.Command{Use: "device", Short: "Device info and priming"}
 
-func deviceSimple(name string, fn func(ctx context.Context) (any, error)) *cobra.Command {
+func deviceSimple(name string, fn func(ctx context.Context) (modelView, error)) *cobra.Command {
 	return &cobra.Command{Use: name, RunE: func(cmd *cobra.Command, args []string) error {
 		if err := requireAuthFields(); err != nil {
 			return err
@@ -21,11 +17,11 @@ func deviceSimple(name string, fn func(ctx context.Context) (any, error)) *cobra
 		if err != nil {
 			return err
 		}
-		return output.Print(output.Format(viper.GetString("output")), []string{name}, []map[string]any{{name: res}})
+		return printModel(name, res)
 	}}
 }
 
-func deviceSimpleHidden(name string, fn func(ctx context.Context) (any, error)) *cobra.Command {
+func deviceSimpleHidden(name string, fn func(ctx context.Context) (modelView, error)) *cobra.Command {
 	cmd := deviceSimple(name, fn)
 	cmd.Hidden = true // Verified broken 2026-01-29: Cannot GET
 	return cmd
@@ -34,34 +30,78 @@ func deviceSimpleHidden(name string, fn func(ctx context.Context) (any, error))
 func init() {
 	deviceCmd.AddCommand(
 		// Working endpoints
-		deviceSimple("info", func(ctx context.Context) (any, error) {
-			cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
-			return cl.Device().Info(ctx)
+		deviceSimple("info", func(ctx context.Context) (modelView, error) {
+			cl := newClient()
+			res, err := cl.Device().Info(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			return modelView{res.Raw, []string{"id", "model", "firmware", "online", "room_temp", "water_level", "priming"}, []map[string]any{{
+				"id":          res.ID,
+				"model":       res.Model,
+				"firmware":    res.FirmwareVersion,
+				"online":      res.Online,
+				"room_temp":   res.RoomTemperature,
+				"water_level": res.WaterLevel,
+				"priming":     res.Priming.Status,
+			}}}, nil
 		}),
-		deviceSimple("peripherals", func(ctx context.Context) (any, error) {
-			cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
-			return cl.Device().Peripherals(ctx)
+		deviceSimple("peripherals", func(ctx context.Context) (modelView, error) {
+			cl := newClient()
+			res, err := cl.Device().Peripherals(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			rows := make([]map[string]any, 0, len(res.Peripherals))
+			for _, p := range res.Peripherals {
+				rows = append(rows, map[string]any{"id": p.ID, "type": p.Type, "name": p.Name, "side": p.Side})
+			}
+			return modelView{res.Raw, []string{"id", "type", "name", "side"}, rows}, nil
 		}),
-		deviceSimple("online", func(ctx context.Context) (any, error) {
-			cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
-			return cl.Device().Online(ctx)
+		deviceSimple("online", func(ctx context.Context) (modelView, error) {
+			cl := newClient()
+			res, err := cl.Device().Online(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			return modelView{res.Raw, []string{"online", "last_heard"}, []map[string]any{{"online": res.Online, "last_heard": res.LastHeard}}}, nil
 		}),
 		// Broken endpoints (hidden)
-		deviceSimpleHidden("owner", func(ctx context.Context) (any, error) {
-			cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
-			return cl.Device().Owner(ctx)
+		deviceSimpleHidden("owner", func(ctx context.Context) (modelView, error) {
+			cl := newClient()
+			res, err := cl.Device().Owner(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			return modelView{res.Raw, []string{"owner_id", "email"}, []map[string]any{{"owner_id": res.OwnerID, "email": res.Owner.Email}}}, nil
 		}),
-		deviceSimpleHidden("warranty", func(ctx context.Context) (any, error) {
-			cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
-			return cl.Device().Warranty(ctx)
+		deviceSimpleHidden("warranty", func(ctx context.Context) (modelView, error) {
+			cl := newClient()
+			res, err := cl.Device().Warranty(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			return modelView{res.Raw, []string{"status", "expires"}, []map[string]any{{"status": res.Warranty.Status, "expires": res.Warranty.Expires}}}, nil
 		}),
-		deviceSimpleHidden("priming-tasks", func(ctx context.Context) (any, error) {
-			cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
-			return cl.Device().PrimingTasks(ctx)
+		deviceSimpleHidden("priming-tasks", func(ctx context.Context) (modelView, error) {
+			cl := newClient()
+			res, err := cl.Device().PrimingTasks(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			rows := make([]map[string]any, 0, len(res.Tasks))
+			for _, t := range res.Tasks {
+				rows = append(rows, map[string]any{"id": t.ID, "type": t.Type, "status": t.Status})
+			}
+			return modelView{res.Raw, []string{"id", "type", "status"}, rows}, nil
 		}),
-		deviceSimpleHidden("priming-schedule", func(ctx context.Context) (any, error) {
-			cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
-			return cl.Device().PrimingSchedule(ctx)
+		deviceSimpleHidden("priming-schedule", func(ctx context.Context) (modelView, error) {
+			cl := newClient()
+			res, err := cl.Device().PrimingSchedule(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			return modelView{res.Raw, []string{"enabled", "time"}, []map[string]any{{"enabled": res.Schedule.Enabled, "time": res.Schedule.Time}}}, nil
 		}),
 	)
 }
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/config"
+	"github.com/steipete/eightctl/internal/output"
+	"github.com/steipete/eightctl/internal/state"
+)
+
+var devicesCmd = &cobra.Command{
+	Use:   "devices",
+	Short: "List the pods on your account",
+	Long: `Lists every pod the account can control, with names from the devices
+section of the config file. Pass --device <id|name> to any command to act
+on a pod other than the account's current device.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuthFields(); err != nil {
+			return err
+		}
+		cl := newClient()
+		refs, err := cl.ListDevices(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		configured := configuredDevices()
+		selected := selectedDevice()
+		rows := make([]map[string]any, 0, len(refs))
+		for _, r := range refs {
+			name := deviceName(configured, r.ID)
+			if name == "" {
+				name = r.Name
+			}
+			rows = append(rows, map[string]any{
+				"id":       r.ID,
+				"name":     name,
+				"current":  r.Current,
+				"selected": r.ID == selected || (selected == "" && r.Current),
+			})
+		}
+
+		fields := viper.GetStringSlice("fields")
+		rows = output.FilterFields(rows, fields)
+		headers := fields
+		if len(headers) == 0 {
+			headers = []string{"id", "name", "current", "selected"}
+		}
+		return output.Print(output.Format(viper.GetString("output")), headers, rows)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(devicesCmd)
+}
+
+// configuredDevices returns the devices section of the config file.
+func configuredDevices() []config.Device {
+	var devices []config.Device
+	_ = viper.UnmarshalKey("devices", &devices)
+	return devices
+}
+
+// selectedDevice returns the device ID chosen with --device, EIGHTCTL_DEVICE,
+// or the device config key, or "" for the account's current device.
+func selectedDevice() string {
+	return config.ResolveDevice(configuredDevices(), viper.GetString("device"))
+}
+
+func deviceName(devices []config.Device, id string) string {
+	for _, d := range devices {
+		if d.ID == id {
+			return d.Name
+		}
+	}
+	return ""
+}
+
+// podManagers builds one state.Manager per pod a bridge should serve: the
+// --device selection, else every configured device, else every pod on the
+// account.
+func podManagers(ctx context.Context, cl *client.Client, opts ...state.Option) ([]*state.Manager, error) {
+	configured := configuredDevices()
+	var ids []string
+	switch {
+	case selectedDevice() != "":
+		ids = []string{selectedDevice()}
+	case len(configured) > 0:
+		for _, d := range configured {
+			ids = append(ids, d.ID)
+		}
+	default:
+		refs, err := cl.ListDevices(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list devices: %w", err)
+		}
+		for _, r := range refs {
+			ids = append(ids, r.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no devices found on this account")
+	}
+
+	managers := make([]*state.Manager, 0, len(ids))
+	for _, id := range ids {
+		podOpts := append([]state.Option{state.WithName(deviceName(configured, id))}, opts...)
+		managers = append(managers, state.NewManager(cl, id, podOpts...))
+	}
+	return managers, nil
+}
+
+// updatePodManagers applies the devices section and a new cache TTL to
+// running pod managers after a config reload.
+func updatePodManagers(managers []*state.Manager, cacheTTL time.Duration) {
+	configured := configuredDevices()
+	for _, m := range managers {
+		m.SetName(deviceName(configured, m.DeviceID()))
+		m.SetCacheTTL(cacheTTL)
+	}
+}
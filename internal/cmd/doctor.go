@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/99designs/keyring"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/config"
+	"github.com/steipete/eightctl/internal/output"
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+// Outcomes of a doctor check.
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// Clock skew beyond these makes token expiry and schedules unreliable.
+const (
+	clockSkewWarn = 30 * time.Second
+	clockSkewFail = 5 * time.Minute
+)
+
+// tokenExpiryWarn flags cached tokens about to run out.
+const tokenExpiryWarn = time.Hour
+
+type check struct {
+	name, status, detail string
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check configuration, credentials, and API connectivity",
+	Long: `Runs the checks behind most setup problems and reports pass, warn, or
+fail for each: config file and permissions, credentials, keyring backend,
+cached token, reachability and sign-in on every Eight Sleep API, clock skew
+against the server, device resolution, and side assignment.
+
+Exits non-zero when any check fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+		defer cancel()
+
+		checks := runDoctor(ctx, newClient())
+
+		failed := 0
+		rows := make([]map[string]any, 0, len(checks))
+		for _, c := range checks {
+			if c.status == checkFail {
+				failed++
+			}
+			rows = append(rows, map[string]any{"check": c.name, "status": c.status, "detail": c.detail})
+		}
+		fields := viper.GetStringSlice("fields")
+		rows = output.FilterFields(rows, fields)
+		headers := fields
+		if len(headers) == 0 {
+			headers = []string{"check", "status", "detail"}
+		}
+		if err := output.Print(output.Format(viper.GetString("output")), headers, rows); err != nil {
+			return err
+		}
+		if failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d check(s) failed", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// runDoctor runs every check in order. Network checks are skipped when
+// there is no way to sign in.
+func runDoctor(ctx context.Context, cl *client.Client) []check {
+	checks := doctorConfigChecks()
+	checks = append(checks, doctorKeyringCheck())
+	token, cached := doctorTokenCheck(cl)
+	creds := doctorCredentialsCheck(cached)
+	checks = append(checks, creds, token)
+	if creds.status == checkFail {
+		return append(checks, check{"api", checkWarn, "skipped: no credentials"})
+	}
+
+	probes := cl.Probe(ctx)
+	for _, p := range probes {
+		checks = append(checks, probeCheck(p))
+	}
+	checks = append(checks, clockSkewCheck(probes))
+	for _, p := range probes {
+		if p.API != "auth-api" && p.Err != nil {
+			// Device lookups would fail for the same reason.
+			return checks
+		}
+	}
+	return append(checks, doctorDeviceChecks(ctx, cl)...)
+}
+
+func doctorConfigChecks() []check {
+	path := loadedConfig.File
+	if path == "" {
+		where := "~/.config/eightctl/config.yaml"
+		if p := viper.GetString("config"); p != "" {
+			where = p
+		} else if p, err := config.DefaultFile(); err == nil {
+			where = p
+		}
+		return []check{{"config file", checkWarn, "none found at " + where + "; using flags and environment"}}
+	}
+	checks := []check{{"config file", checkPass, path}}
+	if err := config.WarnInsecurePerms(path); err != nil {
+		checks = append(checks, check{"config permissions", checkWarn, err.Error()})
+	} else {
+		checks = append(checks, check{"config permissions", checkPass, "not readable by other users"})
+	}
+	if p := viper.GetString("profile"); p != "" {
+		checks = append(checks, check{"profile", checkPass, p})
+	}
+	return checks
+}
+
+func doctorKeyringCheck() check {
+	info, err := tokencache.Describe()
+	switch {
+	case err != nil:
+		return check{"keyring", checkFail, fmt.Sprintf("no usable backend: %v", err)}
+	case info.Passphrase == tokencache.PassphraseBuiltIn:
+		return check{"keyring", checkWarn, "file backend with the built-in passphrase; set EIGHTCTL_KEYRING_PASSWORD and run 'eightctl keyring migrate'"}
+	case info.Passphrase != "":
+		return check{"keyring", checkPass, fmt.Sprintf("file backend, %s passphrase", info.Passphrase)}
+	default:
+		return check{"keyring", checkPass, info.Backend}
+	}
+}
+
+// doctorTokenCheck reports the cached token for cl's identity and whether
+// one was found.
+func doctorTokenCheck(cl *client.Client) (check, bool) {
+	cached, err := tokencache.Load(cl.Identity(), viper.GetString("user_id"))
+	if err != nil {
+		detail := "none cached; the next command signs in"
+		if !errors.Is(err, keyring.ErrKeyNotFound) {
+			detail = fmt.Sprintf("unreadable: %v", err)
+		}
+		return check{"cached token", checkWarn, detail}, false
+	}
+	left := time.Until(cached.ExpiresAt).Round(time.Minute)
+	detail := fmt.Sprintf("valid for %s (until %s)", strings.TrimSuffix(left.String(), "0s"), cached.ExpiresAt.Local().Format("2006-01-02 15:04"))
+	if cached.RefreshToken != "" {
+		detail += ", refreshable"
+	}
+	if left < tokenExpiryWarn && cached.RefreshToken == "" {
+		return check{"cached token", checkWarn, detail}, true
+	}
+	return check{"cached token", checkPass, detail}, true
+}
+
+func doctorCredentialsCheck(haveToken bool) check {
+	var missing []string
+	if viper.GetString("email") == "" {
+		missing = append(missing, "email")
+	}
+	if !havePassword() {
+		missing = append(missing, "password")
+	}
+	switch {
+	case len(missing) == 0:
+		return check{"credentials", checkPass, "email and password set"}
+	case haveToken:
+		return check{"credentials", checkWarn, "missing " + strings.Join(missing, ", ") + "; relying on the cached token"}
+	default:
+		return check{"credentials", checkFail, "missing " + strings.Join(missing, ", ") + " and no cached token"}
+	}
+}
+
+func probeCheck(p client.ProbeResult) check {
+	name := p.API
+	switch {
+	case errors.Is(p.Err, client.ErrUnauthorized), errors.Is(p.Err, client.ErrForbidden):
+		return check{name, checkFail, fmt.Sprintf("authentication rejected: %v", p.Err)}
+	case errors.Is(p.Err, client.ErrNotFound):
+		// The host answered; only the probe endpoint is missing.
+		return check{name, checkWarn, fmt.Sprintf("reachable, but %v", p.Err)}
+	case p.Err != nil:
+		return check{name, checkFail, client.RedactText(p.Err.Error())}
+	}
+	return check{name, checkPass, fmt.Sprintf("%d %s in %s", p.Status, http.StatusText(p.Status), p.Latency.Round(time.Millisecond))}
+}
+
+// clockSkewCheck compares the local clock with the Date header of the
+// first response that had one.
+func clockSkewCheck(probes []client.ProbeResult) check {
+	for _, p := range probes {
+		if p.ServerTime.IsZero() {
+			continue
+		}
+		// Date is truncated to the second; compare with the middle of it.
+		skew := p.LocalTime.Sub(p.ServerTime.Add(500 * time.Millisecond))
+		abs := skew
+		if abs < 0 {
+			abs = -abs
+		}
+		dir := "ahead of"
+		if skew < 0 {
+			dir = "behind"
+		}
+		detail := fmt.Sprintf("local clock %s %s the server", abs.Round(time.Second), dir)
+		switch {
+		case abs > clockSkewFail:
+			return check{"clock skew", checkFail, detail}
+		case abs > clockSkewWarn:
+			return check{"clock skew", checkWarn, detail}
+		}
+		return check{"clock skew", checkPass, detail}
+	}
+	return check{"clock skew", checkWarn, "no server Date header to compare against"}
+}
+
+// doctorDeviceChecks verifies the selected and configured pods are on the
+// account and that the signed-in user has a side.
+func doctorDeviceChecks(ctx context.Context, cl *client.Client) []check {
+	refs, err := cl.ListDevices(ctx)
+	if err != nil {
+		return []check{{"device", checkFail, fmt.Sprintf("list devices: %v", err)}}
+	}
+	onAccount := map[string]bool{}
+	for _, r := range refs {
+		onAccount[r.ID] = true
+	}
+
+	var checks []check
+	for _, d := range configuredDevices() {
+		if !onAccount[d.ID] {
+			checks = append(checks, check{"configured devices", checkWarn, fmt.Sprintf("%s (%s) is not on this account", d.Name, d.ID)})
+		}
+	}
+
+	id := selectedDevice()
+	switch {
+	case id != "" && !onAccount[id]:
+		return append(checks, check{"device", checkFail, fmt.Sprintf("%q does not resolve to a pod on this account", viper.GetString("device"))})
+	case id == "":
+		if id, err = cl.EnsureDeviceID(ctx); err != nil {
+			return append(checks, check{"device", checkFail, err.Error()})
+		}
+	}
+	detail := id
+	if name := deviceName(configuredDevices(), id); name != "" {
+		detail = fmt.Sprintf("%s (%s)", id, name)
+	}
+	checks = append(checks, check{"device", checkPass, fmt.Sprintf("%s, %d pod(s) on account", detail, len(refs))})
+
+	dev, err := cl.Device().GetWithUsers(ctx)
+	if err != nil {
+		return append(checks, check{"side", checkFail, fmt.Sprintf("read side assignment: %v", err)})
+	}
+	if err := cl.EnsureUserID(ctx); err != nil {
+		return append(checks, check{"side", checkFail, err.Error()})
+	}
+	sides := fmt.Sprintf("left=%s right=%s", orNone(dev.LeftUserID), orNone(dev.RightUserID))
+	uid := cl.CurrentUserID()
+	switch uid {
+	case dev.LeftUserID:
+		return append(checks, check{"side", checkPass, "you are on the left; " + sides})
+	case dev.RightUserID:
+		return append(checks, check{"side", checkPass, "you are on the right; " + sides})
+	}
+	return append(checks, check{"side", checkWarn, fmt.Sprintf("user %s is not assigned a side; %s", uid, sides)})
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/mockapi"
+)
+
+func useMockAPI(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(mockapi.New())
+	t.Cleanup(srv.Close)
+	viper.Set("base_url", srv.URL+"/v1")
+	viper.Set("app_api_base_url", srv.URL+"/v1")
+	viper.Set("auth_url", srv.URL+"/v1/tokens")
+	viper.Set("email", mockapi.DefaultLeftEmail)
+	viper.Set("password", "mock")
+}
+
+func checkStatuses(checks []check) map[string]string {
+	got := map[string]string{}
+	for _, c := range checks {
+		got[c.name] = c.status
+	}
+	return got
+}
+
+func TestDoctorAgainstMockAPI(t *testing.T) {
+	useTempKeyring(t)
+	resetViper(t)
+	useMockAPI(t)
+
+	got := checkStatuses(runDoctor(context.Background(), newClient()))
+	for _, name := range []string{"credentials", "auth-api", "client-api v1", "client-api v3", "app-api", "clock skew", "device", "side"} {
+		if got[name] != checkPass {
+			t.Errorf("%s = %q, want pass (all: %v)", name, got[name], got)
+		}
+	}
+	if got["cached token"] != checkWarn {
+		t.Errorf("cached token = %q before first sign-in, want warn", got["cached token"])
+	}
+}
+
+func TestDoctorUnknownDeviceFails(t *testing.T) {
+	useTempKeyring(t)
+	resetViper(t)
+	useMockAPI(t)
+	viper.Set("device", "attic")
+
+	if got := checkStatuses(runDoctor(context.Background(), newClient())); got["device"] != checkFail {
+		t.Fatalf("device = %q, want fail", got["device"])
+	}
+}
+
+func TestDoctorSkipsNetworkWithoutCredentials(t *testing.T) {
+	useTempKeyring(t)
+	resetViper(t)
+
+	got := checkStatuses(runDoctor(context.Background(), newClient()))
+	if got["credentials"] != checkFail {
+		t.Fatalf("credentials = %q, want fail", got["credentials"])
+	}
+	if _, ok := got["auth-api"]; ok {
+		t.Fatalf("network checks should be skipped: %v", got)
+	}
+}
+
+func TestClockSkewCheck(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		skew time.Duration
+		want string
+	}{
+		{2 * time.Second, checkPass},
+		{-time.Minute, checkWarn},
+		{10 * time.Minute, checkFail},
+	}
+	for _, tc := range cases {
+		p := client.ProbeResult{LocalTime: now, ServerTime: now.Add(-tc.skew), Status: http.StatusOK}
+		if got := clockSkewCheck([]client.ProbeResult{p}); got.status != tc.want {
+			t.Errorf("skew %s: got %s (%s), want %s", tc.skew, got.status, got.detail, tc.want)
+		}
+	}
+	if got := clockSkewCheck(nil); got.status != checkWarn {
+		t.Errorf("no Date header: got %s, want warn", got.status)
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/steipete/eightctl/internal/client"
+)
+
+// Process exit codes. Scripts can branch on these instead of parsing stderr.
+const (
+	ExitOK             = 0
+	ExitError          = 1 // any other failure
+	ExitUnauthorized   = 3 // credentials rejected (401)
+	ExitForbidden      = 4 // account lacks access (403)
+	ExitNotFound       = 5 // resource or endpoint missing (404)
+	ExitRateLimited    = 6 // still throttled after retries (429)
+	ExitInvalidRequest = 7 // request rejected as invalid (400/422)
+)
+
+// exitCode maps an error returned by a command to a process exit code.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, client.ErrUnauthorized):
+		return ExitUnauthorized
+	case errors.Is(err, client.ErrForbidden):
+		return ExitForbidden
+	case errors.Is(err, client.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, client.ErrRateLimited):
+		return ExitRateLimited
+	case errors.Is(err, client.ErrInvalidRequest):
+		return ExitInvalidRequest
+	default:
+		return ExitError
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/steipete/eightctl/internal/client"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"generic", errors.New("boom"), ExitError},
+		{"unauthorized", &client.APIError{StatusCode: http.StatusUnauthorized}, ExitUnauthorized},
+		{"forbidden", &client.APIError{StatusCode: http.StatusForbidden}, ExitForbidden},
+		{"not found", &client.APIError{StatusCode: http.StatusNotFound}, ExitNotFound},
+		{"wrapped rate limit", fmt.Errorf("login: %w", &client.APIError{StatusCode: http.StatusTooManyRequests}), ExitRateLimited},
+		{"invalid", &client.APIError{StatusCode: http.StatusBadRequest}, ExitInvalidRequest},
+		{"server error", &client.APIError{StatusCode: http.StatusInternalServerError}, ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/exporter"
+)
+
+var exporterCmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Serve pod and sleep state as Prometheus metrics",
+	Long: `Starts an HTTP server exposing /metrics in the Prometheus text format.
+
+Per pod: eightsleep_up, eightsleep_room_temperature_celsius,
+eightsleep_has_water, eightsleep_priming, eightsleep_needs_priming.
+
+Per side (labels device, side): eightsleep_target_level,
+eightsleep_power_state{state}, eightsleep_bed_temperature_celsius,
+eightsleep_heart_rate_bpm, eightsleep_hrv_milliseconds,
+eightsleep_breath_rate_per_minute, eightsleep_present, eightsleep_sleep_score.
+
+Client: eightctl_api_requests_total{host,method,code} and
+eightctl_api_request_duration_seconds{host}.
+
+Scrapes read cached state; --cache-ttl sets how often the API is polled.
+Every configured pod is exported unless --device selects one.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireAuthFields(); err != nil {
+			return err
+		}
+		return runServices("exporter")
+	},
+}
+
+// startExporter serves /metrics for every pod.
+func startExporter(_ context.Context, env *serviceEnv) (*service, error) {
+	listen := viper.GetString("exporter.listen")
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", listen, err)
+	}
+	srv := &http.Server{
+		Handler:           exporter.New(env.managers, env.metrics).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	failed := make(chan error, 1)
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			failed <- fmt.Errorf("exporter server: %w", err)
+		}
+	}()
+
+	fmt.Printf("Exporter listening on %s (%d pod(s))\n", ln.Addr(), len(env.managers))
+
+	return &service{
+		stop: func() error {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return srv.Shutdown(shutdownCtx)
+		},
+		failed: failed,
+	}, nil
+}
+
+func init() {
+	rootCmd.AddCommand(exporterCmd)
+
+	exporterCmd.Flags().String("listen", ":9757", "address to serve /metrics on")
+	exporterCmd.Flags().Duration("cache-ttl", time.Minute, "how long pod state is reused between scrapes")
+
+	viper.BindPFlag("exporter.listen", exporterCmd.Flags().Lookup("listen"))
+	viper.BindPFlag("exporter.cache-ttl", exporterCmd.Flags().Lookup("cache-ttl"))
+}
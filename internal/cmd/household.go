@@ -4,36 +4,105 @@ import (
 	"context"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/steipete/eightctl/internal/client"
-	"github.com/steipete/eightctl/internal/output"
 )
 
 var householdCmd = &cobra.Command{Use: "household", Short: "Household info", Hidden: true} // Verified broken 2026-01-29: Cannot GET
 
-func householdSimple(name string, fn func(*client.Client, context.Context) (any, error)) *cobra.Command {
+func householdSimple(name string, fn func(*client.Client, context.Context) (modelView, error)) *cobra.Command {
 	return &cobra.Command{Use: name, RunE: func(cmd *cobra.Command, args []string) error {
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		res, err := fn(cl, context.Background())
 		if err != nil {
 			return err
 		}
-		return output.Print(output.Format(viper.GetString("output")), []string{name}, []map[string]any{{name: res}})
+		return printModel(name, res)
 	}}
 }
 
+func householdUserRows(users []client.HouseholdUser) []map[string]any {
+	rows := make([]map[string]any, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, map[string]any{"id": u.ID, "first_name": u.FirstName, "last_name": u.LastName, "email": u.Email, "role": u.Role})
+	}
+	return rows
+}
+
+var householdUserHeaders = []string{"id", "first_name", "last_name", "email", "role"}
+
 func init() {
 	householdCmd.AddCommand(
-		householdSimple("summary", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Household().Summary(ctx) }),
-		householdSimple("schedule", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Household().Schedule(ctx) }),
-		householdSimple("current-set", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Household().CurrentSet(ctx) }),
-		householdSimple("invitations", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Household().Invitations(ctx) }),
-		householdSimple("devices", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Household().Devices(ctx) }),
-		householdSimple("users", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Household().Users(ctx) }),
-		householdSimple("guests", func(cl *client.Client, ctx context.Context) (any, error) { return cl.Household().Guests(ctx) }),
+		householdSimple("summary", func(cl *client.Client, ctx context.Context) (modelView, error) {
+			res, err := cl.Household().Summary(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			var rows []map[string]any
+			for _, h := range res.Households {
+				for _, s := range h.Sets {
+					rows = append(rows, map[string]any{"household": h.ID, "name": h.Name, "set": s.ID, "device": s.DeviceID, "user": s.UserID, "side": s.Side})
+				}
+			}
+			return modelView{res.Raw, []string{"household", "name", "set", "device", "user", "side"}, rows}, nil
+		}),
+		householdSimple("schedule", func(cl *client.Client, ctx context.Context) (modelView, error) {
+			res, err := cl.Household().Schedule(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			rows := []map[string]any{}
+			if r := res.Schedule; r != (client.HouseholdReturn{}) {
+				rows = append(rows, map[string]any{"set": r.SetID, "return_date": r.ReturnDate})
+			}
+			return modelView{res.Raw, []string{"set", "return_date"}, rows}, nil
+		}),
+		householdSimple("current-set", func(cl *client.Client, ctx context.Context) (modelView, error) {
+			res, err := cl.Household().CurrentSet(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			s := res.CurrentSet
+			return modelView{res.Raw, []string{"set", "device", "side"}, []map[string]any{{"set": s.ID, "device": s.DeviceID, "side": s.Side}}}, nil
+		}),
+		householdSimple("invitations", func(cl *client.Client, ctx context.Context) (modelView, error) {
+			res, err := cl.Household().Invitations(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			rows := make([]map[string]any, 0, len(res.Invitations))
+			for _, inv := range res.Invitations {
+				rows = append(rows, map[string]any{"household": inv.HouseholdID, "invited_by": inv.InvitedBy, "email": inv.Email, "status": inv.Status})
+			}
+			return modelView{res.Raw, []string{"household", "invited_by", "email", "status"}, rows}, nil
+		}),
+		householdSimple("devices", func(cl *client.Client, ctx context.Context) (modelView, error) {
+			res, err := cl.Household().Devices(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			rows := make([]map[string]any, 0, len(res.Devices))
+			for _, d := range res.Devices {
+				rows = append(rows, map[string]any{"id": d.ID, "household": d.HouseholdID, "name": d.Name, "left_user": d.LeftUserID, "right_user": d.RightUserID})
+			}
+			return modelView{res.Raw, []string{"id", "household", "name", "left_user", "right_user"}, rows}, nil
+		}),
+		householdSimple("users", func(cl *client.Client, ctx context.Context) (modelView, error) {
+			res, err := cl.Household().Users(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			return modelView{res.Raw, householdUserHeaders, householdUserRows(res.Users)}, nil
+		}),
+		householdSimple("guests", func(cl *client.Client, ctx context.Context) (modelView, error) {
+			res, err := cl.Household().Guests(ctx)
+			if err != nil {
+				return modelView{}, err
+			}
+			return modelView{res.Raw, householdUserHeaders, householdUserRows(res.Guests)}, nil
+		}),
 	)
 }
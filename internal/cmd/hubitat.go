@@ -3,17 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/steipete/eightctl/internal/adapter/hubitat"
-	"github.com/steipete/eightctl/internal/client"
-	"github.com/steipete/eightctl/internal/state"
 )
 
 var hubitatCmd = &cobra.Command{
@@ -27,53 +22,41 @@ The server provides endpoints for:
   - GET /{side}/status - Status for left or right side
   - PUT /{side}/on - Turn on a side
   - PUT /{side}/off - Turn off a side
-  - PUT /{side}/temperature?level=N - Set temperature level (-100 to 100)`,
+  - PUT /{side}/temperature?level=N - Set temperature level (-100 to 100)
+
+These act on the first pod. With several pods, GET /devices lists them and
+every route is also served under /devices/{id|name}/..., e.g.
+PUT /devices/guest-room/left/on.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-
-		cl := client.New(
-			viper.GetString("email"),
-			viper.GetString("password"),
-			viper.GetString("user_id"),
-			viper.GetString("client_id"),
-			viper.GetString("client_secret"),
-		)
-
-		ctx := context.Background()
-
-		deviceID, err := cl.EnsureDeviceID(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get device ID: %w", err)
-		}
-
-		pollInterval := viper.GetDuration("hubitat.poll-interval")
-		mgr := state.NewManager(cl, deviceID, state.WithCacheTTL(pollInterval))
-
-		port := viper.GetInt("hubitat.port")
-		adapter := hubitat.New(mgr, port, pollInterval)
-
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-		if err := adapter.Start(ctx); err != nil {
-			return fmt.Errorf("failed to start server: %w", err)
-		}
-
-		fmt.Printf("Hubitat server listening on port %d\n", port)
-
-		<-sigChan
-		fmt.Println("\nShutting down...")
-
-		if err := adapter.Stop(); err != nil {
-			return fmt.Errorf("failed to stop server: %w", err)
-		}
-
-		return nil
+		return runServices("hubitat")
 	},
 }
 
+// startHubitat serves the Hubitat HTTP API for every pod. A new port needs
+// a restart; names and the poll interval apply through the shared pod
+// managers.
+func startHubitat(ctx context.Context, env *serviceEnv) (*service, error) {
+	port := viper.GetInt("hubitat.port")
+	adapter := hubitat.NewMulti(env.managers, port, viper.GetDuration("hubitat.poll-interval"))
+	if err := adapter.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start server: %w", err)
+	}
+
+	fmt.Printf("Hubitat server listening on port %d (%d pod(s))\n", port, len(env.managers))
+
+	return &service{
+		stop: func() error {
+			if err := adapter.Stop(); err != nil {
+				return fmt.Errorf("failed to stop server: %w", err)
+			}
+			return nil
+		},
+	}, nil
+}
+
 func init() {
 	rootCmd.AddCommand(hubitatCmd)
 
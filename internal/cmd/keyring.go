@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+// Environment variables holding file keyring passphrases. They are read
+// from the environment only, never from the config file.
+const (
+	keyringPasswordEnv    = "EIGHTCTL_KEYRING_PASSWORD"
+	keyringOldPasswordEnv = "EIGHTCTL_KEYRING_OLD_PASSWORD"
+)
+
+var keyringCmd = &cobra.Command{
+	Use:   "keyring",
+	Short: "Manage the token cache keyring",
+	Long: `Tokens are cached in the OS keyring (macOS Keychain, Secret Service,
+Windows Credential Manager). Where none is available, e.g. on a headless
+Linux server, they go to encrypted files in ~/.config/eightctl/keyring.
+
+Set keyring_backend in the config file (or EIGHTCTL_KEYRING_BACKEND) to
+force a backend. The file backend's passphrase comes from
+EIGHTCTL_KEYRING_PASSWORD, else a prompt on a terminal. Without either,
+tokens are not cached unless keyring_backend is file-insecure, which
+accepts a built-in passphrase. Keyrings written by older versions use the
+built-in passphrase and stay readable until migrated.`,
+}
+
+var keyringMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Re-encrypt or move cached tokens into the configured keyring",
+	Long: `Copies every cached token from the --from backend into the configured
+keyring. Run it after setting EIGHTCTL_KEYRING_PASSWORD to re-encrypt file
+keyring entries that still use the built-in passphrase, or after changing
+keyring_backend to move tokens to the new backend.
+
+The old file passphrase is read from EIGHTCTL_KEYRING_OLD_PASSWORD and
+defaults to the built-in one.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from := viper.GetString("keyring.from")
+		moved, skipped, err := tokencache.Migrate(tokencache.Options{
+			Backend:      from,
+			FilePassword: os.Getenv(keyringOldPasswordEnv),
+		})
+		if err != nil {
+			return err
+		}
+		info, err := tokencache.Describe()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Migrated %d token(s) from %s to %s", moved, from, info.Backend)
+		if info.Passphrase != "" {
+			fmt.Printf(" (%s passphrase)", info.Passphrase)
+		}
+		fmt.Println()
+		if skipped > 0 {
+			fmt.Printf("Skipped %d entry(ies) the old passphrase could not decrypt\n", skipped)
+		}
+		return nil
+	},
+}
+
+func init() {
+	keyringMigrateCmd.Flags().String("from", "file", "backend to migrate tokens from")
+	viper.BindPFlag("keyring.from", keyringMigrateCmd.Flags().Lookup("from"))
+
+	keyringCmd.AddCommand(keyringMigrateCmd)
+	rootCmd.AddCommand(keyringCmd)
+}
+
+// keyringOptions configures the token cache from keyring_backend and
+// EIGHTCTL_KEYRING_PASSWORD. The passphrase prompt is only offered on a
+// terminal, so daemons never block on it.
+func keyringOptions() tokencache.Options {
+	o := tokencache.Options{
+		Backend:      viper.GetString("keyring_backend"),
+		FilePassword: os.Getenv(keyringPasswordEnv),
+	}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		o.Prompt = keyringPrompt
+	}
+	return o
+}
+
+// keyringPrompt reads a passphrase without echo. It writes to stderr so
+// JSON and CSV output stay clean.
+func keyringPrompt(prompt string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	pw, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(pw), nil
+}
@@ -22,10 +22,16 @@ var loginCmd = &cobra.Command{
 	Long: `Authenticate with Eight Sleep using email and password.
 
 On success, the access token is cached in your system keychain (macOS Keychain,
-Linux SecretService, or Windows Credential Manager) for future use.
+Linux SecretService, or Windows Credential Manager) for future use. Without
+one, it goes to a file keyring; see 'eightctl keyring --help'.
 
 You can provide credentials via flags, environment variables (EIGHTCTL_EMAIL,
-EIGHTCTL_PASSWORD), or interactively when prompted.`,
+EIGHTCTL_PASSWORD), password_command or password_file in the config, or
+interactively when prompted.
+
+With --save-password the password is stored in the keyring next to the
+token, so later sign-ins need no plaintext password anywhere. Remove it
+with 'eightctl logout --forget-password'.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		email := viper.GetString("email")
 		password := viper.GetString("password")
@@ -44,6 +50,14 @@ EIGHTCTL_PASSWORD), or interactively when prompted.`,
 			}
 		}
 
+		if password == "" {
+			pw, err := lookupPassword(cmd.Context())
+			if err != nil {
+				return err
+			}
+			password = pw
+		}
+
 		// Prompt for password if not provided
 		if password == "" {
 			fmt.Print("Password: ")
@@ -58,19 +72,27 @@ EIGHTCTL_PASSWORD), or interactively when prompted.`,
 			}
 		}
 
-		cl := client.New(
+		cl := configureClient(client.New(
 			email,
 			password,
 			viper.GetString("user_id"),
 			viper.GetString("client_id"),
 			viper.GetString("client_secret"),
-		)
+			clientOptions()...,
+		))
 
 		ctx := context.Background()
 		if err := cl.Authenticate(ctx); err != nil {
 			return fmt.Errorf("authentication failed: %w", err)
 		}
 
+		if viper.GetBool("login.save-password") {
+			if err := tokencache.SavePassword(email, password); err != nil {
+				return fmt.Errorf("save password: %w", err)
+			}
+			fmt.Println("Password saved to the keyring")
+		}
+
 		// Load cached token to get expiration time
 		cached, err := tokencache.Load(cl.Identity(), cl.UserID)
 		if err != nil {
@@ -86,5 +108,7 @@ EIGHTCTL_PASSWORD), or interactively when prompted.`,
 }
 
 func init() {
+	loginCmd.Flags().Bool("save-password", false, "store the password in the keyring for later sign-ins")
+	viper.BindPFlag("login.save-password", loginCmd.Flags().Lookup("save-password"))
 	rootCmd.AddCommand(loginCmd)
 }
@@ -6,7 +6,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/tokencache"
 )
 
@@ -14,17 +13,23 @@ var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Clear cached authentication token",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		c := client.New(
-			viper.GetString("email"),
-			viper.GetString("password"),
-			viper.GetString("user_id"),
-			viper.GetString("client_id"),
-			viper.GetString("client_secret"),
-		)
+		c := newClient()
 		if err := tokencache.Clear(c.Identity()); err != nil {
 			return fmt.Errorf("clear token: %w", err)
 		}
+		if viper.GetBool("logout.forget-password") {
+			if err := tokencache.ClearPassword(c.Email); err != nil {
+				return fmt.Errorf("clear password: %w", err)
+			}
+			fmt.Println("Logged out (token cache and saved password cleared)")
+			return nil
+		}
 		fmt.Println("Logged out (token cache cleared)")
 		return nil
 	},
 }
+
+func init() {
+	logoutCmd.Flags().Bool("forget-password", false, "also remove the password saved by 'login --save-password'")
+	viper.BindPFlag("logout.forget-password", logoutCmd.Flags().Lookup("forget-password"))
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/output"
 )
 
@@ -20,7 +19,7 @@ var metricsTrendsCmd = &cobra.Command{Use: "trends", Short: "Get sleep trends fo
 	from, _ := cmd.Flags().GetString("from")
 	to, _ := cmd.Flags().GetString("to")
 	tz := viper.GetString("timezone")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	var out any
 	if err := cl.Metrics().Trends(context.Background(), from, to, tz, &out); err != nil {
 		return err
@@ -33,7 +32,7 @@ var metricsIntervalsCmd = &cobra.Command{Use: "intervals", RunE: func(cmd *cobra
 		return err
 	}
 	id := viper.GetString("id")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	var out any
 	if err := cl.Metrics().Intervals(context.Background(), id, &out); err != nil {
 		return err
@@ -45,7 +44,7 @@ var metricsSummaryCmd = &cobra.Command{Use: "summary", Hidden: true, RunE: func(
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	var out any
 	if err := cl.Metrics().Summary(context.Background(), &out); err != nil {
 		return err
@@ -57,7 +56,7 @@ var metricsAggregateCmd = &cobra.Command{Use: "aggregate", Hidden: true, RunE: f
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	var out any
 	if err := cl.Metrics().Aggregate(context.Background(), &out); err != nil {
 		return err
@@ -69,7 +68,7 @@ var metricsInsightsCmd = &cobra.Command{Use: "insights", Hidden: true, RunE: fun
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	var out any
 	if err := cl.Metrics().Insights(context.Background(), &out); err != nil {
 		return err
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/steipete/eightctl/internal/mockapi"
+)
+
+var mockServerCmd = &cobra.Command{
+	Use:   "mock-server",
+	Short: "Run an in-memory mock of the Eight Sleep cloud API",
+	Long: `Starts a local HTTP server that emulates the Eight Sleep endpoints eightctl
+uses: token auth, /users/me, /devices/{id}, temperature and power per side,
+alarms, schedules, bedtime, away mode, nap and hot flash modes, and trends.
+
+State lives in memory and resets on restart. Any password is accepted; sign
+in as left@example.com or right@example.com to pick a side. Point other
+eightctl commands at it with the environment variables printed on startup.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listen, _ := cmd.Flags().GetString("listen")
+		deviceID, _ := cmd.Flags().GetString("device-id")
+
+		ln, err := net.Listen("tcp", listen)
+		if err != nil {
+			return fmt.Errorf("listen: %w", err)
+		}
+		root := "http://" + ln.Addr().String()
+		srv := &http.Server{
+			Handler:           mockapi.New(mockapi.WithDeviceID(deviceID)),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Printf("Mock Eight Sleep API listening on %s\n\n", root)
+		fmt.Printf("export EIGHTCTL_BASE_URL=%s\n", mockapi.BaseURL(root))
+		fmt.Printf("export EIGHTCTL_APP_API_BASE_URL=%s\n", mockapi.BaseURL(root))
+		fmt.Printf("export EIGHTCTL_AUTH_URL=%s\n", mockapi.AuthURL(root))
+		fmt.Printf("export EIGHTCTL_EMAIL=%s EIGHTCTL_PASSWORD=mock\n", mockapi.DefaultLeftEmail)
+
+		errCh := make(chan error, 1)
+		go func() { errCh <- srv.Serve(ln) }()
+
+		select {
+		case err := <-errCh:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		case <-ctx.Done():
+		}
+		fmt.Println("\nShutting down...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mockServerCmd)
+
+	mockServerCmd.Flags().String("listen", "127.0.0.1:8380", "address to listen on")
+	mockServerCmd.Flags().String("device-id", mockapi.DefaultDeviceID, "device ID reported by the mock pod")
+}
@@ -3,17 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/steipete/eightctl/internal/adapter/mqtt"
-	"github.com/steipete/eightctl/internal/client"
-	"github.com/steipete/eightctl/internal/state"
 )
 
 var mqttCmd = &cobra.Command{
@@ -22,6 +17,9 @@ var mqttCmd = &cobra.Command{
 	Long: `Starts an MQTT bridge that publishes Eight Sleep Pod state to Home Assistant
 via MQTT Discovery and subscribes to command topics for control.
 
+Every pod on the account is bridged, each as its own Home Assistant device;
+use --device or the devices config section to limit or name them.
+
 The bridge:
   - Publishes MQTT Discovery configs for climate entities
   - Polls device state and publishes to state topics
@@ -33,59 +31,44 @@ and Home Assistant.`,
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-
-		cl := client.New(
-			viper.GetString("email"),
-			viper.GetString("password"),
-			viper.GetString("user_id"),
-			viper.GetString("client_id"),
-			viper.GetString("client_secret"),
-		)
-
-		ctx := context.Background()
-
-		deviceID, err := cl.EnsureDeviceID(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to get device ID: %w", err)
-		}
-
-		pollInterval := viper.GetDuration("mqtt.poll-interval")
-		mgr := state.NewManager(cl, deviceID, state.WithCacheTTL(pollInterval))
-
-		cfg := mqtt.Config{
-			BrokerURL:    viper.GetString("mqtt.broker"),
-			TopicPrefix:  viper.GetString("mqtt.topic-prefix"),
-			DeviceID:     deviceID,
-			DeviceName:   viper.GetString("mqtt.device-name"),
-			PollInterval: pollInterval,
-			ClientID:     viper.GetString("mqtt.client-id"),
-			Username:     viper.GetString("mqtt.mqtt-username"),
-			Password:     viper.GetString("mqtt.mqtt-password"),
-		}
-
-		adapter := mqtt.New(cfg, mgr)
-
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
-
-		if err := adapter.Start(ctx); err != nil {
-			return fmt.Errorf("failed to start MQTT bridge: %w", err)
-		}
-
-		fmt.Printf("MQTT bridge connected to %s\n", cfg.BrokerURL)
-		fmt.Printf("Publishing to %s discovery prefix\n", cfg.TopicPrefix)
-
-		<-sigChan
-		fmt.Println("\nShutting down...")
-
-		if err := adapter.Stop(); err != nil {
-			return fmt.Errorf("failed to stop MQTT bridge: %w", err)
-		}
-
-		return nil
+		return runServices("mqtt")
 	},
 }
 
+// startMQTT connects the MQTT bridge for every pod.
+func startMQTT(ctx context.Context, env *serviceEnv) (*service, error) {
+	cfg := mqtt.Config{
+		BrokerURL:    viper.GetString("mqtt.broker"),
+		TopicPrefix:  viper.GetString("mqtt.topic-prefix"),
+		DeviceID:     env.managers[0].DeviceID(),
+		DeviceName:   viper.GetString("mqtt.device-name"),
+		PollInterval: viper.GetDuration("mqtt.poll-interval"),
+		ClientID:     viper.GetString("mqtt.client-id"),
+		Username:     viper.GetString("mqtt.mqtt-username"),
+		Password:     viper.GetString("mqtt.mqtt-password"),
+	}
+	adapter := mqtt.New(cfg, env.managers...)
+	if err := adapter.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start MQTT bridge: %w", err)
+	}
+
+	fmt.Printf("MQTT bridge connected to %s\n", cfg.BrokerURL)
+	fmt.Printf("Publishing %d pod(s) to %s discovery prefix\n", len(env.managers), cfg.TopicPrefix)
+
+	return &service{
+		// Broker settings need a restart; the rest applies live.
+		reload: func() error {
+			return adapter.Reconfigure(viper.GetDuration("mqtt.poll-interval"), viper.GetString("mqtt.device-name"))
+		},
+		stop: func() error {
+			if err := adapter.Stop(); err != nil {
+				return fmt.Errorf("failed to stop MQTT bridge: %w", err)
+			}
+			return nil
+		},
+	}, nil
+}
+
 func init() {
 	rootCmd.AddCommand(mqttCmd)
 
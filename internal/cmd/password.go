@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/config"
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+// lookupPassword finds the password when none is set by flag, environment,
+// or config: password_command first, then password_file, then a password
+// saved by 'login --save-password'. It returns "" when there is none.
+func lookupPassword(ctx context.Context) (string, error) {
+	configMu.RLock()
+	command := viper.GetString("password_command")
+	file := viper.GetString("password_file")
+	email := viper.GetString("email")
+	configMu.RUnlock()
+
+	if command != "" {
+		return config.ReadPasswordCommand(ctx, command)
+	}
+	if file != "" {
+		if err := config.WarnInsecurePasswordFile(file); err != nil {
+			logger.Warn(err.Error())
+		}
+		return config.ReadPasswordFile(file)
+	}
+	if email != "" {
+		if pw, err := tokencache.LoadPassword(email); err == nil {
+			return pw, nil
+		}
+	}
+	return "", nil
+}
+
+// havePassword reports whether a sign-in would have a password, without
+// running password_command.
+func havePassword() bool {
+	if viper.GetString("password") != "" || viper.GetString("password_command") != "" || viper.GetString("password_file") != "" {
+		return true
+	}
+	email := viper.GetString("email")
+	if email == "" {
+		return false
+	}
+	_, err := tokencache.LoadPassword(email)
+	return err == nil
+}
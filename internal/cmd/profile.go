@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/config"
+	"github.com/steipete/eightctl/internal/output"
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named account profiles",
+	Long: `Profiles keep several Eight Sleep accounts in one config file:
+
+  profile: home
+  profiles:
+    home:
+      email: me@example.com
+      password: secret
+    partner:
+      email: partner@example.com
+      timezone: Europe/Berlin
+      device: guest
+
+Each profile can set email, password, user_id, client_id, client_secret,
+timezone, output, fields, device, and devices; unset keys fall back to the
+top level. Select one with --profile, EIGHTCTL_PROFILE, or 'eightctl
+profile use'. Every profile caches its own token.`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		active := viper.GetString("profile")
+		rows := make([]map[string]any, 0, len(loadedConfig.Profiles))
+		for _, name := range profileNames() {
+			p := loadedConfig.Profiles[name]
+			rows = append(rows, map[string]any{
+				"name":     name,
+				"email":    p.Email,
+				"device":   p.Device,
+				"timezone": p.Timezone,
+				"active":   name == active,
+			})
+		}
+
+		fields := viper.GetStringSlice("fields")
+		rows = output.FilterFields(rows, fields)
+		headers := fields
+		if len(headers) == 0 {
+			headers = []string{"name", "email", "device", "timezone", "active"}
+		}
+		return output.Print(output.Format(viper.GetString("output")), headers, rows)
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default profile in the config file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ToLower(args[0])
+		if _, ok := loadedConfig.Profiles[name]; !ok {
+			return fmt.Errorf("unknown profile %q (have: %s)", args[0], strings.Join(profileNames(), ", "))
+		}
+		path, err := configFile()
+		if err != nil {
+			return err
+		}
+		if err := config.SetKey(path, "profile", name); err != nil {
+			return fmt.Errorf("update config: %w", err)
+		}
+		fmt.Printf("Default profile set to %s in %s\n", name, path)
+		return nil
+	},
+}
+
+var profileShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show the effective settings of a profile (default: active)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := viper.GetString("profile")
+		if len(args) == 1 {
+			name = args[0]
+		}
+		if name == "" {
+			return fmt.Errorf("no active profile; pass a name or set one with 'eightctl profile use'")
+		}
+		cfg, err := loadedConfig.ApplyProfile(name)
+		if err != nil {
+			return err
+		}
+
+		password := ""
+		switch {
+		case cfg.Password != "":
+			password = "(set)"
+		case cfg.PasswordCommand != "":
+			password = "(password_command)"
+		case cfg.PasswordFile != "":
+			password = "(password_file)"
+		}
+		// Same namespace newClient would use for this profile.
+		c := configureClient(client.New(cfg.Email, "", cfg.UserID, cfg.ClientID, cfg.ClientSecret))
+		c.Profile = cfg.Profile
+		token := "none"
+		if cached, err := tokencache.Load(c.Identity(), cfg.UserID); err == nil {
+			token = "valid until " + cached.ExpiresAt.Format("2006-01-02 15:04")
+		}
+
+		row := map[string]any{
+			"name":     cfg.Profile,
+			"email":    cfg.Email,
+			"password": password,
+			"user_id":  cfg.UserID,
+			"timezone": cfg.Timezone,
+			"output":   cfg.Output,
+			"device":   cfg.Device,
+			"token":    token,
+		}
+		fields := viper.GetStringSlice("fields")
+		rows := output.FilterFields([]map[string]any{row}, fields)
+		headers := fields
+		if len(headers) == 0 {
+			headers = []string{"name", "email", "password", "user_id", "timezone", "output", "device", "token"}
+		}
+		return output.Print(output.Format(viper.GetString("output")), headers, rows)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd, profileUseCmd, profileShowCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+// profileNames returns the configured profile names in sorted order.
+func profileNames() []string {
+	names := make([]string, 0, len(loadedConfig.Profiles))
+	for name := range loadedConfig.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/config"
+)
+
+// configMu keeps a config reload from rewriting viper while another
+// goroutine reads it, e.g. lookupPassword during a re-authentication.
+// viper is not safe for concurrent use. Readers that can run while a
+// long-running command serves (newClient, lookupPassword, the services'
+// reload hooks) hold the read lock.
+var configMu sync.RWMutex
+
+// watchConfig reloads the config file when it changes or on SIGHUP while a
+// long-running command serves, then calls apply so the command can pick up
+// the new settings. A file that fails validation is logged and the running
+// config kept. Flags and environment variables still beat the file.
+func watchConfig(ctx context.Context, apply func() error) {
+	path := loadedConfig.File
+	if path == "" {
+		return
+	}
+	err := config.Watch(ctx, path, func() {
+		if err := reloadConfig(path); err != nil {
+			logger.Error("config reload rejected; keeping the running config", "file", path, "reason", err)
+			return
+		}
+		configMu.RLock()
+		err := apply()
+		configMu.RUnlock()
+		if err != nil {
+			logger.Error("config reload not applied", "file", path, "reason", err)
+			return
+		}
+		logger.Info("config reloaded", "file", path)
+	})
+	if err != nil {
+		logger.Warn("config file changes are not watched; send SIGHUP to reload", "err", err)
+	}
+}
+
+// reloadConfig re-reads and validates the config file and merges it into
+// viper the way initConfig does, keeping the profile the command started
+// with. Nothing changes when the file is invalid.
+func reloadConfig(path string) error {
+	problems, err := validateConfigFile(path)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		msgs := make([]string, len(problems))
+		for i, p := range problems {
+			msgs[i] = p.Error()
+		}
+		return errors.New(strings.Join(msgs, "; "))
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	cfg, err := config.Load(path, true)
+	if err != nil {
+		return err
+	}
+	if cfg.File == "" {
+		return errors.New("config file could not be read")
+	}
+	applied, err := cfg.ApplyProfile(viper.GetString("profile"))
+	if err != nil {
+		return err
+	}
+	loadedConfig = cfg
+	applyConfigDefaults(applied)
+	return nil
+}
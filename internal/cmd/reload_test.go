@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/config"
+)
+
+func TestReloadConfigRejectsInvalidFile(t *testing.T) {
+	resetViper(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(s string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(s), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Cleanup(func() { loadedConfig = config.Config{} })
+
+	write("email: b@example.com\ndevices:\n  - id: dev-1\n    name: Bedroom\n")
+	if err := reloadConfig(path); err != nil {
+		t.Fatalf("reloadConfig: %v", err)
+	}
+	if got := viper.GetString("email"); got != "b@example.com" {
+		t.Fatalf("email = %q after reload", got)
+	}
+	if got := deviceName(configuredDevices(), "dev-1"); got != "Bedroom" {
+		t.Fatalf("device name = %q after reload", got)
+	}
+
+	write("email: c@example.com\ntimezon: UTC\n")
+	if err := reloadConfig(path); err == nil {
+		t.Fatal("expected an unknown key to reject the reload")
+	}
+	write("email: c@example.com\nschedule:\n  - time: \"25:00\"\n    action: on\n")
+	if err := reloadConfig(path); err == nil {
+		t.Fatal("expected a bad schedule item to reject the reload")
+	}
+	if got := viper.GetString("email"); got != "b@example.com" {
+		t.Fatalf("rejected reload changed email to %q", got)
+	}
+}
+
+func TestReloadConfigRestoresFlagDefaults(t *testing.T) {
+	resetViper(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Cleanup(func() { loadedConfig = config.Config{} })
+	cmd := &cobra.Command{Use: "mqtt"}
+	cmd.Flags().Duration("poll-interval", 30*time.Second, "")
+	viper.BindPFlag("mqtt.poll-interval", cmd.Flags().Lookup("poll-interval"))
+
+	if err := os.WriteFile(path, []byte("mqtt:\n  poll-interval: 5s\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloadConfig(path); err != nil {
+		t.Fatalf("reloadConfig: %v", err)
+	}
+	if got := viper.GetDuration("mqtt.poll-interval"); got != 5*time.Second {
+		t.Fatalf("poll-interval = %v from the file", got)
+	}
+
+	if err := os.WriteFile(path, []byte("email: a@example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloadConfig(path); err != nil {
+		t.Fatalf("reloadConfig: %v", err)
+	}
+	if got := viper.GetDuration("mqtt.poll-interval"); got != 30*time.Second {
+		t.Fatalf("poll-interval = %v after removing it from the file, want the flag default", got)
+	}
+}
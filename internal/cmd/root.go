@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/charmbracelet/log"
@@ -20,19 +24,36 @@ var (
 		Short: "Control your Eight Sleep Pod from the terminal",
 	}
 	logger = log.New(os.Stderr)
+
+	// loadedConfig is the config file as read, before any profile is
+	// applied; the profile commands inspect it.
+	loadedConfig config.Config
+
+	// configErr is a config problem found by initConfig. It fails every
+	// command except 'config', which is how it gets fixed.
+	configErr error
 )
 
-// Execute is the entry point for main.
+// Execute is the entry point for main. API failures exit with the codes
+// defined in exitcode.go so scripts can tell them apart.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		log.Fatal(err)
+		log.Error(err)
+		os.Exit(exitCode(err))
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if configErr != nil && !isConfigCmd(cmd) {
+			return fmt.Errorf("config: %w (see 'eightctl config validate')", configErr)
+		}
+		return nil
+	}
 
 	rootCmd.PersistentFlags().String("config", "", "config file (default ~/.config/eightctl/config.yaml)")
+	rootCmd.PersistentFlags().String("profile", "", "config profile to use (overrides the profile config key)")
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose logging")
 	rootCmd.PersistentFlags().String("email", "", "Eight Sleep account email")
 	rootCmd.PersistentFlags().String("password", "", "Eight Sleep account password")
@@ -43,8 +64,18 @@ func init() {
 	rootCmd.PersistentFlags().String("output", "table", "output format: table|json|csv")
 	rootCmd.PersistentFlags().StringSlice("fields", []string{}, "output fields filter")
 	rootCmd.PersistentFlags().Bool("quiet", false, "suppress config load message")
+	rootCmd.PersistentFlags().Int("retry-attempts", client.DefaultRetryPolicy.MaxAttempts, "max attempts for rate-limited (429) requests; 1 disables retries")
+	rootCmd.PersistentFlags().Float64("max-rps", config.DefaultMaxRPS, "max API requests per second shared by all local eightctl processes; 0 disables")
+	rootCmd.PersistentFlags().Duration("retry-base-delay", client.DefaultRetryPolicy.BaseDelay, "first retry wait for rate-limited (429) requests; doubles on each attempt")
+	rootCmd.PersistentFlags().Duration("retry-max-delay", client.DefaultRetryPolicy.MaxDelay, "upper bound for a single retry wait, including Retry-After")
+	rootCmd.PersistentFlags().String("device", "", "pod to control, by ID or a name from the devices config section (default: account's current device)")
+	rootCmd.PersistentFlags().String("record", "", "write every API exchange to redacted fixture files in this directory")
+	rootCmd.PersistentFlags().String("replay", "", "serve API responses from fixtures in this directory instead of the network")
+	rootCmd.PersistentFlags().String("trace", "", "write every HTTP exchange to this file as a redacted HAR 1.2 trace")
+	rootCmd.MarkFlagsMutuallyExclusive("record", "replay")
 
 	viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config"))
+	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	viper.BindPFlag("email", rootCmd.PersistentFlags().Lookup("email"))
 	viper.BindPFlag("password", rootCmd.PersistentFlags().Lookup("password"))
@@ -55,6 +86,14 @@ func init() {
 	viper.BindPFlag("output", rootCmd.PersistentFlags().Lookup("output"))
 	viper.BindPFlag("fields", rootCmd.PersistentFlags().Lookup("fields"))
 	viper.BindPFlag("config-quiet", rootCmd.PersistentFlags().Lookup("quiet"))
+	viper.BindPFlag("max_rps", rootCmd.PersistentFlags().Lookup("max-rps"))
+	viper.BindPFlag("retry.max_attempts", rootCmd.PersistentFlags().Lookup("retry-attempts"))
+	viper.BindPFlag("retry.base_delay", rootCmd.PersistentFlags().Lookup("retry-base-delay"))
+	viper.BindPFlag("retry.max_delay", rootCmd.PersistentFlags().Lookup("retry-max-delay"))
+	viper.BindPFlag("device", rootCmd.PersistentFlags().Lookup("device"))
+	viper.BindPFlag("record", rootCmd.PersistentFlags().Lookup("record"))
+	viper.BindPFlag("replay", rootCmd.PersistentFlags().Lookup("replay"))
+	viper.BindPFlag("trace", rootCmd.PersistentFlags().Lookup("trace"))
 
 	rootCmd.AddCommand(onCmd)
 	rootCmd.AddCommand(offCmd)
@@ -80,58 +119,209 @@ func init() {
 }
 
 func initConfig() {
+	configErr = nil
 	cfg, err := config.Load(viper.GetString("config"), viper.GetBool("config-quiet"))
 	if err != nil {
-		log.Fatalf("config: %v", err)
+		configErr = err
 	}
 
+	loadedConfig = cfg
+
 	// ensure env works on the main viper, too
 	viper.SetEnvPrefix("EIGHTCTL")
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
 	viper.AutomaticEnv()
-	// merge into viper defaults
+
+	// --profile / EIGHTCTL_PROFILE beat the profile key in the file.
+	viper.SetDefault("profile", cfg.Profile)
+	if applied, err := cfg.ApplyProfile(viper.GetString("profile")); err != nil {
+		configErr = errors.Join(configErr, err)
+	} else {
+		cfg = applied
+	}
+	viper.Set("profile", cfg.Profile)
+	applyConfigDefaults(cfg)
+
+	if err := tokencache.Configure(keyringOptions()); err != nil {
+		configErr = errors.Join(configErr, err)
+	}
+
+	if err := config.WarnInsecurePerms(cfg.File); err != nil {
+		logger.Warn(err.Error())
+	}
+
+	if viper.GetBool("verbose") {
+		log.SetLevel(log.DebugLevel)
+	}
+}
+
+// applyConfigDefaults merges the config file, with its profile applied, into
+// viper as defaults, so flags and the environment still win.
+func applyConfigDefaults(cfg config.Config) {
 	viper.SetDefault("email", cfg.Email)
 	viper.SetDefault("password", cfg.Password)
+	viper.SetDefault("password_command", cfg.PasswordCommand)
+	viper.SetDefault("password_file", cfg.PasswordFile)
 	viper.SetDefault("user_id", cfg.UserID)
 	viper.SetDefault("client_id", cfg.ClientID)
 	viper.SetDefault("client_secret", cfg.ClientSecret)
-	viper.SetDefault("client_id", cfg.ClientID)
-	viper.SetDefault("client_secret", cfg.ClientSecret)
 	viper.SetDefault("timezone", cfg.Timezone)
 	viper.SetDefault("output", cfg.Output)
 	viper.SetDefault("fields", cfg.Fields)
 	viper.SetDefault("verbose", cfg.Verbose)
+	viper.SetDefault("max_rps", cfg.MaxRPS)
+	viper.SetDefault("retry.max_attempts", cfg.Retry.MaxAttempts)
+	viper.SetDefault("retry.base_delay", cfg.Retry.BaseDelay)
+	viper.SetDefault("retry.max_delay", cfg.Retry.MaxDelay)
+	viper.SetDefault("base_url", cfg.BaseURL)
+	viper.SetDefault("app_api_base_url", cfg.AppAPIBaseURL)
+	viper.SetDefault("auth_url", cfg.AuthURL)
+	viper.SetDefault("device", cfg.Device)
+	viper.SetDefault("devices", cfg.Devices)
+	viper.SetDefault("keyring_backend", cfg.KeyringBackend)
+	viper.SetDefault("latitude", cfg.Latitude)
+	viper.SetDefault("longitude", cfg.Longitude)
+	setFlagDefault("mqtt.broker", cfg.MQTT.Broker)
+	setFlagDefault("mqtt.topic-prefix", cfg.MQTT.TopicPrefix)
+	setFlagDefault("mqtt.device-name", cfg.MQTT.DeviceName)
+	setFlagDefault("mqtt.client-id", cfg.MQTT.ClientID)
+	setFlagDefault("mqtt.mqtt-username", cfg.MQTT.Username)
+	setFlagDefault("mqtt.mqtt-password", cfg.MQTT.Password)
+	setFlagDefault("mqtt.poll-interval", cfg.MQTT.PollInterval)
+	setFlagDefault("hubitat.port", cfg.Hubitat.Port)
+	setFlagDefault("hubitat.poll-interval", cfg.Hubitat.PollInterval)
+	setFlagDefault("exporter.listen", cfg.Exporter.Listen)
+	setFlagDefault("exporter.cache-ttl", cfg.Exporter.CacheTTL)
+	setFlagDefault("serve.services", cfg.Serve.Services)
+}
 
-	if err := config.WarnInsecurePerms(viper.ConfigFileUsed()); err != nil {
-		logger.Warn(err.Error())
-	}
-
-	if viper.GetBool("verbose") {
-		log.SetLevel(log.DebugLevel)
+// setFlagDefault is viper.SetDefault for keys that also have a flag
+// default, which any viper default would hide, even a zero one. A zero v
+// clears the default, so a key dropped from the file on reload falls back
+// to the flag default again.
+func setFlagDefault(key string, v any) {
+	if reflect.ValueOf(v).IsZero() {
+		v = nil
 	}
+	viper.SetDefault(key, v)
 }
 
-func requireAuthFields() error {
-	// Allow cached token to satisfy auth without requiring credentials.
-	c := client.New(
+// newClient builds a Client from the merged flag/env/config settings.
+func newClient() *client.Client {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return configureClient(client.New(
 		viper.GetString("email"),
 		viper.GetString("password"),
 		viper.GetString("user_id"),
 		viper.GetString("client_id"),
 		viper.GetString("client_secret"),
-	)
+		clientOptions()...,
+	))
+}
+
+// startTokenRefresher renews cl's token ahead of expiry until ctx ends.
+// Long-running commands (daemon, mqtt, hubitat) call it after creating their client.
+func startTokenRefresher(ctx context.Context, cl *client.Client) {
+	r := &client.Refresher{Client: cl}
+	go r.Run(ctx)
+}
+
+// clientOptions returns the password lookup plus the fixture record/replay
+// and trace options selected by flags.
+func clientOptions() []client.Option {
+	opts := []client.Option{client.WithPasswordFunc(lookupPassword)}
+	if path := viper.GetString("trace"); path != "" {
+		opts = append(opts, client.WithTrace(path))
+	}
+	if dir := viper.GetString("record"); dir != "" {
+		opts = append(opts, client.WithRecord(dir))
+	}
+	if dir := viper.GetString("replay"); dir != "" {
+		opts = append(opts, client.WithReplay(dir))
+	}
+	return opts
+}
+
+// configureClient applies transport settings shared by every command.
+func configureClient(c *client.Client) *client.Client {
+	if u := viper.GetString("base_url"); u != "" {
+		c.BaseURL = u
+	}
+	if u := viper.GetString("app_api_base_url"); u != "" {
+		c.AppAPIBaseURL = u
+	}
+	if u := viper.GetString("auth_url"); u != "" {
+		c.AuthURL = u
+	}
+	if id := selectedDevice(); id != "" {
+		c.DeviceID = id
+	}
+	c.Profile = viper.GetString("profile")
+	c.Retry = retryPolicy()
+	// Replayed fixtures never reach the API, so they need no throttling.
+	if viper.GetString("replay") == "" {
+		c.Limiter = rateLimiter()
+	}
+	return c
+}
+
+// rateLimitBurst is how many requests may go out back to back before max_rps applies.
+const rateLimitBurst = 5
+
+// rateLimiter returns the machine-wide limiter, or nil when disabled.
+func rateLimiter() client.Limiter {
+	rps := viper.GetFloat64("max_rps")
+	if rps <= 0 {
+		return nil
+	}
+	dir, err := config.Dir()
+	if err != nil {
+		return nil
+	}
+	return client.NewFileLimiter(filepath.Join(dir, "ratelimit.json"), rps, rateLimitBurst)
+}
+
+// retryPolicy overlays configured retry settings on the client defaults.
+// Zero values keep the default.
+func retryPolicy() client.RetryPolicy {
+	p := client.DefaultRetryPolicy
+	if n := viper.GetInt("retry.max_attempts"); n > 0 {
+		p.MaxAttempts = n
+	}
+	if d := viper.GetDuration("retry.base_delay"); d > 0 {
+		p.BaseDelay = d
+	}
+	if d := viper.GetDuration("retry.max_delay"); d > 0 {
+		p.MaxDelay = d
+	}
+	return p
+}
+
+func requireAuthFields() error {
+	// Replayed fixtures need no credentials.
+	if viper.GetString("replay") != "" {
+		return nil
+	}
+	// Allow cached token to satisfy auth without requiring credentials.
+	c := newClient()
 	if cached, err := tokencache.Load(c.Identity(), viper.GetString("user_id")); err == nil {
 		if cached.UserID != "" {
 			viper.Set("user_id", cached.UserID)
 		}
 		return nil
 	}
+	return requirePassword()
+}
 
+// requirePassword is requireAuthFields without the cached-token shortcut,
+// for commands that always sign in.
+func requirePassword() error {
 	missing := []string{}
 	if viper.GetString("email") == "" {
 		missing = append(missing, "email")
 	}
-	if viper.GetString("password") == "" {
+	if !havePassword() {
 		missing = append(missing, "password")
 	}
 	if len(missing) > 0 {
@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -59,3 +63,87 @@ func TestRequireAuthFieldsFailsWithoutCacheOrCreds(t *testing.T) {
 		t.Fatalf("expected missing credentials error")
 	}
 }
+
+func TestSelectedDeviceResolvesConfiguredName(t *testing.T) {
+	resetViper(t)
+	viper.Set("devices", []map[string]any{
+		{"id": "dev-1", "name": "Bedroom"},
+		{"id": "dev-2", "name": "Guest"},
+	})
+
+	viper.Set("device", "guest")
+	if got := selectedDevice(); got != "dev-2" {
+		t.Fatalf("expected dev-2, got %q", got)
+	}
+	viper.Set("device", "dev-9")
+	if got := selectedDevice(); got != "dev-9" {
+		t.Fatalf("expected raw ID to pass through, got %q", got)
+	}
+	if c := newClient(); c.DeviceID != "dev-9" {
+		t.Fatalf("client not pointed at selected device, got %q", c.DeviceID)
+	}
+}
+
+func TestProfileNamespacesTokenCache(t *testing.T) {
+	useTempKeyring(t)
+	resetViper(t)
+	viper.Set("email", "me@example.com")
+
+	plain := newClient().Identity()
+	viper.Set("profile", "partner")
+	profiled := newClient().Identity()
+	if profiled.Profile != "partner" || profiled == plain {
+		t.Fatalf("profile not part of identity: %+v vs %+v", profiled, plain)
+	}
+}
+
+func TestRequireAuthFieldsAcceptsPasswordSources(t *testing.T) {
+	useTempKeyring(t)
+	resetViper(t)
+	viper.Set("email", "me@example.com")
+	if err := requireAuthFields(); err == nil {
+		t.Fatal("expected missing password error")
+	}
+
+	if err := tokencache.SavePassword("me@example.com", "saved"); err != nil {
+		t.Fatalf("SavePassword: %v", err)
+	}
+	if err := requireAuthFields(); err != nil {
+		t.Fatalf("saved password should satisfy auth: %v", err)
+	}
+	if pw, err := lookupPassword(context.Background()); err != nil || pw != "saved" {
+		t.Fatalf("lookupPassword = %q, %v", pw, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "pw")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	viper.Set("password_file", path)
+	if pw, err := lookupPassword(context.Background()); err != nil || pw != "from-file" {
+		t.Fatalf("password_file should win over the keyring, got %q, %v", pw, err)
+	}
+}
+
+func TestLookupPasswordWarnsOnReadableHomeFile(t *testing.T) {
+	resetViper(t)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := os.WriteFile(filepath.Join(home, "pw"), []byte("from-home\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(home, "pw"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var logs bytes.Buffer
+	logger.SetOutput(&logs)
+	t.Cleanup(func() { logger.SetOutput(os.Stderr) })
+
+	viper.Set("password_file", "~/pw")
+	if pw, err := lookupPassword(context.Background()); err != nil || pw != "from-home" {
+		t.Fatalf("lookupPassword = %q, %v", pw, err)
+	}
+	if !strings.Contains(logs.String(), "password file") || !strings.Contains(logs.String(), "644") {
+		t.Fatalf("expected a password file permissions warning, got %q", logs.String())
+	}
+}
@@ -37,7 +37,7 @@ var scheduleNextCmd = &cobra.Command{
 		}
 		now := time.Now().In(loc)
 
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		scheds, err := cl.ListSchedules(context.Background())
 		if err != nil {
 			return err
@@ -73,7 +73,7 @@ var scheduleListCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		scheds, err := cl.ListSchedules(context.Background())
 		if err != nil {
 			return err
@@ -110,7 +110,7 @@ var scheduleCreateCmd = &cobra.Command{
 			return fmt.Errorf("--days required")
 		}
 		enabled := !viper.GetBool("disabled")
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		s := client.TemperatureSchedule{StartTime: start, Level: level, DaysOfWeek: days, Enabled: enabled}
 		res, err := cl.CreateSchedule(context.Background(), s)
 		if err != nil {
@@ -145,7 +145,7 @@ var scheduleUpdateCmd = &cobra.Command{
 		if len(patch) == 0 {
 			return fmt.Errorf("no fields to update")
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if _, err := cl.UpdateSchedule(context.Background(), args[0], patch); err != nil {
 			return err
 		}
@@ -162,7 +162,7 @@ var scheduleDeleteCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if err := cl.DeleteSchedule(context.Background(), args[0]); err != nil {
 			return err
 		}
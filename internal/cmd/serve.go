@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/config"
+	"github.com/steipete/eightctl/internal/exporter"
+	"github.com/steipete/eightctl/internal/state"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the daemon, bridges, and exporter in one process",
+	Long: `Runs any combination of the long-running services in one process:
+
+  daemon    the schedule from the config file
+  mqtt      the Home Assistant MQTT bridge
+  hubitat   the Hubitat HTTP server
+  exporter  the Prometheus exporter
+
+Pick them with --services or the serve.services config key. They share one
+sign-in and one cached state per pod, so the API is polled once however
+many services read it, at the shortest of their poll intervals. Each
+service takes its settings from its config section (mqtt, hubitat,
+exporter) or the defaults of its standalone command.
+
+SIGINT or SIGTERM stops every service; if one fails, the others are
+stopped too. The config file is reloaded on change or SIGHUP.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := viper.GetStringSlice("serve.services")
+		if len(names) == 0 {
+			return fmt.Errorf("no services to run; pass --services or set serve.services (%s)", strings.Join(config.ServiceNames, ", "))
+		}
+		for i, name := range names {
+			if _, ok := serviceDefs[name]; !ok {
+				return fmt.Errorf("unknown service %q (want %s)", name, strings.Join(config.ServiceNames, ", "))
+			}
+			if slices.Contains(names[:i], name) {
+				return fmt.Errorf("service %q is listed twice", name)
+			}
+		}
+		if err := requireAuthFields(); err != nil {
+			return err
+		}
+		return runServices(names...)
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringSlice("services", nil, "services to run: "+strings.Join(config.ServiceNames, ","))
+	viper.BindPFlag("serve.services", serveCmd.Flags().Lookup("services"))
+	rootCmd.AddCommand(serveCmd)
+}
+
+// service is a running part of a long-running command.
+type service struct {
+	reload func() error // applies a reloaded config; may be nil
+	stop   func() error
+	failed <-chan error // receives if the service dies on its own; may be nil
+}
+
+// serviceEnv is what the services of one process share.
+type serviceEnv struct {
+	client   *client.Client
+	managers []*state.Manager     // one per pod; nil when no service reads pod state
+	metrics  *exporter.APIMetrics // set when the exporter runs
+	names    []string
+}
+
+// serviceDef describes a service runServices can start.
+type serviceDef struct {
+	start    func(ctx context.Context, env *serviceEnv) (*service, error)
+	cacheTTL string // viper key of how stale pod state may be; "" if it reads none
+	vitals   bool   // pod state must include sleep vitals
+}
+
+var serviceDefs = map[string]serviceDef{
+	"daemon":   {start: startDaemon},
+	"mqtt":     {start: startMQTT, cacheTTL: "mqtt.poll-interval"},
+	"hubitat":  {start: startHubitat, cacheTTL: "hubitat.poll-interval"},
+	"exporter": {start: startExporter, cacheTTL: "exporter.cache-ttl", vitals: true},
+}
+
+// cacheTTL is the shortest poll interval of the services that read pod
+// state, and whether any does.
+func (env *serviceEnv) cacheTTL() (time.Duration, bool) {
+	var ttl time.Duration
+	found := false
+	for _, name := range env.names {
+		key := serviceDefs[name].cacheTTL
+		if key == "" {
+			continue
+		}
+		if d := viper.GetDuration(key); !found || d < ttl {
+			ttl = d
+		}
+		found = true
+	}
+	return ttl, found
+}
+
+// dryRun reports whether the process only previews the schedule and so
+// never needs a token.
+func (env *serviceEnv) dryRun() bool {
+	return len(env.names) == 1 && env.names[0] == "daemon" && viper.GetBool("dry-run")
+}
+
+// invalidate drops every pod's cached state.
+func (env *serviceEnv) invalidate() {
+	for _, m := range env.managers {
+		m.InvalidateCache()
+	}
+}
+
+// runServices starts the named services on one Client and one set of pod
+// managers, then waits for a signal or for a service to fail and stops
+// them all, last started first.
+func runServices(names ...string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := &serviceEnv{client: newClient(), names: names}
+	if slices.Contains(names, "exporter") {
+		env.metrics = exporter.NewAPIMetrics()
+		env.client.Use(env.metrics.Middleware())
+	}
+	if ttl, ok := env.cacheTTL(); ok {
+		opts := []state.Option{state.WithCacheTTL(ttl)}
+		for _, name := range names {
+			if serviceDefs[name].vitals {
+				opts = append(opts, state.WithVitals())
+				break
+			}
+		}
+		managers, err := podManagers(ctx, env.client, opts...)
+		if err != nil {
+			return err
+		}
+		env.managers = managers
+	}
+
+	if !env.dryRun() {
+		startTokenRefresher(ctx, env.client)
+	}
+
+	var running []*service
+	stopAll := func() error {
+		var errs []error
+		for i := len(running) - 1; i >= 0; i-- {
+			errs = append(errs, running[i].stop())
+		}
+		return errors.Join(errs...)
+	}
+	failed := make(chan error, len(names))
+	for _, name := range names {
+		s, err := serviceDefs[name].start(ctx, env)
+		if err != nil {
+			return errors.Join(err, stopAll())
+		}
+		running = append(running, s)
+		if s.failed != nil {
+			go func() {
+				if err, ok := <-s.failed; ok {
+					failed <- fmt.Errorf("%s: %w", name, err)
+				}
+			}()
+		}
+	}
+
+	watchConfig(ctx, func() error {
+		if ttl, ok := env.cacheTTL(); ok {
+			updatePodManagers(env.managers, ttl)
+		}
+		var errs []error
+		for _, s := range running {
+			if s.reload != nil {
+				errs = append(errs, s.reload())
+			}
+		}
+		return errors.Join(errs...)
+	})
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	var runErr error
+	select {
+	case <-sigChan:
+		fmt.Println("\nShutting down...")
+	case runErr = <-failed:
+	}
+	return errors.Join(runErr, stopAll())
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/config"
+)
+
+func TestServiceDefsMatchConfig(t *testing.T) {
+	for _, name := range config.ServiceNames {
+		if _, ok := serviceDefs[name]; !ok {
+			t.Errorf("config.ServiceNames lists %q but serve cannot start it", name)
+		}
+	}
+	for name := range serviceDefs {
+		if !slices.Contains(config.ServiceNames, name) {
+			t.Errorf("service %q missing from config.ServiceNames", name)
+		}
+	}
+}
+
+func TestServiceEnvCacheTTLIsShortestInterval(t *testing.T) {
+	resetViper(t)
+	viper.Set("mqtt.poll-interval", 30*time.Second)
+	viper.Set("hubitat.poll-interval", 10*time.Second)
+	viper.Set("exporter.cache-ttl", time.Minute)
+
+	if _, ok := (&serviceEnv{names: []string{"daemon"}}).cacheTTL(); ok {
+		t.Error("daemon alone sets no cache TTL")
+	}
+	viper.Set("dry-run", true)
+	if !(&serviceEnv{names: []string{"daemon"}}).dryRun() {
+		t.Error("a dry-run daemon alone needs no token")
+	}
+	if (&serviceEnv{names: []string{"daemon", "mqtt"}}).dryRun() {
+		t.Error("mqtt beside a dry-run daemon still needs a token")
+	}
+	env := &serviceEnv{names: []string{"daemon", "mqtt", "exporter"}}
+	if ttl, ok := env.cacheTTL(); !ok || ttl != 30*time.Second {
+		t.Errorf("cacheTTL = %v, %v; want 30s", ttl, ok)
+	}
+	env.names = append(env.names, "hubitat")
+	if ttl, _ := env.cacheTTL(); ttl != 10*time.Second {
+		t.Errorf("cacheTTL = %v; want 10s", ttl)
+	}
+}
+
+func TestDaemonDryRunMakesNoRequests(t *testing.T) {
+	resetViper(t)
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unexpected request", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	schedule := "schedule:\n  - time: \"22:00\"\n    side: left\n    action: on\n"
+	if err := os.WriteFile(path, []byte(schedule), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	loadedConfig = config.Config{File: path}
+	t.Cleanup(func() { loadedConfig = config.Config{} })
+	viper.Set("dry-run", true)
+	viper.Set("pid-file", filepath.Join(dir, "daemon.pid"))
+	viper.Set("state-file", filepath.Join(dir, "state.json"))
+
+	cl := client.New("me@example.com", "pw", "", "", "")
+	cl.BaseURL = srv.URL
+	cl.AuthURL = srv.URL
+	s, err := startDaemon(context.Background(), &serviceEnv{client: cl, names: []string{"daemon"}})
+	if err != nil {
+		t.Fatalf("startDaemon: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := s.stop(); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Fatalf("dry run made %d API requests", n)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/output"
 )
 
@@ -32,7 +31,7 @@ var sleepDayCmd = &cobra.Command{
 		if tz == "local" {
 			tz = time.Local.String()
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		day, err := cl.GetSleepDay(context.Background(), date, tz)
 		if err != nil {
 			return err
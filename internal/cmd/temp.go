@@ -10,7 +10,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/daemon"
 	"github.com/steipete/eightctl/internal/model"
 )
@@ -27,7 +26,7 @@ var tempCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 
 		ctx := context.Background()
 		sideStr := viper.GetString("temp_side")
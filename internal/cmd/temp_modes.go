@@ -6,7 +6,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/output"
 )
 
@@ -22,7 +21,7 @@ var (
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		return cl.TempModes().NapActivate(context.Background())
 	}}
 )
@@ -31,7 +30,7 @@ var tempNapOffCmd = &cobra.Command{Use: "off", RunE: func(cmd *cobra.Command, ar
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.TempModes().NapDeactivate(context.Background())
 }}
 
@@ -39,7 +38,7 @@ var tempNapExtendCmd = &cobra.Command{Use: "extend", RunE: func(cmd *cobra.Comma
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.TempModes().NapExtend(context.Background())
 }}
 
@@ -47,7 +46,7 @@ var tempNapStatusCmd = &cobra.Command{Use: "status", RunE: func(cmd *cobra.Comma
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	var out map[string]any
 	if err := cl.TempModes().NapStatus(context.Background(), &out); err != nil {
 		return err
@@ -66,7 +65,7 @@ var (
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		return cl.TempModes().HotFlashActivate(context.Background())
 	}}
 )
@@ -75,7 +74,7 @@ var tempHotOffCmd = &cobra.Command{Use: "off", RunE: func(cmd *cobra.Command, ar
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.TempModes().HotFlashDeactivate(context.Background())
 }}
 
@@ -83,7 +82,7 @@ var tempHotStatusCmd = &cobra.Command{Use: "status", RunE: func(cmd *cobra.Comma
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	var out map[string]any
 	if err := cl.TempModes().HotFlashStatus(context.Background(), &out); err != nil {
 		return err
@@ -100,7 +99,7 @@ var tempEventsCmd = &cobra.Command{Use: "events", RunE: func(cmd *cobra.Command,
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	from := viper.GetString("from")
 	to := viper.GetString("to")
 	var out any
@@ -6,9 +6,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-
-	"github.com/steipete/eightctl/internal/client"
-	"github.com/steipete/eightctl/internal/output"
 )
 
 var travelCmd = &cobra.Command{Use: "travel", Short: "Travel / jetlag endpoints", Hidden: true} // Verified broken 2026-01-29: Cannot GET
@@ -17,12 +14,16 @@ var travelTripsCmd = &cobra.Command{Use: "trips", RunE: func(cmd *cobra.Command,
 	if err := requireAuthFields(); err != nil {
 		return err
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Travel().Trips(context.Background())
 	if err != nil {
 		return err
 	}
-	return output.Print(output.Format(viper.GetString("output")), []string{"trips"}, []map[string]any{{"trips": res}})
+	rows := make([]map[string]any, 0, len(res.Trips))
+	for _, t := range res.Trips {
+		rows = append(rows, map[string]any{"id": t.ID, "destination": t.Destination, "start": t.StartDate, "end": t.EndDate, "timezone": t.Timezone})
+	}
+	return printModel("trips", modelView{res.Raw, []string{"id", "destination", "start", "end", "timezone"}, rows})
 }}
 
 var travelCreateTripCmd = &cobra.Command{Use: "create-trip", RunE: func(cmd *cobra.Command, args []string) error {
@@ -45,7 +46,7 @@ var travelCreateTripCmd = &cobra.Command{Use: "create-trip", RunE: func(cmd *cob
 	if len(body) == 0 {
 		return fmt.Errorf("provide at least --destination or --start-date/--end-date")
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Travel().CreateTrip(context.Background(), body)
 }}
 
@@ -57,7 +58,7 @@ var travelDeleteTripCmd = &cobra.Command{Use: "delete-trip", RunE: func(cmd *cob
 	if id == "" {
 		return fmt.Errorf("--trip required")
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Travel().DeleteTrip(context.Background(), id)
 }}
 
@@ -66,12 +67,16 @@ var travelPlansCmd = &cobra.Command{Use: "plans", RunE: func(cmd *cobra.Command,
 		return err
 	}
 	trip := viper.GetString("trip")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Travel().Plans(context.Background(), trip)
 	if err != nil {
 		return err
 	}
-	return output.Print(output.Format(viper.GetString("output")), []string{"plans"}, []map[string]any{{"plans": res}})
+	rows := make([]map[string]any, 0, len(res.Plans))
+	for _, p := range res.Plans {
+		rows = append(rows, map[string]any{"id": p.ID, "trip": p.TripID, "name": p.Name, "date": p.Date})
+	}
+	return printModel("plans", modelView{res.Raw, []string{"id", "trip", "name", "date"}, rows})
 }}
 
 var travelCreatePlanCmd = &cobra.Command{Use: "create-plan", RunE: func(cmd *cobra.Command, args []string) error {
@@ -89,7 +94,7 @@ var travelCreatePlanCmd = &cobra.Command{Use: "create-plan", RunE: func(cmd *cob
 	if v := viper.GetString("date"); v != "" {
 		body["date"] = v
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Travel().CreatePlan(context.Background(), trip, body)
 }}
 
@@ -111,7 +116,7 @@ var travelUpdatePlanCmd = &cobra.Command{Use: "update-plan", RunE: func(cmd *cob
 	if len(patch) == 0 {
 		return fmt.Errorf("no fields to update")
 	}
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	return cl.Travel().UpdatePlan(context.Background(), plan, patch)
 }}
 
@@ -120,12 +125,16 @@ var travelTasksCmd = &cobra.Command{Use: "tasks", RunE: func(cmd *cobra.Command,
 		return err
 	}
 	plan := viper.GetString("plan")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Travel().PlanTasks(context.Background(), plan)
 	if err != nil {
 		return err
 	}
-	return output.Print(output.Format(viper.GetString("output")), []string{"tasks"}, []map[string]any{{"tasks": res}})
+	rows := make([]map[string]any, 0, len(res.Tasks))
+	for _, t := range res.Tasks {
+		rows = append(rows, map[string]any{"id": t.ID, "type": t.Type, "start": t.StartTime, "completed": t.Completed})
+	}
+	return printModel("tasks", modelView{res.Raw, []string{"id", "type", "start", "completed"}, rows})
 }}
 
 var travelAirportCmd = &cobra.Command{Use: "airport-search", RunE: func(cmd *cobra.Command, args []string) error {
@@ -133,12 +142,16 @@ var travelAirportCmd = &cobra.Command{Use: "airport-search", RunE: func(cmd *cob
 		return err
 	}
 	query := viper.GetString("query")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Travel().AirportSearch(context.Background(), query)
 	if err != nil {
 		return err
 	}
-	return output.Print(output.Format(viper.GetString("output")), []string{"airports"}, []map[string]any{{"airports": res}})
+	rows := make([]map[string]any, 0, len(res.Airports))
+	for _, a := range res.Airports {
+		rows = append(rows, map[string]any{"code": a.Code, "name": a.Name, "city": a.City, "country": a.Country, "timezone": a.Timezone})
+	}
+	return printModel("airports", modelView{res.Raw, []string{"code", "name", "city", "country", "timezone"}, rows})
 }}
 
 var travelFlightCmd = &cobra.Command{Use: "flight-status", RunE: func(cmd *cobra.Command, args []string) error {
@@ -146,12 +159,20 @@ var travelFlightCmd = &cobra.Command{Use: "flight-status", RunE: func(cmd *cobra
 		return err
 	}
 	flight := viper.GetString("flight")
-	cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+	cl := newClient()
 	res, err := cl.Travel().FlightStatus(context.Background(), flight)
 	if err != nil {
 		return err
 	}
-	return output.Print(output.Format(viper.GetString("output")), []string{"flight"}, []map[string]any{{"flight": res}})
+	row := map[string]any{
+		"flight":    res.FlightNumber,
+		"status":    res.Status,
+		"from":      res.DepartureAirport,
+		"to":        res.ArrivalAirport,
+		"departure": res.DepartureTime,
+		"arrival":   res.ArrivalTime,
+	}
+	return printModel("flight", modelView{res.Raw, []string{"flight", "status", "from", "to", "departure", "arrival"}, []map[string]any{row}})
 }}
 
 func init() {
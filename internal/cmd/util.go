@@ -1,6 +1,13 @@
 package cmd
 
-import "sort"
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/spf13/viper"
+
+	"github.com/steipete/eightctl/internal/output"
+)
 
 func mapKeys(m map[string]any) []string {
 	keys := make([]string, 0, len(m))
@@ -10,3 +17,31 @@ func mapKeys(m map[string]any) []string {
 	sort.Strings(keys)
 	return keys
 }
+
+// modelView is a typed API response prepared for printing.
+type modelView struct {
+	raw     json.RawMessage
+	headers []string
+	rows    []map[string]any
+}
+
+// printModel renders a typed response. JSON output keeps the body exactly
+// as the API returned it under name; table and CSV show the model's columns,
+// narrowed by --fields.
+func printModel(name string, v modelView) error {
+	format := output.Format(viper.GetString("output"))
+	if format == output.FormatJSON || len(v.headers) == 0 {
+		var body any = v.raw
+		if format != output.FormatJSON {
+			body = string(v.raw)
+		}
+		return output.Print(format, []string{name}, []map[string]any{{name: body}})
+	}
+	fields := viper.GetStringSlice("fields")
+	rows := output.FilterFields(v.rows, fields)
+	headers := v.headers
+	if len(fields) > 0 {
+		headers = fields
+	}
+	return output.Print(format, headers, rows)
+}
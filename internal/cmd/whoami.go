@@ -5,9 +5,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-
-	"github.com/steipete/eightctl/internal/client"
 )
 
 var whoamiCmd = &cobra.Command{
@@ -17,7 +14,7 @@ var whoamiCmd = &cobra.Command{
 		if err := requireAuthFields(); err != nil {
 			return err
 		}
-		cl := client.New(viper.GetString("email"), viper.GetString("password"), viper.GetString("user_id"), viper.GetString("client_id"), viper.GetString("client_secret"))
+		cl := newClient()
 		if err := cl.Authenticate(context.Background()); err != nil {
 			return err
 		}
@@ -5,10 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// DefaultMaxRPS is the shared request budget across all local eightctl processes.
+const DefaultMaxRPS = 1.0
+
 // Config holds merged configuration.
 type Config struct {
 	Email        string   `mapstructure:"email"`
@@ -20,6 +24,180 @@ type Config struct {
 	Output       string   `mapstructure:"output"`
 	Fields       []string `mapstructure:"fields"`
 	Verbose      bool     `mapstructure:"verbose"`
+	Retry        Retry    `mapstructure:"retry"`
+	MaxRPS       float64  `mapstructure:"max_rps"`
+
+	// PasswordCommand and PasswordFile supply the password when Password is
+	// empty; see ReadPasswordCommand and ReadPasswordFile.
+	PasswordCommand string `mapstructure:"password_command"`
+	PasswordFile    string `mapstructure:"password_file"`
+
+	// Device selects the pod commands act on, by ID or by a name from Devices.
+	// Empty uses the account's current device.
+	Device  string   `mapstructure:"device"`
+	Devices []Device `mapstructure:"devices"`
+
+	// API endpoint overrides, e.g. for `eightctl mock-server`. Empty uses the real cloud.
+	BaseURL       string `mapstructure:"base_url"`
+	AppAPIBaseURL string `mapstructure:"app_api_base_url"`
+	AuthURL       string `mapstructure:"auth_url"`
+
+	// Profile names the entry in Profiles to apply; --profile and
+	// EIGHTCTL_PROFILE override it.
+	Profile  string             `mapstructure:"profile"`
+	Profiles map[string]Profile `mapstructure:"profiles"`
+
+	// Sections for the long-running commands; their flags override them.
+	MQTT     MQTT     `mapstructure:"mqtt"`
+	Hubitat  Hubitat  `mapstructure:"hubitat"`
+	Exporter Exporter `mapstructure:"exporter"`
+	Serve    Serve    `mapstructure:"serve"`
+
+	// Schedule is the daemon's schedule. The daemon package decodes and
+	// validates the items.
+	Schedule []map[string]any `mapstructure:"schedule"`
+
+	// Latitude and Longitude, in degrees north and east, place the
+	// schedule's sunrise and sunset times.
+	Latitude  float64 `mapstructure:"latitude"`
+	Longitude float64 `mapstructure:"longitude"`
+
+	// KeyringBackend forces the token cache backend (keychain,
+	// secret-service, wincred, file, ...). Empty or "auto" picks the first
+	// that works.
+	KeyringBackend string `mapstructure:"keyring_backend"`
+
+	// File is the config file that was read, or "" when none was found.
+	File string `mapstructure:"-"`
+}
+
+// Profile holds per-account settings. Set fields replace the top-level
+// values when the profile is active.
+type Profile struct {
+	Email        string   `mapstructure:"email"`
+	Password     string   `mapstructure:"password"`
+	UserID       string   `mapstructure:"user_id"`
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	Timezone     string   `mapstructure:"timezone"`
+	Output       string   `mapstructure:"output"`
+	Fields       []string `mapstructure:"fields"`
+	Device       string   `mapstructure:"device"`
+	Devices      []Device `mapstructure:"devices"`
+
+	PasswordCommand string `mapstructure:"password_command"`
+	PasswordFile    string `mapstructure:"password_file"`
+}
+
+// ApplyProfile returns cfg with the named profile laid over it. Profile names
+// are case-insensitive; an empty name returns cfg unchanged.
+func (cfg Config) ApplyProfile(name string) (Config, error) {
+	if name == "" {
+		return cfg, nil
+	}
+	p, ok := cfg.Profiles[strings.ToLower(name)]
+	if !ok {
+		return cfg, fmt.Errorf("unknown profile %q", name)
+	}
+	cfg.Profile = strings.ToLower(name)
+	overlay := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	overlay(&cfg.Email, p.Email)
+	if p.Password != "" || p.PasswordCommand != "" || p.PasswordFile != "" {
+		// A profile's password source replaces every top-level one, so a
+		// top-level password_command never signs in the profile's account.
+		cfg.Password, cfg.PasswordCommand, cfg.PasswordFile = p.Password, p.PasswordCommand, p.PasswordFile
+	}
+	overlay(&cfg.UserID, p.UserID)
+	overlay(&cfg.ClientID, p.ClientID)
+	overlay(&cfg.ClientSecret, p.ClientSecret)
+	overlay(&cfg.Timezone, p.Timezone)
+	overlay(&cfg.Output, p.Output)
+	overlay(&cfg.Device, p.Device)
+	if len(p.Fields) > 0 {
+		cfg.Fields = p.Fields
+	}
+	if len(p.Devices) > 0 {
+		cfg.Devices = p.Devices
+	}
+	return cfg, nil
+}
+
+// Device gives a pod a friendly name for --device and the smart home bridges.
+type Device struct {
+	ID   string `mapstructure:"id"`
+	Name string `mapstructure:"name"`
+}
+
+// ResolveDevice maps a --device value to a device ID. Names from the devices
+// section match case-insensitively; anything else is taken as an ID.
+func ResolveDevice(devices []Device, key string) string {
+	for _, d := range devices {
+		if d.ID == key || strings.EqualFold(d.Name, key) {
+			return d.ID
+		}
+	}
+	return key
+}
+
+// MQTT configures the Home Assistant bridge. Keys match its flags.
+type MQTT struct {
+	Broker       string        `mapstructure:"broker"`
+	TopicPrefix  string        `mapstructure:"topic-prefix"`
+	DeviceName   string        `mapstructure:"device-name"`
+	ClientID     string        `mapstructure:"client-id"`
+	Username     string        `mapstructure:"mqtt-username"`
+	Password     string        `mapstructure:"mqtt-password"`
+	PollInterval time.Duration `mapstructure:"poll-interval"`
+}
+
+// Hubitat configures the Hubitat HTTP server. Keys match its flags.
+type Hubitat struct {
+	Port         int           `mapstructure:"port"`
+	PollInterval time.Duration `mapstructure:"poll-interval"`
+}
+
+// Exporter configures the Prometheus exporter. Keys match its flags.
+type Exporter struct {
+	Listen   string        `mapstructure:"listen"`
+	CacheTTL time.Duration `mapstructure:"cache-ttl"`
+}
+
+// Serve configures 'eightctl serve'. Keys match its flags.
+type Serve struct {
+	Services []string `mapstructure:"services"`
+}
+
+// ServiceNames are the services 'eightctl serve' can run, in the order it
+// starts them.
+var ServiceNames = []string{"daemon", "mqtt", "hubitat", "exporter"}
+
+// Retry tunes backoff for rate-limited API calls. Zero values use client defaults.
+type Retry struct {
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	BaseDelay   time.Duration `mapstructure:"base_delay"`
+	MaxDelay    time.Duration `mapstructure:"max_delay"`
+}
+
+// Dir returns the eightctl configuration directory (~/.config/eightctl).
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("find home: %w", err)
+	}
+	return filepath.Join(home, ".config", "eightctl"), nil
+}
+
+// DefaultFile returns the path Load reads when no config file is given.
+func DefaultFile() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
 }
 
 // Load initializes viper and unmarshals Config.
@@ -34,21 +212,24 @@ func Load(configPath string, quiet bool) (Config, error) {
 	if configPath != "" {
 		v.SetConfigFile(configPath)
 	} else {
-		home, err := os.UserHomeDir()
+		dir, err := Dir()
 		if err != nil {
-			return Config{}, fmt.Errorf("find home: %w", err)
+			return Config{}, err
 		}
-		v.AddConfigPath(filepath.Join(home, ".config", "eightctl"))
+		v.AddConfigPath(dir)
 		v.SetConfigName("config")
 	}
 
 	// defaults
 	v.SetDefault("timezone", "local")
 	v.SetDefault("output", "table")
+	v.SetDefault("max_rps", DefaultMaxRPS)
 
+	file := ""
 	if err := v.ReadInConfig(); err == nil {
+		file = v.ConfigFileUsed()
 		if !quiet {
-			fmt.Fprintf(os.Stderr, "Using config file: %s\n", v.ConfigFileUsed())
+			fmt.Fprintf(os.Stderr, "Using config file: %s\n", file)
 		}
 	}
 
@@ -56,12 +237,17 @@ func Load(configPath string, quiet bool) (Config, error) {
 	if err := v.Unmarshal(&cfg); err != nil {
 		return Config{}, fmt.Errorf("decode config: %w", err)
 	}
+	cfg.File = file
 
 	return cfg, nil
 }
 
 // WarnInsecurePerms checks if config file is too permissive.
 func WarnInsecurePerms(path string) error {
+	return insecurePerms("config file", path)
+}
+
+func insecurePerms(what, path string) error {
 	if path == "" {
 		return nil
 	}
@@ -71,7 +257,7 @@ func WarnInsecurePerms(path string) error {
 	}
 	mode := info.Mode().Perm()
 	if mode&0o077 != 0 {
-		return fmt.Errorf("config file %s permissions %o; suggest 600", path, mode)
+		return fmt.Errorf("%s %s permissions %o; suggest 600", what, path, mode)
 	}
 	return nil
 }
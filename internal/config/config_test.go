@@ -0,0 +1,325 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestResolveDevice(t *testing.T) {
+	devices := []Device{
+		{ID: "dev-a", Name: "Bedroom"},
+		{ID: "dev-b", Name: "Guest Room"},
+	}
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"Bedroom", "dev-a"},
+		{"guest room", "dev-b"},
+		{"dev-b", "dev-b"},
+		{"dev-unlisted", "dev-unlisted"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := ResolveDevice(devices, tt.key); got != tt.want {
+			t.Errorf("ResolveDevice(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestLoadAppliesProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, `
+email: me@example.com
+password: top-secret
+timezone: America/New_York
+profile: partner
+profiles:
+  Partner:
+    email: partner@example.com
+    device: guest
+  test:
+    output: json
+`)
+	cfg, err := Load(path, true)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.File != path || cfg.Profile != "partner" || len(cfg.Profiles) != 2 {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+
+	got, err := cfg.ApplyProfile(cfg.Profile)
+	if err != nil {
+		t.Fatalf("ApplyProfile: %v", err)
+	}
+	if got.Email != "partner@example.com" || got.Device != "guest" {
+		t.Errorf("profile values not applied: %+v", got)
+	}
+	if got.Password != "top-secret" || got.Timezone != "America/New_York" {
+		t.Errorf("unset profile keys should keep top-level values: %+v", got)
+	}
+
+	if _, err := cfg.ApplyProfile("nope"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+	if same, _ := cfg.ApplyProfile(""); same.Email != "me@example.com" {
+		t.Errorf("empty profile should leave config unchanged, got %q", same.Email)
+	}
+}
+
+func TestSetKeyKeepsOtherContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "# account\nemail: me@example.com\nprofile: old # default\n")
+
+	if err := SetKey(path, "profile", "work"); err != nil {
+		t.Fatalf("SetKey: %v", err)
+	}
+	if err := SetKey(path, "mqtt.broker", "tcp://localhost:1883"); err != nil {
+		t.Fatalf("SetKey nested: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# account\nemail: me@example.com\nprofile: work # default\nmqtt:\n  broker: tcp://localhost:1883\n"
+	if string(data) != want {
+		t.Errorf("config after SetKey:\n%s\nwant:\n%s", data, want)
+	}
+
+	if err := SetKey(path, "email.user", "x"); err == nil || !strings.Contains(err.Error(), "not a mapping") {
+		t.Errorf("expected not-a-mapping error, got %v", err)
+	}
+}
+
+func TestSetKeyCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "eightctl", "config.yaml")
+	if err := SetKey(path, "profile", "work"); err != nil {
+		t.Fatalf("SetKey: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("new config file mode %o, want 600", info.Mode().Perm())
+	}
+}
+
+func TestWriteKeysReplacesFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	writeFile(t, path, "email: old@example.com\npassword: hunter2\n")
+
+	err := WriteKeys(path, []KeyValue{{Key: "email", Value: "me@example.com"}, {Key: "mqtt.broker", Value: "tcp://localhost:1883"}})
+	if err != nil {
+		t.Fatalf("WriteKeys: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "email: me@example.com\nmqtt:\n  broker: tcp://localhost:1883\n"; string(data) != want {
+		t.Errorf("config after WriteKeys:\n%s\nwant:\n%s", data, want)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only config.yaml left behind, got %d entries", len(entries))
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProfilePasswordSourceReplacesTopLevel(t *testing.T) {
+	cfg := Config{
+		Password: "top-secret",
+		Profiles: map[string]Profile{
+			"work": {PasswordCommand: "pass show eightsleep/work"},
+			"home": {Email: "home@example.com"},
+		},
+	}
+	work, _ := cfg.ApplyProfile("work")
+	if work.Password != "" || work.PasswordCommand != "pass show eightsleep/work" {
+		t.Errorf("work profile: password=%q command=%q", work.Password, work.PasswordCommand)
+	}
+	home, _ := cfg.ApplyProfile("home")
+	if home.Password != "top-secret" {
+		t.Errorf("home profile should inherit password, got %q", home.Password)
+	}
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pw")
+	writeFile(t, path, "hunter2\r\nsecond line\n")
+	pw, err := ReadPasswordFile(path)
+	if err != nil || pw != "hunter2" {
+		t.Fatalf("ReadPasswordFile = %q, %v", pw, err)
+	}
+
+	writeFile(t, path, "\n")
+	if _, err := ReadPasswordFile(path); err == nil {
+		t.Error("expected error for empty password file")
+	}
+}
+
+func TestReadPasswordCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	pw, err := ReadPasswordCommand(context.Background(), "printf 'hunter2\\nuser: me\\n'")
+	if err != nil || pw != "hunter2" {
+		t.Fatalf("ReadPasswordCommand = %q, %v", pw, err)
+	}
+	if _, err := ReadPasswordCommand(context.Background(), "exit 3"); err == nil {
+		t.Error("expected error for failing command")
+	}
+}
+
+func TestValidateReportsTyposAndBadValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, `
+email: me@example.com
+timezon: Europe/Berlin
+output: xml
+profile: work
+profiles:
+  home:
+    devices:
+      - name: Bedroom
+mqtt:
+  broker: localhost:1883
+  poll-intervall: 10s
+hubitat:
+  port: 70000
+serve:
+  services: [daemon, homekit, daemon]
+latitude: 95
+schedule:
+  - time: "22:00"
+    action: on
+`)
+	problems, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	want := []string{
+		`mqtt.poll-intervall: unknown key; did you mean "poll-interval"?`,
+		`timezon: unknown key; did you mean "timezone"?`,
+		`output: must be table, json, or csv, not "xml"`,
+		`profiles.home.devices[0].id: is required`,
+		`profile: no profile named "work"`,
+		`latitude: must be between -90 and 90`,
+		`mqtt.broker: must be a URL like tcp://host:1883, not "localhost:1883"`,
+		`hubitat.port: must be between 1 and 65535`,
+		`serve.services[1]: unknown service "homekit" (want daemon, mqtt, hubitat, exporter)`,
+		`serve.services[2]: "daemon" is listed twice`,
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.Error())
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidateReportsWrongTypes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "max_rps: fast\n")
+	problems, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0].Msg, "max_rps") {
+		t.Errorf("problems = %v", problems)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	cases := []struct {
+		key, in string
+		want    any
+	}{
+		{"email", "me@example.com", "me@example.com"},
+		{"max_rps", "2.5", 2.5},
+		{"verbose", "true", true},
+		{"hubitat.port", "8081", 8081},
+		{"mqtt.poll-interval", "45s", "45s"},
+		{"profiles.work.timezone", "UTC", "UTC"},
+	}
+	for _, c := range cases {
+		got, err := ParseValue(c.key, c.in)
+		if err != nil || got != c.want {
+			t.Errorf("ParseValue(%q, %q) = %v, %v; want %v", c.key, c.in, got, err, c.want)
+		}
+	}
+	if got, err := ParseValue("fields", "a, b"); err != nil || len(got.([]string)) != 2 {
+		t.Errorf("ParseValue(fields) = %v, %v", got, err)
+	}
+	for _, key := range []string{"nope", "mqtt.nope", "devices", "retry"} {
+		if _, err := ParseValue(key, "x"); err == nil {
+			t.Errorf("ParseValue(%q) should fail", key)
+		}
+	}
+	if _, err := ParseValue("hubitat.port", "eighty"); err == nil {
+		t.Error("expected error for non-integer port")
+	}
+}
+
+func TestWatchReportsWritesAndSIGHUP(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no SIGHUP on windows")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("email: a@example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changed := make(chan struct{}, 10)
+	if err := Watch(ctx, path, func() { changed <- struct{}{} }); err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	wait := func(what string) {
+		t.Helper()
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no change reported after %s", what)
+		}
+	}
+
+	// Other files in the directory are ignored.
+	if err := os.WriteFile(filepath.Join(dir, "other.yaml"), []byte("x: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("email: b@example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wait("write")
+	select {
+	case <-changed:
+		t.Fatal("one save should produce one change")
+	case <-time.After(3 * watchDebounce):
+	}
+
+	self, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := self.Signal(syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	wait("SIGHUP")
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyValue is one setting for WriteKeys.
+type KeyValue struct {
+	Key   string
+	Value any
+}
+
+// SetKey writes value under a dotted key (e.g. "mqtt.broker") in the YAML
+// file at path, creating the file and any parent mappings as needed. Other
+// keys and comments are kept as they are.
+func SetKey(path, key string, value any) error {
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: top level is not a mapping", path)
+	}
+	if err := setNode(root, key, value); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return writeDoc(path, &doc)
+}
+
+// WriteKeys replaces the file at path with one holding just values, in
+// order. The old file stays in place until the new one is complete.
+func WriteKeys(path string, values []KeyValue) error {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, kv := range values {
+		if err := setNode(root, kv.Key, kv.Value); err != nil {
+			return err
+		}
+	}
+	return writeDoc(path, &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}})
+}
+
+// setNode sets a dotted key in a YAML mapping, creating parent mappings.
+func setNode(root *yaml.Node, key string, value any) error {
+	var val yaml.Node
+	if err := val.Encode(value); err != nil {
+		return err
+	}
+	parts := strings.Split(key, ".")
+	node := root
+	for i, part := range parts {
+		child := mappingValue(node, part)
+		if i == len(parts)-1 {
+			if child == nil {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, &val)
+			} else {
+				// Keep any comment attached to the old value.
+				val.HeadComment, val.LineComment, val.FootComment = child.HeadComment, child.LineComment, child.FootComment
+				*child = val
+			}
+			break
+		}
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, child)
+		}
+		if child.Kind != yaml.MappingNode {
+			return fmt.Errorf("%s is not a mapping", strings.Join(parts[:i+1], "."))
+		}
+		node = child
+	}
+	return nil
+}
+
+// writeDoc encodes doc to path through a rename, so a failed write never
+// leaves the config half written. A symlinked config file is written at
+// its target.
+func writeDoc(path string, doc *yaml.Node) error {
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, out.Bytes(), 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a YAML mapping, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
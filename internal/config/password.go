@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ReadPasswordCommand runs command through the shell and returns the first
+// line of its stdout, the convention of pass, the 1Password CLI
+// (op read), and systemd-creds. Stderr passes through so the command can
+// prompt, e.g. for a GPG passphrase.
+func ReadPasswordCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("password_command: %w", err)
+	}
+	pw := firstLine(out.String())
+	if pw == "" {
+		return "", fmt.Errorf("password_command printed no password")
+	}
+	return pw, nil
+}
+
+// ReadPasswordFile returns the first line of the file at path. A leading
+// "~/" is expanded. Like the config file, it should be readable only by
+// its owner; check it with WarnInsecurePasswordFile.
+func ReadPasswordFile(path string) (string, error) {
+	path, err := ExpandHome(path)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("password_file: %w", err)
+	}
+	pw := firstLine(string(data))
+	if pw == "" {
+		return "", fmt.Errorf("password_file %s is empty", path)
+	}
+	return pw, nil
+}
+
+// WarnInsecurePasswordFile is WarnInsecurePerms for password_file, with
+// "~/" expanded the way ReadPasswordFile does.
+func WarnInsecurePasswordFile(path string) error {
+	path, err := ExpandHome(path)
+	if err != nil {
+		return nil
+	}
+	return insecurePerms("password file", path)
+}
+
+// ExpandHome replaces a leading "~/" in path with the home directory.
+func ExpandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("find home: %w", err)
+	}
+	return filepath.Join(home, rest), nil
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return strings.TrimSuffix(line, "\r")
+}
@@ -0,0 +1,366 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is one thing Validate found wrong with a config file.
+type Problem struct {
+	Key string // dotted path, e.g. "profiles.work.devices[0].id"; "" for the whole file
+	Msg string
+}
+
+func (p Problem) Error() string {
+	if p.Key == "" {
+		return p.Msg
+	}
+	return p.Key + ": " + p.Msg
+}
+
+// Validate checks the config file at path for unknown keys (usually typos),
+// values of the wrong type, and values commands would reject. Schedule
+// items are left to the daemon package. The error is non-nil only when the
+// file cannot be read or is not YAML.
+func Validate(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	problems := unknownKeys(reflect.TypeOf(Config{}), raw, "")
+
+	// Decode the way Load does, minus the environment, so type errors
+	// point at the file.
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(string(data))); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return append(problems, Problem{Msg: err.Error()}), nil
+	}
+	return append(problems, cfg.check()...), nil
+}
+
+// check reports values the commands would reject.
+func (cfg Config) check() []Problem {
+	var problems []Problem
+	add := func(key, format string, args ...any) {
+		problems = append(problems, Problem{Key: key, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	checkAccount := func(prefix, password, command, file, timezone, output string, devices []Device) {
+		n := 0
+		for _, s := range []string{password, command, file} {
+			if s != "" {
+				n++
+			}
+		}
+		if n > 1 {
+			add(prefix+"password", "set only one of password, password_command, and password_file")
+		}
+		if timezone != "" && timezone != "local" {
+			if _, err := time.LoadLocation(timezone); err != nil {
+				add(prefix+"timezone", "unknown timezone %q", timezone)
+			}
+		}
+		switch output {
+		case "", "table", "json", "csv":
+		default:
+			add(prefix+"output", "must be table, json, or csv, not %q", output)
+		}
+		for i, d := range devices {
+			if d.ID == "" {
+				add(fmt.Sprintf("%sdevices[%d].id", prefix, i), "is required")
+			}
+		}
+	}
+	checkAccount("", cfg.Password, cfg.PasswordCommand, cfg.PasswordFile, cfg.Timezone, cfg.Output, cfg.Devices)
+	for _, name := range sortedKeys(cfg.Profiles) {
+		p := cfg.Profiles[name]
+		checkAccount("profiles."+name+".", p.Password, p.PasswordCommand, p.PasswordFile, p.Timezone, p.Output, p.Devices)
+	}
+	if cfg.Profile != "" {
+		if _, ok := cfg.Profiles[strings.ToLower(cfg.Profile)]; !ok {
+			add("profile", "no profile named %q", cfg.Profile)
+		}
+	}
+
+	if cfg.Latitude < -90 || cfg.Latitude > 90 {
+		add("latitude", "must be between -90 and 90")
+	}
+	if cfg.Longitude < -180 || cfg.Longitude > 180 {
+		add("longitude", "must be between -180 and 180")
+	}
+	if cfg.MaxRPS < 0 {
+		add("max_rps", "must not be negative")
+	}
+	if cfg.Retry.MaxAttempts < 0 {
+		add("retry.max_attempts", "must not be negative")
+	}
+	if cfg.Retry.BaseDelay < 0 {
+		add("retry.base_delay", "must not be negative")
+	}
+	if cfg.Retry.MaxDelay < 0 {
+		add("retry.max_delay", "must not be negative")
+	}
+
+	if cfg.MQTT.Broker != "" {
+		u, err := url.Parse(cfg.MQTT.Broker)
+		if err != nil || u.Host == "" {
+			add("mqtt.broker", "must be a URL like tcp://host:1883, not %q", cfg.MQTT.Broker)
+		} else {
+			switch u.Scheme {
+			case "tcp", "ssl", "tls", "mqtt", "mqtts", "ws", "wss":
+			default:
+				add("mqtt.broker", "unsupported scheme %q", u.Scheme)
+			}
+		}
+	}
+	if cfg.MQTT.PollInterval < 0 {
+		add("mqtt.poll-interval", "must not be negative")
+	}
+	if cfg.Hubitat.Port < 0 || cfg.Hubitat.Port > 65535 {
+		add("hubitat.port", "must be between 1 and 65535")
+	}
+	if cfg.Hubitat.PollInterval < 0 {
+		add("hubitat.poll-interval", "must not be negative")
+	}
+	if cfg.Exporter.Listen != "" {
+		if _, _, err := net.SplitHostPort(cfg.Exporter.Listen); err != nil {
+			add("exporter.listen", "must be host:port or :port, not %q", cfg.Exporter.Listen)
+		}
+	}
+	if cfg.Exporter.CacheTTL < 0 {
+		add("exporter.cache-ttl", "must not be negative")
+	}
+	seen := map[string]bool{}
+	for i, name := range cfg.Serve.Services {
+		key := fmt.Sprintf("serve.services[%d]", i)
+		switch {
+		case !slices.Contains(ServiceNames, name):
+			add(key, "unknown service %q (want %s)", name, strings.Join(ServiceNames, ", "))
+		case seen[name]:
+			add(key, "%q is listed twice", name)
+		}
+		seen[name] = true
+	}
+	return problems
+}
+
+// unknownKeys walks raw alongside t and reports keys t has no field for.
+func unknownKeys(t reflect.Type, raw any, path string) []Problem {
+	var problems []Problem
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return nil // wrong type; decoding reports it
+		}
+		fields := structFields(t)
+		for _, k := range sortedKeys(m) {
+			ft, ok := fields[k]
+			if !ok {
+				msg := "unknown key"
+				if s := suggest(k, fields); s != "" {
+					msg += fmt.Sprintf("; did you mean %q?", s)
+				}
+				problems = append(problems, Problem{Key: joinKey(path, k), Msg: msg})
+				continue
+			}
+			problems = append(problems, unknownKeys(ft, m[k], joinKey(path, k))...)
+		}
+	case reflect.Map:
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return nil
+		}
+		for _, k := range sortedKeys(m) {
+			problems = append(problems, unknownKeys(t.Elem(), m[k], joinKey(path, k))...)
+		}
+	case reflect.Slice:
+		l, ok := raw.([]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range l {
+			problems = append(problems, unknownKeys(t.Elem(), item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return problems
+}
+
+// structFields maps the mapstructure keys of t to their field types.
+func structFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("mapstructure")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		fields[tag] = f.Type
+	}
+	return fields
+}
+
+// suggest returns the known key closest to k, if it is a plausible typo.
+func suggest(k string, fields map[string]reflect.Type) string {
+	best, bestDist := "", 3
+	for _, name := range sortedKeys(fields) {
+		if d := editDistance(k, name); d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// KeyType returns the Go type stored under a dotted key such as
+// "mqtt.broker" or "profiles.work.email".
+func KeyType(key string) (reflect.Type, bool) {
+	t := reflect.TypeOf(Config{})
+	for _, part := range strings.Split(key, ".") {
+		switch t.Kind() {
+		case reflect.Struct:
+			ft, ok := structFields(t)[part]
+			if !ok {
+				return nil, false
+			}
+			t = ft
+		case reflect.Map:
+			if part == "" {
+				return nil, false
+			}
+			t = t.Elem()
+		default:
+			return nil, false
+		}
+	}
+	return t, true
+}
+
+// ParseValue converts a command-line value for key to what the file should
+// hold: numbers and booleans stay typed, durations are checked but kept as
+// written, and lists are comma-separated.
+func ParseValue(key, s string) (any, error) {
+	t, ok := KeyType(key)
+	if !ok {
+		return nil, fmt.Errorf("unknown config key %q", key)
+	}
+	if t == reflect.TypeOf(time.Duration(0)) {
+		if _, err := time.ParseDuration(s); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		return s, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return s, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: want true or false, got %q", key, s)
+		}
+		return b, nil
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: want an integer, got %q", key, s)
+		}
+		return n, nil
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: want a number, got %q", key, s)
+		}
+		return f, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			parts := strings.Split(s, ",")
+			for i := range parts {
+				parts[i] = strings.TrimSpace(parts[i])
+			}
+			return parts, nil
+		}
+	}
+	return nil, fmt.Errorf("%s cannot be set from the command line; edit the file", key)
+}
+
+func joinKey(path, k string) string {
+	if path == "" {
+		return k
+	}
+	return path + "." + k
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// FileKeys returns the dotted keys the config file at path sets, lowercased
+// the way viper reports them.
+func FileKeys(path string) (map[string]bool, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	keys := map[string]bool{}
+	for _, k := range v.AllKeys() {
+		keys[k] = true
+	}
+	return keys, nil
+}
+
+// Sets reports whether the profile overrides the top-level key.
+func (p Profile) Sets(key string) bool {
+	v := reflect.ValueOf(p)
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("mapstructure") == key {
+			return !v.Field(i).IsZero()
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the burst of events one save produces.
+const watchDebounce = 200 * time.Millisecond
+
+// Watch calls onChange, from a single goroutine, whenever the config file at
+// path changes and whenever the process gets SIGHUP, until ctx is done. The
+// directory is watched rather than the file so editors that save by
+// replacing the file are seen too. A non-nil error means file changes are
+// not watched; SIGHUP still works.
+func Watch(ctx context.Context, path string, onChange func()) error {
+	path = filepath.Clean(path)
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	var events chan fsnotify.Event
+	var errs chan error
+	w, err := fsnotify.NewWatcher()
+	if err == nil {
+		if err = w.Add(filepath.Dir(path)); err != nil {
+			w.Close()
+		} else {
+			events, errs = w.Events, w.Errors
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("watch %s: %w", path, err)
+	}
+
+	go func() {
+		defer signal.Stop(hup)
+		if events != nil {
+			defer w.Close()
+		}
+		debounce := time.NewTimer(0)
+		<-debounce.C
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				onChange()
+			case ev := <-events:
+				if filepath.Clean(ev.Name) == path && ev.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					debounce.Reset(watchDebounce)
+				}
+			case <-errs:
+				// Overflow or a transient error; the next event still counts.
+			case <-debounce.C:
+				onChange()
+			}
+		}
+	}()
+	return err
+}
@@ -0,0 +1,180 @@
+package daemon
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month,
+// month, and day of week. Fields take *, lists, ranges, and steps; months
+// and weekdays also take three-letter names, and 7 is Sunday like 0. As in
+// crontab, when both day fields are restricted a day matching either fires.
+type Cron struct {
+	minute, hour, dom, month, dow uint64 // bit n set when value n matches
+	domAny, dowAny                bool
+}
+
+// cronSearchDays bounds Next; a leap day recurs within it.
+const cronSearchDays = 8 * 366
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	dayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+// ParseCron parses a five-field cron expression such as "30 6 * * mon-fri".
+// Expressions that can never fire, like "0 0 31 feb *", are errors.
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("want 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+	var c Cron
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if c.dow, err = parseCronField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1 // 7 is Sunday too
+	}
+	c.domAny = strings.HasPrefix(fields[2], "*")
+	c.dowAny = strings.HasPrefix(fields[4], "*")
+	if c.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, fmt.Errorf("never fires")
+	}
+	return &c, nil
+}
+
+// parseCronField parses one comma-separated field into a bit set.
+func parseCronField(field string, lo, hi int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("bad step %q", stepStr)
+			}
+			step = n
+		}
+		start, end := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = cronValue(a, lo, hi, names); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = cronValue(b, lo, hi, names); err != nil {
+					return 0, err
+				}
+				if end < start {
+					return 0, fmt.Errorf("range %q runs backwards", rng)
+				}
+			} else if hasStep {
+				end = hi // "5/15" means from 5 to the end
+			}
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(s string, lo, hi int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad value %q", s)
+	}
+	if v < lo || v > hi {
+		return 0, fmt.Errorf("%d out of range %d-%d", v, lo, hi)
+	}
+	return v, nil
+}
+
+// dayMatches reports whether the expression fires on the date of t.
+func (c *Cron) dayMatches(t time.Time) bool {
+	if c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Next returns the first firing strictly after t, in t's location, or the
+// zero time if there is none within eight years.
+func (c *Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	y, m, d := t.Date()
+	for i := 0; i <= cronSearchDays; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, loc)
+		if !c.dayMatches(day) {
+			continue
+		}
+		for h := 0; h < 24; h++ {
+			if c.hour&(1<<h) == 0 {
+				continue
+			}
+			for min := 0; min < 60; min++ {
+				if c.minute&(1<<min) == 0 {
+					continue
+				}
+				if cand := wallTime(day.Year(), day.Month(), day.Day(), h, min, loc); cand.After(t) {
+					return cand
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// wallTime is time.Date for a schedule, made predictable across DST
+// changes: a wall time skipped by a spring-forward gap runs as late as the
+// gap is long, and one repeated by a fall-back overlap means the first.
+func wallTime(y int, m time.Month, d, h, min int, loc *time.Location) time.Time {
+	t := time.Date(y, m, d, h, min, 0, 0, loc)
+	if t.Hour() != h || t.Minute() != min {
+		// In a gap: read the wall time with the offset from before it.
+		_, off := t.Add(-3 * time.Hour).Zone()
+		return time.Date(y, m, d, h, min, 0, 0, time.FixedZone("", off)).In(loc)
+	}
+	if first := t.Add(-time.Hour); first.Hour() == h && first.Minute() == min {
+		return first
+	}
+	return t
+}
@@ -0,0 +1,64 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	// 2026-03-06 is a Friday.
+	base := time.Date(2026, 3, 6, 12, 0, 0, 0, ny)
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"30 6 * * mon-fri", base, time.Date(2026, 3, 9, 6, 30, 0, 0, ny)},
+		{"0 9 * * sat,sun", base, time.Date(2026, 3, 7, 9, 0, 0, 0, ny)},
+		{"*/15 * * * *", base, time.Date(2026, 3, 6, 12, 15, 0, 0, ny)},
+		{"0 22 1 * *", base, time.Date(2026, 4, 1, 22, 0, 0, 0, ny)},
+		{"0 0 29 feb *", base, time.Date(2028, 2, 29, 0, 0, 0, 0, ny)},
+		{"0 7 * * 7", base, time.Date(2026, 3, 8, 7, 0, 0, 0, ny)},
+		// Both day fields restricted: either matches, as in crontab.
+		{"0 8 13 * fri", base, time.Date(2026, 3, 13, 8, 0, 0, 0, ny)},
+		{"0 8 10 * mon", base, time.Date(2026, 3, 9, 8, 0, 0, 0, ny)},
+		// 02:30 does not exist on 2026-03-08 in New York; it runs at 03:30.
+		{"30 2 8 mar *", base, time.Date(2026, 3, 8, 3, 30, 0, 0, ny)},
+		// 01:30 happens twice on 2026-11-01; it runs the first time.
+		{"30 1 1 nov *", base, time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := c.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("%q.Next(%v) = %v, want %v", tt.expr, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestParseCronRejects(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * * funday",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"0 0 31 feb *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) accepted", expr)
+		}
+	}
+}
@@ -6,106 +6,365 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/charmbracelet/log"
+
 	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/model"
+	"github.com/steipete/eightctl/internal/state"
 )
 
-// ScheduleItem describes a timed action.
+// ScheduleItem describes a timed action. It fires at Time on Days (every
+// day when empty), or whenever Cron matches. Time is HH:MM, or sunrise or
+// sunset with an optional offset such as "sunrise-30m". Side picks left,
+// right, or both; empty acts on the signed-in user's side.
 type ScheduleItem struct {
 	Time        string `mapstructure:"time" yaml:"time"`
+	Days        Days   `mapstructure:"days" yaml:"days"`
+	Cron        string `mapstructure:"cron" yaml:"cron"`
+	Side        string `mapstructure:"side" yaml:"side"`
 	Action      string `mapstructure:"action" yaml:"action"`
 	Temperature string `mapstructure:"temperature" yaml:"temperature"`
+
+	// A ramp action moves the temperature From..To in a SetTemperature
+	// call every Step until Over has passed.
+	From string        `mapstructure:"from" yaml:"from"`
+	To   string        `mapstructure:"to" yaml:"to"`
+	Over time.Duration `mapstructure:"over" yaml:"over"`
+	Step time.Duration `mapstructure:"step" yaml:"step"`
+
+	// CatchUp says what to do with firings missed while the daemon was
+	// stopped or the machine asleep: skip (the default), run_latest, or
+	// run_all, for firings at most MaxLateness late.
+	CatchUp     string        `mapstructure:"catch_up" yaml:"catch_up"`
+	MaxLateness time.Duration `mapstructure:"max_lateness" yaml:"max_lateness"`
 }
 
 // Runner executes scheduled items.
 type Runner struct {
 	Items    []ScheduleItem
 	Client   *client.Client
+	Pod      state.StateProvider // acts for items with a Side
 	Timezone *time.Location
 	DryRun   bool
 	Sync     bool
 	PIDFile  string
+
+	// StateFile keeps how far each item has been handled across restarts,
+	// so nothing runs twice and missed firings can be caught up. Empty
+	// keeps it in memory only.
+	StateFile string
+
+	// Latitude and Longitude place sunrise and sunset times.
+	Latitude  float64
+	Longitude float64
+
+	// Changed, if set, is called after each action that changes the pod,
+	// e.g. to drop cached state that other services read.
+	Changed func()
+
+	items   atomic.Pointer[[]ScheduleItem] // set by SetItems; replaces Items
+	handled map[string]time.Time           // by item key: firings up to here are done
+	checked time.Time                      // when process last ran
+
+	rampMu    sync.Mutex
+	ramps     map[model.Side]*ramp // running ramps by rampKeys side
+	rampWG    sync.WaitGroup
+	rampFails chan error
+
+	selfMu   sync.Mutex
+	self     model.Side // signed-in user's side, cached by selfSide
+	selfUser string     // user self was found for
 }
 
+// SetItems swaps in a new schedule while Run is running; the next check
+// uses it. Items should already be validated.
+func (r *Runner) SetItems(items []ScheduleItem) {
+	r.items.Store(&items)
+}
+
+// schedule returns the items currently in effect.
+func (r *Runner) schedule() []ScheduleItem {
+	if items := r.items.Load(); items != nil {
+		return *items
+	}
+	return r.Items
+}
+
+// place is where the schedule runs.
+func (r *Runner) place() Place {
+	return Place{Location: r.Timezone, Latitude: r.Latitude, Longitude: r.Longitude}
+}
+
+// onTime is how late a firing may run and still count as on time. Run
+// wakes at least this often, so it notices a resume from suspend or a clock
+// change promptly.
+const onTime = time.Minute
+
+// Run executes the schedule until ctx is done. It sleeps until the next
+// firing by the wall clock, and after a restart or suspend handles the
+// firings it missed by each item's catch-up policy.
 func (r *Runner) Run(ctx context.Context) error {
 	if err := r.writePID(); err != nil {
 		return err
 	}
 	defer r.removePID()
+	if err := r.loadState(); err != nil {
+		return err
+	}
 
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
-	executed := map[string]bool{}
-	day := time.Now().Day()
-
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	// Cancelling on signal also aborts in-flight API calls and retry waits.
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	defer r.stopRamps()
 
+	timer := time.NewTimer(0)
+	defer timer.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return nil
-		case <-sig:
-			return nil
-		case now := <-ticker.C:
-			if now.Day() != day {
-				executed = map[string]bool{}
-				day = now.Day()
+		case err := <-r.rampFailures():
+			if ctx.Err() != nil {
+				return nil
 			}
-			if err := r.process(now, executed); err != nil {
-				return err
+			return err
+		case <-timer.C:
+		}
+		now := time.Now()
+		if err := r.process(ctx, now); err != nil {
+			if ctx.Err() != nil {
+				return nil
 			}
+			return err
 		}
+		timer.Reset(r.wait(time.Now()))
 	}
 }
 
-func (r *Runner) process(now time.Time, executed map[string]bool) error {
-	for _, item := range r.Items {
-		t, err := time.ParseInLocation("15:04", item.Time, r.Timezone)
+// wait returns how long Run may sleep after now: until the next firing,
+// but no longer than onTime.
+func (r *Runner) wait(now time.Time) time.Duration {
+	d := onTime
+	for _, item := range r.schedule() {
+		next, err := item.Next(now, r.place())
+		if err == nil && !next.IsZero() && next.Sub(now) < d {
+			d = next.Sub(now)
+		}
+	}
+	return d
+}
+
+// firing is a run of an item that process decided to perform.
+type firing struct {
+	at   time.Time
+	key  string
+	item ScheduleItem
+}
+
+// process runs every firing due by now that has not been handled yet, in
+// time order, and records how far each item has been handled.
+func (r *Runner) process(ctx context.Context, now time.Time) error {
+	defer func() { r.checked = now }()
+	if r.handled == nil {
+		r.handled = map[string]time.Time{}
+	}
+	items := r.schedule()
+	var due []firing
+	pending := map[string]int{}
+	current := map[string]bool{}
+	for _, item := range items {
+		key := item.key()
+		if current[key] {
+			continue // a duplicate item fires once
+		}
+		current[key] = true
+		last, ok := r.handled[key]
+		if !ok {
+			// New to the schedule: count from the previous check, so an
+			// item added by a reload still fires on time.
+			last = now
+			if !r.checked.IsZero() && r.checked.Before(now) {
+				last = r.checked
+			}
+			r.handled[key] = last
+		}
+		if !last.Before(now) {
+			continue // new this check, or the clock went back
+		}
+		runs, err := r.catchUp(item, key, last, now)
 		if err != nil {
-			return fmt.Errorf("parse time %s: %w", item.Time, err)
+			return err
 		}
-		candidate := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, r.Timezone)
-		if now.Before(candidate) || now.Sub(candidate) >= time.Minute {
+		if len(runs) == 0 {
+			r.handled[key] = now
 			continue
 		}
-		key := candidate.Format("2006-01-02 15:04") + item.Action
-		if executed[key] {
-			continue
+		for _, at := range runs {
+			due = append(due, firing{at: at, key: key, item: item})
 		}
-		executed[key] = true
-		if r.DryRun {
-			fmt.Printf("DRY-RUN %s %s %s\n", candidate.Format(time.RFC3339), item.Action, item.Temperature)
-			continue
+		pending[key] = len(runs)
+	}
+	for key := range r.handled {
+		if !current[key] {
+			delete(r.handled, key) // removed from the schedule
 		}
-		switch item.Action {
+	}
+	if err := r.saveState(); err != nil {
+		return err
+	}
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].at.Before(due[j].at) })
+	for _, f := range due {
+		if err := r.fire(ctx, f.item, f.at); err != nil {
+			return err
+		}
+		r.handled[f.key] = f.at
+		if pending[f.key]--; pending[f.key] == 0 {
+			r.handled[f.key] = now
+		}
+		if err := r.saveState(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// catchUp returns the firings of item after last and up to now that its
+// catch-up policy runs. Firings later than onTime are late: skip drops
+// them, run_latest runs the newest, and run_all runs each, all within the
+// item's max lateness.
+func (r *Runner) catchUp(item ScheduleItem, key string, last, now time.Time) ([]time.Time, error) {
+	window := onTime
+	if item.CatchUp == "run_latest" || item.CatchUp == "run_all" {
+		window = item.maxLateness()
+	}
+	from := last
+	if cutoff := now.Add(-window); from.Before(cutoff) {
+		first, err := item.Next(from, r.place())
+		if err != nil {
+			return nil, err
+		}
+		if !first.IsZero() && !first.After(cutoff) {
+			log.Warn("skipping missed firings", "item", key, "since", first.Format(time.RFC3339), "catch_up", item.catchUpPolicy())
+		}
+		from = cutoff
+	}
+	var runs []time.Time
+	for at := from; ; {
+		next, err := item.Next(at, r.place())
+		if err != nil {
+			return nil, err
+		}
+		if next.IsZero() || next.After(now) {
+			break
+		}
+		runs = append(runs, next)
+		at = next
+	}
+	if item.CatchUp == "run_latest" && len(runs) > 1 {
+		log.Warn("skipping missed firings", "item", key, "since", runs[0].Format(time.RFC3339), "catch_up", item.CatchUp)
+		runs = runs[len(runs)-1:]
+	}
+	for _, at := range runs {
+		if late := now.Sub(at); late >= onTime {
+			log.Info("running missed firing", "item", key, "due", at.Format(time.RFC3339), "late", late.Round(time.Second))
+		}
+	}
+	return runs, nil
+}
+
+// fire performs one firing, or prints it in a dry run.
+func (r *Runner) fire(ctx context.Context, item ScheduleItem, at time.Time) error {
+	if r.DryRun {
+		fmt.Printf("DRY-RUN %s %s %s %s\n", at.Format(time.RFC3339), sideLabel(item.Side), item.Action, item.Temperature)
+		if item.Action == "ramp" {
+			r.printRamp(item, at)
+		}
+		return nil
+	}
+	if err := r.apply(ctx, item, at); err != nil {
+		return err
+	}
+	if r.Changed != nil {
+		r.Changed()
+	}
+	return nil
+}
+
+// apply performs an item due at the given time on each of its sides. Any
+// ramp running on those sides stops first.
+func (r *Runner) apply(ctx context.Context, item ScheduleItem, at time.Time) error {
+	sides, err := item.targets()
+	if err != nil {
+		return err
+	}
+	keys := r.rampKeys(ctx, sides)
+	r.cancelRamps(keys)
+	if item.Action == "ramp" {
+		return r.startRamp(ctx, item, at, sides, keys)
+	}
+	level := 0
+	if item.Action == "temp" {
+		if level, err = ParseTemp(item.Temperature); err != nil {
+			return err
+		}
+	}
+	for _, side := range sides {
+		if err := r.act(ctx, item.Action, side, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// act performs one action on a side; side 0 is the signed-in user's.
+func (r *Runner) act(ctx context.Context, action string, side model.Side, level int) error {
+	if side == 0 {
+		switch action {
 		case "on":
-			if err := r.Client.TurnOn(context.Background()); err != nil {
-				return err
-			}
+			return r.Client.TurnOn(ctx)
 		case "off":
-			if err := r.Client.TurnOff(context.Background()); err != nil {
-				return err
-			}
+			return r.Client.TurnOff(ctx)
 		case "temp":
-			level, err := ParseTemp(item.Temperature)
-			if err != nil {
-				return err
-			}
-			if err := r.Client.SetTemperature(context.Background(), level); err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("unknown action %s", item.Action)
+			return r.Client.SetTemperature(ctx, level)
 		}
+		return fmt.Errorf("unknown action %s", action)
+	}
+	if r.Pod == nil {
+		return fmt.Errorf("%s side: no pod to act on", side)
+	}
+	var err error
+	switch action {
+	case "on":
+		err = r.Pod.TurnOn(ctx, side)
+	case "off":
+		err = r.Pod.TurnOff(ctx, side)
+	case "temp":
+		err = r.Pod.SetTemperature(ctx, side, level)
+	default:
+		err = fmt.Errorf("unknown action %s", action)
+	}
+	if err != nil {
+		return fmt.Errorf("%s side: %w", side, err)
 	}
 	return nil
 }
 
+// sideLabel names the side an item acts on for logs.
+func sideLabel(side string) string {
+	if side == "" {
+		return "self"
+	}
+	return strings.ToLower(side)
+}
+
 func (r *Runner) writePID() error {
 	if r.PIDFile == "" {
 		return nil
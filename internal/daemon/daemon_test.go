@@ -0,0 +1,125 @@
+package daemon
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steipete/eightctl/internal/model"
+)
+
+// processAt runs r.process at each time in turn.
+func processAt(t *testing.T, r *Runner, times ...time.Time) {
+	t.Helper()
+	for _, now := range times {
+		if err := r.process(context.Background(), now); err != nil {
+			t.Fatalf("process at %v: %v", now, err)
+		}
+	}
+}
+
+func TestRunnerSetItemsReplacesSchedule(t *testing.T) {
+	pod := &fakePod{}
+	r := Runner{
+		Items:    []ScheduleItem{{Time: "08:00", Side: "left", Action: "on"}},
+		Pod:      pod,
+		Timezone: time.UTC,
+	}
+	processAt(t, &r, time.Date(2026, 1, 2, 7, 59, 30, 0, time.UTC))
+	r.SetItems([]ScheduleItem{{Time: "08:00", Side: "left", Action: "off"}})
+	processAt(t, &r, time.Date(2026, 1, 2, 8, 0, 30, 0, time.UTC))
+
+	if got, want := pod.recorded(), []podCall{{"off", model.Left, 0}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("pod calls = %v, want only the swapped-in item %v", got, want)
+	}
+}
+
+func TestRunnerSkipsItemsOnOtherDays(t *testing.T) {
+	pod := &fakePod{}
+	r := Runner{
+		Items: []ScheduleItem{
+			{Time: "08:00", Days: Days{"weekdays"}, Side: "left", Action: "on"},
+			{Cron: "0 8 * * sat", Side: "left", Action: "off"},
+		},
+		Pod:      pod,
+		Timezone: time.UTC,
+	}
+	// 2026-03-07 is a Saturday.
+	processAt(t, &r, time.Date(2026, 3, 7, 7, 59, 50, 0, time.UTC), time.Date(2026, 3, 7, 8, 0, 10, 0, time.UTC))
+	if got, want := pod.recorded(), []podCall{{"off", model.Left, 0}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("pod calls = %v, want only the Saturday cron item %v", got, want)
+	}
+}
+
+type podCall struct {
+	action string
+	side   model.Side
+	level  int
+}
+
+type fakePod struct {
+	mu     sync.Mutex
+	calls  []podCall
+	state  model.DeviceState
+	states int // GetState calls
+}
+
+func (p *fakePod) record(c podCall) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, c)
+}
+
+func (p *fakePod) recorded() []podCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]podCall(nil), p.calls...)
+}
+
+func (p *fakePod) GetState(ctx context.Context) (*model.DeviceState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.states++
+	return &p.state, nil
+}
+
+func (p *fakePod) SetTemperature(ctx context.Context, side model.Side, level int) error {
+	p.record(podCall{"temp", side, level})
+	return nil
+}
+
+func (p *fakePod) TurnOn(ctx context.Context, side model.Side) error {
+	p.record(podCall{"on", side, 0})
+	return nil
+}
+
+func (p *fakePod) TurnOff(ctx context.Context, side model.Side) error {
+	p.record(podCall{"off", side, 0})
+	return nil
+}
+
+func TestRunnerRoutesSidesThroughPod(t *testing.T) {
+	pod := &fakePod{}
+	r := Runner{
+		Items: []ScheduleItem{
+			{Time: "22:00", Side: "left", Action: "temp", Temperature: "-20"},
+			{Time: "22:00", Side: "right", Action: "temp", Temperature: "30"},
+			{Time: "22:00", Side: "both", Action: "on"},
+			{Time: "23:00", Side: "left", Action: "off"},
+		},
+		Pod:      pod,
+		Timezone: time.UTC,
+	}
+	processAt(t, &r, time.Date(2026, 1, 2, 21, 59, 0, 0, time.UTC), time.Date(2026, 1, 2, 22, 0, 20, 0, time.UTC))
+	want := []podCall{
+		{"temp", model.Left, -20},
+		{"temp", model.Right, 30},
+		{"on", model.Left, 0},
+		{"on", model.Right, 0},
+	}
+	if got := pod.recorded(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("pod calls = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,246 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/steipete/eightctl/internal/model"
+)
+
+// rampStep is one SetTemperature call of a ramp.
+type rampStep struct {
+	At    time.Time
+	Level int
+}
+
+// rampSteps plans a ramp from start: a step every step, the last at
+// start+over reaching to. Steps that would repeat a level are dropped. A
+// step outside (0, over] makes one jump at start+over; no over jumps at once.
+func rampSteps(from, to int, start time.Time, over, step time.Duration) []rampStep {
+	if over <= 0 {
+		return []rampStep{{At: start, Level: to}}
+	}
+	if step <= 0 || step > over {
+		step = over
+	}
+	steps := []rampStep{{At: start, Level: from}}
+	for elapsed := step; ; elapsed += step {
+		if elapsed > over {
+			elapsed = over
+		}
+		level := from + int(math.Round(float64(to-from)*float64(elapsed)/float64(over)))
+		if level != steps[len(steps)-1].Level {
+			steps = append(steps, rampStep{At: start.Add(elapsed), Level: level})
+		}
+		if elapsed == over {
+			return steps
+		}
+	}
+}
+
+// plan returns a ramp item's steps when it fires at start.
+func (it ScheduleItem) plan(start time.Time) ([]rampStep, error) {
+	from, err := ParseTemp(it.From)
+	if err != nil {
+		return nil, fmt.Errorf("from %q: %w", it.From, err)
+	}
+	to, err := ParseTemp(it.To)
+	if err != nil {
+		return nil, fmt.Errorf("to %q: %w", it.To, err)
+	}
+	if it.Over <= 0 {
+		return nil, fmt.Errorf("ramp needs a positive over, like 45m")
+	}
+	if it.Step <= 0 || it.Step > it.Over {
+		return nil, fmt.Errorf("ramp needs a step between 0 and over, like 5m")
+	}
+	return rampSteps(from, to, start, it.Over, it.Step), nil
+}
+
+// remainingSteps drops the steps of a late ramp that are already past,
+// keeping the latest of them so the ramp resumes from the level it should
+// be at now instead of replaying each missed step back to back.
+func remainingSteps(steps []rampStep, now time.Time) []rampStep {
+	i := 0
+	for i+1 < len(steps) && !steps[i+1].At.After(now) {
+		i++
+	}
+	return steps[i:]
+}
+
+// ramp is a ramp running on one side.
+type ramp struct {
+	cancel context.CancelFunc
+}
+
+// startRamp runs a ramp item from start in the background, one ramp per
+// side, tracked under keys (see rampKeys). The first step is due at once; a
+// ramp started late begins at the level it should have reached by now. A
+// failed step stops that side's ramp and is reported to Run.
+func (r *Runner) startRamp(ctx context.Context, item ScheduleItem, start time.Time, sides, keys []model.Side) error {
+	steps, err := item.plan(start)
+	if err != nil {
+		return err
+	}
+	steps = remainingSteps(steps, time.Now())
+	r.rampMu.Lock()
+	defer r.rampMu.Unlock()
+	if r.ramps == nil {
+		r.ramps = map[model.Side]*ramp{}
+	}
+	for i, side := range sides {
+		key := keys[i]
+		ctx, cancel := context.WithCancel(ctx)
+		rp := &ramp{cancel: cancel}
+		r.ramps[key] = rp
+		r.rampWG.Add(1)
+		go func() {
+			defer r.rampWG.Done()
+			defer r.endRamp(key, rp)
+			if err := r.runRamp(ctx, side, steps); err != nil && ctx.Err() == nil {
+				select {
+				case r.rampFailures() <- err:
+				default: // Run is already stopping on an earlier failure
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// runRamp makes each step's SetTemperature call when it is due.
+func (r *Runner) runRamp(ctx context.Context, side model.Side, steps []rampStep) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for _, s := range steps {
+		timer.Reset(time.Until(s.At))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+		if err := r.act(ctx, "temp", side, s.Level); err != nil {
+			return fmt.Errorf("ramp: %w", err)
+		}
+		if r.Changed != nil {
+			r.Changed()
+		}
+	}
+	return nil
+}
+
+// endRamp forgets a finished ramp unless another replaced it.
+func (r *Runner) endRamp(key model.Side, rp *ramp) {
+	rp.cancel()
+	r.rampMu.Lock()
+	defer r.rampMu.Unlock()
+	if r.ramps[key] == rp {
+		delete(r.ramps, key)
+	}
+}
+
+// cancelRamps stops the ramps running under keys.
+func (r *Runner) cancelRamps(keys []model.Side) {
+	r.rampMu.Lock()
+	defer r.rampMu.Unlock()
+	for _, key := range keys {
+		if rp := r.ramps[key]; rp != nil {
+			rp.cancel()
+			delete(r.ramps, key)
+		}
+	}
+}
+
+// rampKeys returns the physical side of the bed each of sides acts on, so
+// an action replaces a ramp on the same side whether either names it or
+// leaves it to the signed-in user's. Side 0 stays 0 when the user's side
+// cannot be told.
+func (r *Runner) rampKeys(ctx context.Context, sides []model.Side) []model.Side {
+	keys := make([]model.Side, len(sides))
+	for i, side := range sides {
+		if side == 0 {
+			side = r.selfSide(ctx)
+		}
+		keys[i] = side
+	}
+	return keys
+}
+
+// selfSide returns the side of the pod the signed-in user is assigned, or
+// 0 when it cannot be told or no item names a side, in which case every
+// ramp is keyed 0 anyway and the pod need not be looked up. A side once
+// found is kept for as long as the same user is signed in.
+func (r *Runner) selfSide(ctx context.Context) model.Side {
+	if r.Client == nil || r.Pod == nil || !r.namesSides() {
+		return 0
+	}
+	if err := r.Client.EnsureUserID(ctx); err != nil {
+		log.Debug("cannot resolve own side", "error", err)
+		return 0
+	}
+	uid := r.Client.CurrentUserID()
+	r.selfMu.Lock()
+	defer r.selfMu.Unlock()
+	if r.self != 0 && r.selfUser == uid {
+		return r.self
+	}
+	st, err := r.Pod.GetState(ctx)
+	if err != nil {
+		log.Debug("cannot resolve own side", "error", err)
+		return 0
+	}
+	for _, side := range []model.Side{model.Left, model.Right} {
+		if u := st.GetSide(side); u != nil && u.ID == uid {
+			r.self, r.selfUser = side, uid
+			return side
+		}
+	}
+	return 0
+}
+
+// namesSides reports whether any item in the schedule names a side.
+func (r *Runner) namesSides() bool {
+	for _, it := range r.schedule() {
+		if it.Side != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// stopRamps stops every ramp and waits for them to return.
+func (r *Runner) stopRamps() {
+	r.rampMu.Lock()
+	for side, rp := range r.ramps {
+		rp.cancel()
+		delete(r.ramps, side)
+	}
+	r.rampMu.Unlock()
+	r.rampWG.Wait()
+}
+
+// rampFailures returns the channel failed ramp steps are reported on.
+func (r *Runner) rampFailures() chan error {
+	r.rampMu.Lock()
+	defer r.rampMu.Unlock()
+	if r.rampFails == nil {
+		r.rampFails = make(chan error, 1)
+	}
+	return r.rampFails
+}
+
+// printRamp prints the steps a ramp item firing at start would take.
+func (r *Runner) printRamp(item ScheduleItem, start time.Time) {
+	steps, err := item.plan(start)
+	if err != nil {
+		fmt.Printf("DRY-RUN   %v\n", err)
+		return
+	}
+	for _, s := range steps {
+		fmt.Printf("DRY-RUN   %s %s temp %d\n", s.At.Format(time.RFC3339), sideLabel(item.Side), s.Level)
+	}
+}
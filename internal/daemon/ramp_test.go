@@ -0,0 +1,238 @@
+package daemon
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/model"
+)
+
+func TestRampSteps(t *testing.T) {
+	start := time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC)
+	type step struct {
+		after time.Duration
+		level int
+	}
+	tests := []struct {
+		name       string
+		from, to   int
+		over, step time.Duration
+		want       []step
+	}{
+		{"warm-up", -20, 10, 45 * time.Minute, 5 * time.Minute, []step{
+			{0, -20}, {5 * time.Minute, -17}, {10 * time.Minute, -13}, {15 * time.Minute, -10}, {20 * time.Minute, -7},
+			{25 * time.Minute, -3}, {30 * time.Minute, 0}, {35 * time.Minute, 3}, {40 * time.Minute, 7}, {45 * time.Minute, 10},
+		}},
+		{"last step short", 10, -10, 10 * time.Minute, 4 * time.Minute, []step{
+			{0, 10}, {4 * time.Minute, 2}, {8 * time.Minute, -6}, {10 * time.Minute, -10},
+		}},
+		{"repeated levels dropped", 0, 2, 10 * time.Minute, time.Minute, []step{
+			{0, 0}, {3 * time.Minute, 1}, {8 * time.Minute, 2},
+		}},
+		{"one step", 0, 30, time.Hour, time.Hour, []step{{0, 0}, {time.Hour, 30}}},
+		{"no step", 0, 30, time.Hour, 0, []step{{0, 0}, {time.Hour, 30}}},
+		{"no over", 0, 30, 0, time.Minute, []step{{0, 30}}},
+	}
+	for _, tt := range tests {
+		var got []step
+		for _, s := range rampSteps(tt.from, tt.to, start, tt.over, tt.step) {
+			got = append(got, step{s.At.Sub(start), s.Level})
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: steps = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseScheduleRamp(t *testing.T) {
+	items, err := ParseSchedule([]byte(`
+schedule:
+  - time: "06:00"
+    side: left
+    action: ramp
+    from: -20
+    to: 68F
+    over: 45m
+    step: 5m
+`))
+	if err != nil {
+		t.Fatalf("ParseSchedule: %v", err)
+	}
+	it := items[0]
+	if it.From != "-20" || it.To != "68F" || it.Over != 45*time.Minute || it.Step != 5*time.Minute {
+		t.Fatalf("item = %+v", it)
+	}
+}
+
+func TestRemainingSteps(t *testing.T) {
+	start := time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC)
+	steps := rampSteps(0, 40, start, 2*time.Hour, 30*time.Minute)
+	levels := func(steps []rampStep) []int {
+		var out []int
+		for _, s := range steps {
+			out = append(out, s.Level)
+		}
+		return out
+	}
+	tests := []struct {
+		now  time.Time
+		want []int
+	}{
+		{start, []int{0, 10, 20, 30, 40}},
+		{start.Add(45 * time.Minute), []int{10, 20, 30, 40}},
+		{start.Add(time.Hour), []int{20, 30, 40}},
+		{start.Add(3 * time.Hour), []int{40}},
+	}
+	for _, tt := range tests {
+		if got := levels(remainingSteps(steps, tt.now)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("at %s: levels = %v, want %v", tt.now.Sub(start), got, tt.want)
+		}
+	}
+}
+
+func TestPlanRejectsBadStep(t *testing.T) {
+	item := ScheduleItem{Action: "ramp", From: "0", To: "20", Over: time.Hour}
+	if _, err := item.plan(time.Now()); err == nil {
+		t.Fatal("expected an error for a ramp without a step")
+	}
+}
+
+func TestRunnerRampRunsToTheEnd(t *testing.T) {
+	pod := &fakePod{}
+	r := &Runner{Pod: pod, Timezone: time.UTC}
+	item := ScheduleItem{Side: "both", Action: "ramp", From: "0", To: "20", Over: 20 * time.Millisecond, Step: 10 * time.Millisecond}
+	if err := r.apply(context.Background(), item, time.Now()); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	r.rampWG.Wait()
+
+	want := map[model.Side][]int{model.Left: {0, 10, 20}, model.Right: {0, 10, 20}}
+	got := map[model.Side][]int{}
+	for _, c := range pod.recorded() {
+		got[c.side] = append(got[c.side], c.level)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("levels by side = %v, want %v", got, want)
+	}
+	if len(r.ramps) != 0 {
+		t.Fatalf("finished ramps still tracked: %v", r.ramps)
+	}
+}
+
+func TestRunnerLateRampStartsAtCurrentLevel(t *testing.T) {
+	pod := &fakePod{}
+	r := &Runner{Pod: pod, Timezone: time.UTC}
+	item := ScheduleItem{Side: "left", Action: "ramp", From: "0", To: "40", Over: 2 * time.Hour, Step: 30 * time.Minute}
+	if err := r.apply(context.Background(), item, time.Now().Add(-45*time.Minute)); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(pod.recorded()) < 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("late ramp never set a level")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	r.stopRamps()
+	if calls := pod.recorded(); !reflect.DeepEqual(calls, []podCall{{"temp", model.Left, 10}}) {
+		t.Fatalf("calls = %v, want one step to the current level 10", calls)
+	}
+}
+
+func TestRunnerOwnSideCancelsNamedSideRamp(t *testing.T) {
+	pod := &fakePod{state: model.DeviceState{
+		LeftUser:  &model.UserState{ID: "partner", Side: model.Left},
+		RightUser: &model.UserState{ID: "me", Side: model.Right},
+	}}
+	cl := client.New("email", "pass", "me", "", "")
+	ramp := ScheduleItem{Action: "ramp", From: "-20", To: "10", Over: time.Hour, Step: 30 * time.Minute}
+	off := ScheduleItem{Side: "right", Action: "off"}
+	r := &Runner{Items: []ScheduleItem{ramp, off}, Client: cl, Pod: pod, Timezone: time.UTC}
+	ctx := context.Background()
+	if err := r.startRamp(ctx, ramp, time.Now().Add(time.Hour), []model.Side{0}, r.rampKeys(ctx, []model.Side{0})); err != nil {
+		t.Fatalf("start ramp: %v", err)
+	}
+	r.rampMu.Lock()
+	_, right := r.ramps[model.Right]
+	r.rampMu.Unlock()
+	if !right {
+		t.Fatal("side-less ramp not tracked under the user's right side")
+	}
+
+	if err := r.apply(ctx, off, time.Now()); err != nil {
+		t.Fatalf("apply off: %v", err)
+	}
+	r.rampMu.Lock()
+	running := len(r.ramps)
+	r.rampMu.Unlock()
+	if running != 0 {
+		t.Fatalf("right off left %d ramp(s) running", running)
+	}
+	r.stopRamps()
+
+	if keys := r.rampKeys(ctx, []model.Side{0}); keys[0] != model.Right {
+		t.Fatalf("own side resolved to %v on the second lookup", keys[0])
+	}
+	pod.mu.Lock()
+	states := pod.states
+	pod.mu.Unlock()
+	if states != 1 {
+		t.Fatalf("own side looked up %d times, want once", states)
+	}
+}
+
+func TestRunnerSideLessScheduleSkipsPodLookup(t *testing.T) {
+	pod := &fakePod{}
+	ramp := ScheduleItem{Action: "ramp", From: "-20", To: "10", Over: time.Hour, Step: 30 * time.Minute}
+	r := &Runner{Items: []ScheduleItem{ramp}, Client: client.New("email", "pass", "me", "", ""), Pod: pod, Timezone: time.UTC}
+	ctx := context.Background()
+	if err := r.startRamp(ctx, ramp, time.Now().Add(time.Hour), []model.Side{0}, r.rampKeys(ctx, []model.Side{0})); err != nil {
+		t.Fatalf("start ramp: %v", err)
+	}
+	r.stopRamps()
+	if pod.states != 0 {
+		t.Fatalf("side-less schedule read pod state %d times", pod.states)
+	}
+}
+
+func TestRunnerActionCancelsRampOnSameSide(t *testing.T) {
+	pod := &fakePod{}
+	r := &Runner{Pod: pod, Timezone: time.UTC}
+	ctx := context.Background()
+	ramp := ScheduleItem{Side: "both", Action: "ramp", From: "-20", To: "10", Over: time.Hour, Step: 30 * time.Minute}
+	if err := r.apply(ctx, ramp, time.Now()); err != nil {
+		t.Fatalf("apply ramp: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(pod.recorded()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("first ramp steps never ran")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := r.apply(ctx, ScheduleItem{Side: "left", Action: "off"}, time.Now()); err != nil {
+		t.Fatalf("apply off: %v", err)
+	}
+	r.rampMu.Lock()
+	_, left := r.ramps[model.Left]
+	_, right := r.ramps[model.Right]
+	r.rampMu.Unlock()
+	if left || !right {
+		t.Fatalf("after left off: left ramp running %v, right ramp running %v; want only right", left, right)
+	}
+
+	r.stopRamps()
+	calls := pod.recorded()
+	if last := calls[len(calls)-1]; last != (podCall{"off", model.Left, 0}) {
+		t.Fatalf("last call = %v, want left off", last)
+	}
+	for _, c := range calls {
+		if c.action == "temp" && c.level != -20 {
+			t.Fatalf("ramp went past its first step: %v", calls)
+		}
+	}
+}
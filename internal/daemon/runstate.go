@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultMaxLateness bounds catch-up for items that set no max_lateness.
+const defaultMaxLateness = time.Hour
+
+// runState is the daemon state file.
+type runState struct {
+	// Handled maps item keys to the time up to which their firings are done.
+	Handled map[string]time.Time `json:"handled"`
+}
+
+// key identifies an item in the state file, so its history survives
+// reordering the schedule. Editing what or when an item runs starts a new
+// history; changing its catch-up policy does not.
+func (it ScheduleItem) key() string {
+	var parts []string
+	add := func(name, v string) {
+		if v != "" {
+			parts = append(parts, name+"="+v)
+		}
+	}
+	add("time", it.Time)
+	add("days", strings.Join(it.Days, ","))
+	add("cron", it.Cron)
+	add("side", it.Side)
+	add("action", it.Action)
+	add("temperature", it.Temperature)
+	add("from", it.From)
+	add("to", it.To)
+	if it.Over != 0 {
+		add("over", it.Over.String())
+	}
+	if it.Step != 0 {
+		add("step", it.Step.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// catchUpPolicy returns CatchUp with its default filled in.
+func (it ScheduleItem) catchUpPolicy() string {
+	if it.CatchUp == "" {
+		return "skip"
+	}
+	return it.CatchUp
+}
+
+// maxLateness returns MaxLateness with its default filled in.
+func (it ScheduleItem) maxLateness() time.Duration {
+	if it.MaxLateness == 0 {
+		return defaultMaxLateness
+	}
+	return it.MaxLateness
+}
+
+// loadState reads the state file, if any.
+func (r *Runner) loadState() error {
+	r.handled = map[string]time.Time{}
+	if r.StateFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(r.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read daemon state: %w", err)
+	}
+	var st runState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("read daemon state %s: %w (delete it to start over)", r.StateFile, err)
+	}
+	for k, t := range st.Handled {
+		r.handled[k] = t
+	}
+	return nil
+}
+
+// saveState writes the state file through a rename, so a crash never
+// leaves it half written. Dry runs never write it.
+func (r *Runner) saveState() error {
+	if r.StateFile == "" || r.DryRun {
+		return nil
+	}
+	data, err := json.MarshalIndent(runState{Handled: r.handled}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.StateFile), 0o755); err != nil {
+		return fmt.Errorf("write daemon state: %w", err)
+	}
+	tmp := r.StateFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("write daemon state: %w", err)
+	}
+	if err := os.Rename(tmp, r.StateFile); err != nil {
+		return fmt.Errorf("write daemon state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package daemon
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRunnerCatchUp(t *testing.T) {
+	hourly := ScheduleItem{Cron: "0 * * * *", Side: "left", Action: "on"}
+	withPolicy := func(policy string, lateness time.Duration) ScheduleItem {
+		it := hourly
+		it.CatchUp, it.MaxLateness = policy, lateness
+		return it
+	}
+	// Asleep from 07:30 to 10:30, missing 08:00, 09:00, and 10:00.
+	before := time.Date(2026, 1, 2, 7, 30, 0, 0, time.UTC)
+	after := time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		item ScheduleItem
+		runs int
+	}{
+		{"skip by default", hourly, 0},
+		{"skip", withPolicy("skip", 0), 0},
+		{"run latest", withPolicy("run_latest", 0), 1},
+		{"run all within the default lateness", withPolicy("run_all", 0), 1},
+		{"run all", withPolicy("run_all", 4*time.Hour), 3},
+		{"run all within max lateness", withPolicy("run_all", 2*time.Hour), 2},
+		{"run latest too late", withPolicy("run_latest", 20*time.Minute), 0},
+	}
+	for _, tt := range tests {
+		pod := &fakePod{}
+		r := &Runner{Items: []ScheduleItem{tt.item}, Pod: pod, Timezone: time.UTC}
+		processAt(t, r, before, after)
+		if got := len(pod.recorded()); got != tt.runs {
+			t.Errorf("%s: %d runs, want %d", tt.name, got, tt.runs)
+		}
+		// Nothing runs again at the next check.
+		processAt(t, r, after.Add(30*time.Second))
+		if got := len(pod.recorded()); got != tt.runs {
+			t.Errorf("%s: %d runs after a second check, want %d", tt.name, got, tt.runs)
+		}
+	}
+}
+
+func TestRunnerStatePersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "daemon-state.json")
+	items := []ScheduleItem{{Time: "08:00", Side: "left", Action: "on", CatchUp: "run_latest", MaxLateness: 2 * time.Hour}}
+	pod := &fakePod{}
+	start := func() *Runner {
+		r := &Runner{Items: items, Pod: pod, Timezone: time.UTC, StateFile: path}
+		if err := r.loadState(); err != nil {
+			t.Fatalf("loadState: %v", err)
+		}
+		return r
+	}
+
+	processAt(t, start(), time.Date(2026, 1, 2, 7, 59, 0, 0, time.UTC), time.Date(2026, 1, 2, 8, 0, 5, 0, time.UTC))
+	// A restart within the same minute must not run it again.
+	processAt(t, start(), time.Date(2026, 1, 2, 8, 0, 40, 0, time.UTC))
+	if got := len(pod.recorded()); got != 1 {
+		t.Fatalf("%d runs after a restart, want 1", got)
+	}
+	// Stopped overnight and restarted late: caught up once.
+	processAt(t, start(), time.Date(2026, 1, 3, 9, 15, 0, 0, time.UTC))
+	if got := len(pod.recorded()); got != 2 {
+		t.Fatalf("%d runs after a late restart, want 2", got)
+	}
+
+	// A dry run reads the state but never writes it.
+	dry := start()
+	dry.DryRun = true
+	processAt(t, dry, time.Date(2026, 1, 4, 8, 0, 5, 0, time.UTC))
+	r := start()
+	if got := r.handled[items[0].key()]; !got.Equal(time.Date(2026, 1, 3, 9, 15, 0, 0, time.UTC)) {
+		t.Fatalf("handled after a dry run = %v, want the last real check", got)
+	}
+}
+
+func TestRunnerFiresOnceAcrossDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	tests := []struct {
+		name       string
+		item       ScheduleItem
+		from, till time.Time
+		want       []string // local times of the checks that fired
+	}{
+		{"repeated 01:30 runs the first time", ScheduleItem{Time: "01:30"},
+			time.Date(2026, 11, 1, 0, 0, 0, 0, ny), time.Date(2026, 11, 1, 3, 0, 0, 0, ny),
+			[]string{"01:30 EDT"}},
+		{"skipped 02:30 runs an hour late", ScheduleItem{Time: "02:30"},
+			time.Date(2026, 3, 8, 0, 0, 0, 0, ny), time.Date(2026, 3, 8, 5, 0, 0, 0, ny),
+			[]string{"03:30 EDT"}},
+		{"half-hourly cron through fall-back", ScheduleItem{Cron: "*/30 * * * *"},
+			time.Date(2026, 11, 1, 0, 10, 0, 0, ny), time.Date(2026, 11, 1, 2, 40, 0, 0, ny),
+			[]string{"00:30 EDT", "01:00 EDT", "01:30 EDT", "02:00 EST", "02:30 EST"}},
+	}
+	for _, tt := range tests {
+		pod := &fakePod{}
+		tt.item.Side, tt.item.Action = "left", "on"
+		r := &Runner{Items: []ScheduleItem{tt.item}, Pod: pod, Timezone: ny}
+		var got []string
+		for now := tt.from; !now.After(tt.till); now = now.Add(time.Minute) {
+			n := len(pod.recorded())
+			processAt(t, r, now)
+			if len(pod.recorded()) > n {
+				got = append(got, now.In(ny).Format("15:04 MST"))
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: fired at %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRunnerWaitsUntilNextFiring(t *testing.T) {
+	r := &Runner{Items: []ScheduleItem{{Time: "08:00", Action: "on"}}, Timezone: time.UTC}
+	if got := r.wait(time.Date(2026, 1, 2, 7, 59, 20, 0, time.UTC)); got != 40*time.Second {
+		t.Errorf("wait before a firing = %v, want 40s", got)
+	}
+	if got := r.wait(time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)); got != onTime {
+		t.Errorf("wait with nothing due soon = %v, want %v", got, onTime)
+	}
+}
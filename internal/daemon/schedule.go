@@ -0,0 +1,379 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/steipete/eightctl/internal/model"
+)
+
+// ErrNoSchedule means the config has no schedule items.
+var ErrNoSchedule = errors.New("no schedule entries found")
+
+// ParseSchedule decodes and validates the schedule section of a config
+// file. Unknown item keys are errors, so a misspelled key cannot silently
+// drop a setting.
+func ParseSchedule(data []byte) ([]ScheduleItem, error) {
+	var raw struct {
+		Schedule yaml.Node `yaml:"schedule"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Schedule.Kind == 0 || len(raw.Schedule.Content) == 0 {
+		return nil, ErrNoSchedule
+	}
+	if raw.Schedule.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("schedule (line %d): must be a list", raw.Schedule.Line)
+	}
+	known := itemKeys()
+	var errs []error
+	for i, n := range raw.Schedule.Content {
+		if n.Kind != yaml.MappingNode {
+			continue
+		}
+		for j := 0; j < len(n.Content); j += 2 {
+			if k := n.Content[j]; !known[k.Value] {
+				errs = append(errs, fmt.Errorf("schedule[%d]: unknown key %q (line %d)", i, k.Value, k.Line))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	var items []ScheduleItem
+	if err := raw.Schedule.Decode(&items); err != nil {
+		return nil, fmt.Errorf("schedule: %w", err)
+	}
+	if err := ValidateSchedule(items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// itemKeys returns the YAML keys of ScheduleItem.
+func itemKeys() map[string]bool {
+	keys := map[string]bool{}
+	t := reflect.TypeOf(ScheduleItem{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		keys[name] = true
+	}
+	return keys
+}
+
+// Days lists the weekdays an item runs on: mon..sun, weekdays, or weekends.
+// YAML may give a list or one comma-separated string.
+type Days []string
+
+// UnmarshalYAML accepts "weekdays", "sat,sun", or [mon, wed, fri].
+func (d *Days) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*d = nil
+		for _, s := range strings.Split(n.Value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				*d = append(*d, s)
+			}
+		}
+		return nil
+	}
+	var list []string
+	if err := n.Decode(&list); err != nil {
+		return err
+	}
+	*d = list
+	return nil
+}
+
+// weekdays returns the set of days, or nil when the item runs every day.
+func (d Days) weekdays() (map[time.Weekday]bool, error) {
+	if len(d) == 0 {
+		return nil, nil
+	}
+	set := map[time.Weekday]bool{}
+	for _, name := range d {
+		switch name = strings.ToLower(name); name {
+		case "weekdays":
+			for wd := time.Monday; wd <= time.Friday; wd++ {
+				set[wd] = true
+			}
+		case "weekends":
+			set[time.Saturday], set[time.Sunday] = true, true
+		default:
+			wd, ok := dayNames[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown day %q (want mon..sun, weekdays, or weekends)", name)
+			}
+			set[time.Weekday(wd)] = true
+		}
+	}
+	return set, nil
+}
+
+// Place is where a schedule runs: the timezone its clock times are read in,
+// and the coordinates sunrise and sunset are computed for. Latitude and
+// Longitude are in degrees, north and east positive; both zero means unset.
+type Place struct {
+	Location  *time.Location
+	Latitude  float64
+	Longitude float64
+}
+
+// hasCoordinates reports whether the coordinates were set.
+func (p Place) hasCoordinates() bool {
+	return p.Latitude != 0 || p.Longitude != 0
+}
+
+// itemTime is a parsed item time: a wall clock time, or a sun event moved
+// by an offset.
+type itemTime struct {
+	hour, min int
+	sun       string // "sunrise" or "sunset"; "" for a clock time
+	offset    time.Duration
+}
+
+// maxSunOffset bounds the offset of a sun time, so it stays on its day.
+const maxSunOffset = 12 * time.Hour
+
+// parseItemTime parses "06:30", "sunrise", "sunrise-30m", or "sunset+1h".
+func parseItemTime(s string) (itemTime, error) {
+	bad := fmt.Errorf("time %q: want HH:MM, or sunrise or sunset with an optional offset like sunset+1h", s)
+	for _, event := range []string{"sunrise", "sunset"} {
+		rest, ok := strings.CutPrefix(strings.ToLower(s), event)
+		if !ok {
+			continue
+		}
+		it := itemTime{sun: event}
+		if rest == "" {
+			return it, nil
+		}
+		if rest[0] != '+' && rest[0] != '-' {
+			return itemTime{}, bad
+		}
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return itemTime{}, bad
+		}
+		if d%time.Minute != 0 || d < -maxSunOffset || d > maxSunOffset {
+			return itemTime{}, fmt.Errorf("time %q: offset must be whole minutes, at most 12h", s)
+		}
+		it.offset = d
+		return it, nil
+	}
+	clock, err := time.Parse("15:04", s)
+	if err != nil {
+		return itemTime{}, bad
+	}
+	return itemTime{hour: clock.Hour(), min: clock.Minute()}, nil
+}
+
+// sunSearchDays bounds the search for a sun time; polar night and midnight
+// sun last well under a year.
+const sunSearchDays = 370
+
+// targets returns the sides the item acts on; 0 stands for the signed-in
+// user's own side.
+func (it ScheduleItem) targets() ([]model.Side, error) {
+	switch strings.ToLower(it.Side) {
+	case "":
+		return []model.Side{0}, nil
+	case "both":
+		return []model.Side{model.Left, model.Right}, nil
+	}
+	side, err := model.ParseSide(it.Side)
+	if err != nil {
+		return nil, fmt.Errorf("unknown side %q (want left, right, or both)", it.Side)
+	}
+	return []model.Side{side}, nil
+}
+
+// Next returns the item's first firing strictly after t, in p's location,
+// or the zero time if it never fires.
+func (it ScheduleItem) Next(t time.Time, p Place) (time.Time, error) {
+	loc := p.Location
+	t = t.In(loc)
+	if it.Cron != "" {
+		c, err := ParseCron(it.Cron)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cron %q: %w", it.Cron, err)
+		}
+		return c.Next(t), nil
+	}
+	at, err := parseItemTime(it.Time)
+	if err != nil {
+		return time.Time{}, err
+	}
+	days, err := it.Days.weekdays()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("days: %w", err)
+	}
+	y, m, d := t.Date()
+	if at.sun != "" {
+		if !p.hasCoordinates() {
+			return time.Time{}, errNoCoordinates
+		}
+		// An offset can move a firing to the day before, so start there.
+		for i := -1; i <= sunSearchDays; i++ {
+			date := time.Date(y, m, d+i, 12, 0, 0, 0, loc)
+			if days != nil && !days[date.Weekday()] {
+				continue
+			}
+			event, ok := sunEvent(date.Year(), date.Month(), date.Day(), p.Latitude, p.Longitude, at.sun == "sunrise")
+			if !ok {
+				continue // the sun stays up or down all day
+			}
+			if cand := event.Add(at.offset).In(loc); cand.After(t) {
+				return cand, nil
+			}
+		}
+		return time.Time{}, nil
+	}
+	for i := 0; i <= 7; i++ {
+		cand := wallTime(y, m, d+i, at.hour, at.min, loc)
+		if days != nil && !days[cand.Weekday()] {
+			continue
+		}
+		if cand.After(t) {
+			return cand, nil
+		}
+	}
+	return time.Time{}, nil
+}
+
+var errNoCoordinates = errors.New("sunrise and sunset times need latitude and longitude in the config")
+
+// CheckPlace reports items that cannot run at p, i.e. sun times when p has
+// no coordinates.
+func CheckPlace(items []ScheduleItem, p Place) error {
+	if p.hasCoordinates() {
+		return nil
+	}
+	var errs []error
+	for i, it := range items {
+		if at, err := parseItemTime(it.Time); err == nil && at.sun != "" {
+			errs = append(errs, fmt.Errorf("schedule[%d]: time %q: %w", i, it.Time, errNoCoordinates))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Firing is one upcoming run of a schedule item.
+type Firing struct {
+	At    time.Time
+	Index int // position in the schedule
+	Item  ScheduleItem
+}
+
+// Upcoming returns the next n firings of items after t, in order.
+func Upcoming(items []ScheduleItem, t time.Time, p Place, n int) ([]Firing, error) {
+	next := make([]time.Time, len(items))
+	for i, it := range items {
+		at, err := it.Next(t, p)
+		if err != nil {
+			return nil, fmt.Errorf("schedule[%d]: %w", i, err)
+		}
+		next[i] = at
+	}
+	var out []Firing
+	for len(out) < n {
+		first := -1
+		for i, at := range next {
+			if !at.IsZero() && (first < 0 || at.Before(next[first])) {
+				first = i
+			}
+		}
+		if first < 0 {
+			break
+		}
+		out = append(out, Firing{At: next[first], Index: first, Item: items[first]})
+		// Errors were caught on the first pass.
+		next[first], _ = items[first].Next(next[first], p)
+	}
+	return out, nil
+}
+
+// Validate reports what is wrong with the item, if anything.
+func (it ScheduleItem) Validate() error {
+	switch {
+	case it.Cron != "" && it.Time != "":
+		return fmt.Errorf("set time or cron, not both")
+	case it.Cron != "" && len(it.Days) > 0:
+		return fmt.Errorf("days cannot be combined with cron; put them in the expression")
+	case it.Cron != "":
+		if _, err := ParseCron(it.Cron); err != nil {
+			return fmt.Errorf("cron %q: %w", it.Cron, err)
+		}
+	default:
+		if _, err := parseItemTime(it.Time); err != nil {
+			return err
+		}
+		if _, err := it.Days.weekdays(); err != nil {
+			return fmt.Errorf("days: %w", err)
+		}
+	}
+	if _, err := it.targets(); err != nil {
+		return err
+	}
+	switch it.CatchUp {
+	case "", "skip":
+		if it.MaxLateness != 0 {
+			return fmt.Errorf("max_lateness only applies to catch_up run_latest and run_all")
+		}
+	case "run_latest", "run_all":
+		if it.MaxLateness < 0 {
+			return fmt.Errorf("max_lateness must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown catch_up %q (want skip, run_latest, or run_all)", it.CatchUp)
+	}
+	isRamp := it.From != "" || it.To != "" || it.Over != 0 || it.Step != 0
+	if isRamp && it.Action != "ramp" {
+		return fmt.Errorf("from, to, over, and step only apply to the ramp action")
+	}
+	switch it.Action {
+	case "ramp":
+		for _, v := range []struct{ key, temp string }{{"from", it.From}, {"to", it.To}} {
+			level, err := ParseTemp(v.temp)
+			if err != nil {
+				return fmt.Errorf("%s %q: %w", v.key, v.temp, err)
+			}
+			if level < -100 || level > 100 {
+				return fmt.Errorf("%s %q: level must be between -100 and 100", v.key, v.temp)
+			}
+		}
+		switch {
+		case it.Over <= 0:
+			return fmt.Errorf("ramp needs a positive over, like 45m")
+		case it.Step <= 0 || it.Step > it.Over:
+			return fmt.Errorf("ramp needs a step between 0 and over, like 5m")
+		}
+	case "on", "off":
+	case "temp":
+		level, err := ParseTemp(it.Temperature)
+		if err != nil {
+			return fmt.Errorf("temperature %q: %w", it.Temperature, err)
+		}
+		if level < -100 || level > 100 {
+			return fmt.Errorf("temperature %q: level must be between -100 and 100", it.Temperature)
+		}
+	default:
+		return fmt.Errorf("unknown action %q (want on, off, temp, or ramp)", it.Action)
+	}
+	return nil
+}
+
+// ValidateSchedule checks every item and names bad ones by index.
+func ValidateSchedule(items []ScheduleItem) error {
+	var errs []error
+	for i, it := range items {
+		if err := it.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("schedule[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
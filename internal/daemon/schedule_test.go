@@ -0,0 +1,142 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	good := []ScheduleItem{
+		{Time: "22:00", Action: "on"},
+		{Time: "22:30", Action: "temp", Temperature: "68F"},
+		{Time: "07:00", Action: "off"},
+		{Time: "06:30", Days: Days{"weekdays"}, Action: "on"},
+		{Cron: "0 9 * * sat,sun", Action: "on"},
+		{Time: "sunrise-30m", Days: Days{"weekdays"}, Action: "temp", Temperature: "20"},
+		{Time: "sunset+1h", Action: "off"},
+		{Time: "Sunset", Action: "off"},
+		{Time: "21:30", Side: "left", Action: "temp", Temperature: "-10"},
+		{Time: "21:30", Side: "Both", Action: "on"},
+		{Time: "06:00", Action: "ramp", From: "-20", To: "10", Over: 45 * time.Minute, Step: 5 * time.Minute},
+		{Time: "06:00", Action: "on", CatchUp: "run_latest", MaxLateness: 2 * time.Hour},
+		{Cron: "0 * * * *", Action: "off", CatchUp: "run_all"},
+	}
+	if err := ValidateSchedule(good); err != nil {
+		t.Fatalf("valid schedule rejected: %v", err)
+	}
+
+	bad := []ScheduleItem{
+		{Time: "25:00", Action: "on"},
+		{Time: "22:00", Action: "warm"},
+		{Time: "22:00", Action: "temp", Temperature: "hot"},
+		{Time: "22:00", Action: "temp", Temperature: "150"},
+		{Time: "22:00", Days: Days{"someday"}, Action: "on"},
+		{Time: "22:00", Cron: "0 22 * * *", Action: "on"},
+		{Cron: "0 22 * *", Action: "on"},
+		{Cron: "0 22 * * *", Days: Days{"mon"}, Action: "on"},
+		{Time: "sunrise+13h", Action: "on"},
+		{Time: "sunset 1h", Action: "on"},
+		{Time: "noon", Action: "on"},
+		{Time: "22:00", Side: "middle", Action: "on"},
+		{Time: "06:00", Action: "ramp", From: "-20", To: "hot", Over: time.Hour, Step: time.Minute},
+		{Time: "06:00", Action: "ramp", From: "-20", To: "10", Step: time.Minute},
+		{Time: "06:00", Action: "ramp", From: "-20", To: "10", Over: time.Minute, Step: time.Hour},
+		{Time: "06:00", Action: "on", Over: time.Hour},
+		{Time: "06:00", Action: "on", CatchUp: "sometimes"},
+		{Time: "06:00", Action: "on", MaxLateness: time.Hour},
+	}
+	err := ValidateSchedule(bad)
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	for _, want := range []string{"schedule[0]: time", "schedule[1]: unknown action", "schedule[2]: temperature", "schedule[3]: temperature",
+		`schedule[4]: days: unknown day "someday"`, "schedule[5]: set time or cron", `schedule[6]: cron "0 22 * *"`, "schedule[7]: days cannot be combined",
+		`schedule[8]: time "sunrise+13h": offset`, `schedule[9]: time "sunset 1h": want HH:MM, or sunrise`, `schedule[10]: time "noon"`,
+		`schedule[11]: unknown side "middle" (want left, right, or both)`, `schedule[12]: to "hot"`,
+		"schedule[13]: ramp needs a positive over", "schedule[14]: ramp needs a step", "schedule[15]: from, to, over, and step only apply",
+		`schedule[16]: unknown catch_up "sometimes"`, "schedule[17]: max_lateness only applies"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestParseScheduleRejectsUnknownKeys(t *testing.T) {
+	data := []byte(`
+email: me@example.com
+schedule:
+  - time: "22:00"
+    action: on
+  - time: "07:00"
+    actoin: off
+`)
+	_, err := ParseSchedule(data)
+	if err == nil || !strings.Contains(err.Error(), `schedule[1]: unknown key "actoin" (line 7)`) {
+		t.Fatalf("ParseSchedule error = %v", err)
+	}
+
+	items, err := ParseSchedule([]byte("schedule:\n  - time: \"22:00\"\n    action: on\n"))
+	if err != nil || len(items) != 1 || items[0].Action != "on" {
+		t.Fatalf("ParseSchedule = %+v, %v", items, err)
+	}
+	if _, err := ParseSchedule([]byte("email: x\n")); err != ErrNoSchedule {
+		t.Fatalf("expected ErrNoSchedule, got %v", err)
+	}
+}
+
+func TestParseScheduleDays(t *testing.T) {
+	items, err := ParseSchedule([]byte(`
+schedule:
+  - time: "06:30"
+    days: weekdays
+    action: on
+  - time: "09:00"
+    days: [sat, sun]
+    action: on
+  - time: "22:00"
+    days: "Fri, sat"
+    action: off
+`))
+	if err != nil {
+		t.Fatalf("ParseSchedule: %v", err)
+	}
+	want := []Days{{"weekdays"}, {"sat", "sun"}, {"Fri", "sat"}}
+	for i, it := range items {
+		if strings.Join(it.Days, ",") != strings.Join(want[i], ",") {
+			t.Errorf("items[%d].Days = %v, want %v", i, it.Days, want[i])
+		}
+	}
+}
+
+func TestUpcoming(t *testing.T) {
+	items := []ScheduleItem{
+		{Time: "06:30", Days: Days{"weekdays"}, Action: "on"},
+		{Time: "09:00", Days: Days{"weekends"}, Action: "on"},
+		{Cron: "0 22 * * fri,sat", Action: "off"},
+	}
+	// 2026-03-06 is a Friday.
+	from := time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC)
+	got, err := Upcoming(items, from, Place{Location: time.UTC}, 5)
+	if err != nil {
+		t.Fatalf("Upcoming: %v", err)
+	}
+	want := []struct {
+		at    string
+		index int
+	}{
+		{"2026-03-06 22:00 Fri", 2},
+		{"2026-03-07 09:00 Sat", 1},
+		{"2026-03-07 22:00 Sat", 2},
+		{"2026-03-08 09:00 Sun", 1},
+		{"2026-03-09 06:30 Mon", 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d firings, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if at := got[i].At.Format("2006-01-02 15:04 Mon"); at != w.at || got[i].Index != w.index {
+			t.Errorf("firing %d = %s item %d, want %s item %d", i, at, got[i].Index, w.at, w.index)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"math"
+	"time"
+)
+
+// zenith is the sun's zenith angle at sunrise and sunset in degrees:
+// 90° plus atmospheric refraction and the sun's apparent radius.
+const zenith = 90.833
+
+// sunEvent returns the sunrise (rise true) or sunset on the given date at
+// lat/lon in degrees, north and east positive. It uses the equations of
+// NOAA's solar calculator (after Meeus), good to about a minute outside the
+// polar regions. ok is false when the sun does not rise or set that day.
+func sunEvent(y int, m time.Month, d int, lat, lon float64, rise bool) (t time.Time, ok bool) {
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	// Start from local solar noon, then refine at the event itself.
+	minutes := 720 - 4*lon
+	for range 2 {
+		at := midnight.Add(time.Duration(minutes * float64(time.Minute)))
+		decl, eqTime := solarPosition(at)
+		latR := rad(lat)
+		cosHA := math.Cos(rad(zenith))/(math.Cos(latR)*math.Cos(decl)) - math.Tan(latR)*math.Tan(decl)
+		if cosHA > 1 || cosHA < -1 {
+			return time.Time{}, false // polar night or midnight sun
+		}
+		ha := deg(math.Acos(cosHA))
+		if rise {
+			ha = -ha
+		}
+		minutes = 720 - 4*lon - eqTime + 4*ha
+	}
+	return midnight.Add(time.Duration(minutes * float64(time.Minute))).Round(time.Minute), true
+}
+
+// solarPosition returns the sun's declination in radians and the equation
+// of time in minutes at t.
+func solarPosition(t time.Time) (decl, eqTime float64) {
+	jd := float64(t.Unix())/86400 + 2440587.5
+	c := (jd - 2451545) / 36525 // Julian centuries since J2000
+
+	meanLong := math.Mod(280.46646+c*(36000.76983+c*0.0003032), 360)
+	meanAnom := 357.52911 + c*(35999.05029-0.0001537*c)
+	ecc := 0.016708634 - c*(0.000042037+0.0000001267*c)
+	center := math.Sin(rad(meanAnom))*(1.914602-c*(0.004817+0.000014*c)) +
+		math.Sin(rad(2*meanAnom))*(0.019993-0.000101*c) +
+		math.Sin(rad(3*meanAnom))*0.000289
+	omega := 125.04 - 1934.136*c
+	appLong := meanLong + center - 0.00569 - 0.00478*math.Sin(rad(omega))
+	obliq := 23 + (26+(21.448-c*(46.815+c*(0.00059-c*0.001813)))/60)/60 + 0.00256*math.Cos(rad(omega))
+
+	decl = math.Asin(math.Sin(rad(obliq)) * math.Sin(rad(appLong)))
+	yy := math.Pow(math.Tan(rad(obliq)/2), 2)
+	l0, m := rad(meanLong), rad(meanAnom)
+	eqTime = 4 * deg(yy*math.Sin(2*l0)-2*ecc*math.Sin(m)+4*ecc*yy*math.Sin(m)*math.Cos(2*l0)-
+		0.5*yy*yy*math.Sin(4*l0)-1.25*ecc*ecc*math.Sin(2*m))
+	return decl, eqTime
+}
+
+func rad(d float64) float64 { return d * math.Pi / 180 }
+func deg(r float64) float64 { return r * 180 / math.Pi }
@@ -0,0 +1,138 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSunEvent(t *testing.T) {
+	type place struct {
+		tz       string
+		lat, lon float64
+	}
+	var (
+		equator = place{"UTC", 0, 0}
+		newYork = place{"America/New_York", 40.7128, -74.006}
+		berlin  = place{"Europe/Berlin", 52.52, 13.405}
+		london  = place{"Europe/London", 51.5074, -0.1278}
+		sydney  = place{"Australia/Sydney", -33.8688, 151.2093}
+		reyk    = place{"Atlantic/Reykjavik", 64.1466, -21.9426}
+		tromso  = place{"Europe/Oslo", 69.6492, 18.9553}
+	)
+	tests := []struct {
+		name      string
+		at        place
+		date      string
+		rise, set string // local wall clock; "" when the sun does not rise or set
+	}{
+		{"equinox at the equator", equator, "2026-03-20", "06:04", "18:11"},
+		// Across a spring-forward change the events move about a minute
+		// while the clock jumps an hour.
+		{"new york before dst", newYork, "2026-03-07", "06:20", "17:54"},
+		{"new york dst starts", newYork, "2026-03-08", "07:19", "18:55"},
+		{"new york before dst ends", newYork, "2026-10-31", "07:25", "17:53"},
+		{"new york dst ends", newYork, "2026-11-01", "06:26", "16:52"},
+		{"berlin before dst", berlin, "2026-03-28", "05:50", "18:34"},
+		{"berlin dst starts", berlin, "2026-03-29", "06:48", "19:35"},
+		{"london midsummer", london, "2026-06-21", "04:43", "21:22"},
+		{"london midwinter", london, "2026-12-21", "08:04", "15:53"},
+		{"sydney before dst ends", sydney, "2026-04-04", "07:09", "18:47"},
+		{"sydney dst ends", sydney, "2026-04-05", "06:10", "17:45"},
+		{"sydney midwinter", sydney, "2026-06-21", "07:00", "16:54"},
+		// Sunset falls after midnight, on the next day.
+		{"reykjavik midsummer", reyk, "2026-06-21", "02:55", "00:04"},
+		{"tromso polar night", tromso, "2026-12-21", "", ""},
+		{"tromso midnight sun", tromso, "2026-06-21", "", ""},
+		{"tromso after polar night", tromso, "2026-02-01", "09:24", "14:33"},
+	}
+	for _, tt := range tests {
+		loc, err := time.LoadLocation(tt.at.tz)
+		if err != nil {
+			t.Skip("no tzdata")
+		}
+		date, _ := time.Parse("2006-01-02", tt.date)
+		for _, ev := range []struct {
+			rise bool
+			want string
+		}{{true, tt.rise}, {false, tt.set}} {
+			got, ok := sunEvent(date.Year(), date.Month(), date.Day(), tt.at.lat, tt.at.lon, ev.rise)
+			if ev.want == "" {
+				if ok {
+					t.Errorf("%s: rise=%v got %v, want no event", tt.name, ev.rise, got.In(loc))
+				}
+				continue
+			}
+			want, _ := time.Parse("15:04", ev.want)
+			local := got.In(loc)
+			if !ok || local.Hour() != want.Hour() || local.Minute() != want.Minute() {
+				t.Errorf("%s: rise=%v got %v (ok=%v), want %s", tt.name, ev.rise, local, ok, ev.want)
+			}
+		}
+	}
+}
+
+func TestScheduleItemNextSun(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	oslo, _ := time.LoadLocation("Europe/Oslo")
+	reyk, _ := time.LoadLocation("Atlantic/Reykjavik")
+	newYork := Place{Location: ny, Latitude: 40.7128, Longitude: -74.006}
+	tromso := Place{Location: oslo, Latitude: 69.6492, Longitude: 18.9553}
+	reykjavik := Place{Location: reyk, Latitude: 64.1466, Longitude: -21.9426}
+
+	tests := []struct {
+		name string
+		item ScheduleItem
+		at   Place
+		from time.Time
+		want time.Time
+	}{
+		{"offset before sunrise across dst", ScheduleItem{Time: "sunrise-30m"}, newYork,
+			time.Date(2026, 3, 7, 12, 0, 0, 0, ny), time.Date(2026, 3, 8, 6, 49, 0, 0, ny)},
+		{"offset after sunset", ScheduleItem{Time: "sunset+1h"}, newYork,
+			time.Date(2026, 11, 1, 12, 0, 0, 0, ny), time.Date(2026, 11, 1, 17, 52, 0, 0, ny)},
+		{"later today", ScheduleItem{Time: "Sunset"}, newYork,
+			time.Date(2026, 3, 8, 18, 0, 0, 0, ny), time.Date(2026, 3, 8, 18, 55, 0, 0, ny)},
+		// 2026-03-07 is a Saturday.
+		{"days filter", ScheduleItem{Time: "sunrise", Days: Days{"weekdays"}}, newYork,
+			time.Date(2026, 3, 7, 0, 0, 0, 0, ny), time.Date(2026, 3, 9, 7, 17, 0, 0, ny)},
+		{"skips polar night", ScheduleItem{Time: "sunrise"}, tromso,
+			time.Date(2026, 12, 1, 12, 0, 0, 0, oslo), time.Date(2027, 1, 15, 11, 35, 0, 0, oslo)},
+		{"skips midnight sun", ScheduleItem{Time: "sunset"}, tromso,
+			time.Date(2026, 5, 25, 12, 0, 0, 0, oslo), time.Date(2026, 7, 27, 0, 13, 0, 0, oslo)},
+		{"sunset past midnight", ScheduleItem{Time: "sunset+30m"}, reykjavik,
+			time.Date(2026, 6, 21, 12, 0, 0, 0, reyk), time.Date(2026, 6, 22, 0, 34, 0, 0, reyk)},
+	}
+	for _, tt := range tests {
+		got, err := tt.item.Next(tt.from, tt.at)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: Next = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := (ScheduleItem{Time: "sunrise"}).Next(time.Now(), Place{Location: ny}); err == nil {
+		t.Error("sun time without coordinates: expected an error")
+	}
+}
+
+func TestCheckPlace(t *testing.T) {
+	items := []ScheduleItem{
+		{Time: "22:00", Action: "on"},
+		{Time: "sunrise-30m", Action: "temp", Temperature: "20"},
+	}
+	if err := CheckPlace(items, Place{Location: time.UTC}); err == nil || err.Error() != `schedule[1]: time "sunrise-30m": `+errNoCoordinates.Error() {
+		t.Fatalf("CheckPlace without coordinates = %v", err)
+	}
+	if err := CheckPlace(items, Place{Location: time.UTC, Latitude: 52.5, Longitude: 13.4}); err != nil {
+		t.Fatalf("CheckPlace: %v", err)
+	}
+	if err := CheckPlace(items[:1], Place{Location: time.UTC}); err != nil {
+		t.Fatalf("CheckPlace without sun times: %v", err)
+	}
+}
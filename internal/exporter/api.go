@@ -0,0 +1,121 @@
+package exporter
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/steipete/eightctl/internal/client"
+)
+
+// DefaultBuckets are the latency histogram bounds in seconds.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// APIMetrics counts Eight Sleep API calls and their latency. Register
+// Middleware on the client whose traffic should be measured.
+type APIMetrics struct {
+	buckets []float64
+
+	mu       sync.Mutex
+	requests map[requestKey]float64
+	latency  map[string]*histogram // by host
+}
+
+type requestKey struct{ host, method, code string }
+
+type histogram struct {
+	counts []uint64 // cumulative per bucket
+	sum    float64
+	count  uint64
+}
+
+// NewAPIMetrics returns an empty collector using DefaultBuckets.
+func NewAPIMetrics() *APIMetrics {
+	return &APIMetrics{
+		buckets:  DefaultBuckets,
+		requests: map[requestKey]float64{},
+		latency:  map[string]*histogram{},
+	}
+}
+
+// Middleware observes every request that reaches the wire, so retries and
+// re-authentication count as separate calls.
+func (m *APIMetrics) Middleware() client.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return client.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			code := "error"
+			if err == nil {
+				code = strconv.Itoa(resp.StatusCode)
+			}
+			m.observe(req.URL.Host, req.Method, code, time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+func (m *APIMetrics) observe(host, method, code string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[requestKey{host, method, code}]++
+	h := m.latency[host]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latency[host] = h
+	}
+	secs := d.Seconds()
+	for i, le := range m.buckets {
+		if secs <= le {
+			h.counts[i]++
+		}
+	}
+	h.sum += secs
+	h.count++
+}
+
+// families renders the collected metrics in a stable order.
+func (m *APIMetrics) families() []*family {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requests := &family{name: "eightctl_api_requests_total", help: "Eight Sleep API requests by host, method, and status code.", typ: "counter"}
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.host != b.host {
+			return a.host < b.host
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.code < b.code
+	})
+	for _, k := range keys {
+		requests.add(m.requests[k], "host", k.host, "method", k.method, "code", k.code)
+	}
+
+	latency := &family{name: "eightctl_api_request_duration_seconds", help: "Eight Sleep API request latency by host.", typ: "histogram"}
+	hosts := make([]string, 0, len(m.latency))
+	for host := range m.latency {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		h := m.latency[host]
+		for i, le := range m.buckets {
+			latency.samples = append(latency.samples, sample{suffix: "_bucket", labels: []string{"host", host, "le", formatValue(le)}, value: float64(h.counts[i])})
+		}
+		latency.samples = append(latency.samples,
+			sample{suffix: "_bucket", labels: []string{"host", host, "le", "+Inf"}, value: float64(h.count)},
+			sample{suffix: "_sum", labels: []string{"host", host}, value: h.sum},
+			sample{suffix: "_count", labels: []string{"host", host}, value: float64(h.count)},
+		)
+	}
+	return []*family{requests, latency}
+}
@@ -0,0 +1,122 @@
+// Package exporter serves pod and sleep state as Prometheus metrics.
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/charmbracelet/log"
+
+	"github.com/steipete/eightctl/internal/model"
+	"github.com/steipete/eightctl/internal/state"
+)
+
+// ContentType is the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// scrapeTimeout bounds how long one scrape may wait on the API.
+const scrapeTimeout = 20 * time.Second
+
+// Exporter renders metrics for a set of pods. State comes from each pod's
+// state.Manager, so its cache TTL decides how often a scrape hits the API.
+type Exporter struct {
+	pods *state.Fleet
+	api  *APIMetrics
+}
+
+// New creates an exporter for the given pods. api may be nil to omit the
+// API call metrics.
+func New(managers []*state.Manager, api *APIMetrics) *Exporter {
+	return &Exporter{pods: state.NewFleet(managers...), api: api}
+}
+
+// Handler serves /metrics and a small landing page at /.
+func (e *Exporter) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), scrapeTimeout)
+		defer cancel()
+		var buf bytes.Buffer
+		if err := e.Write(ctx, &buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", ContentType)
+		_, _ = w.Write(buf.Bytes())
+	})
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `eightctl exporter: metrics at /metrics`)
+	})
+	return mux
+}
+
+// Write renders all metrics to w. A pod whose state cannot be fetched is
+// reported with eightsleep_up 0 rather than failing the scrape.
+func (e *Exporter) Write(ctx context.Context, w io.Writer) error {
+	var (
+		up           = &family{name: "eightsleep_up", help: "Whether the last state fetch for the pod succeeded.", typ: "gauge"}
+		info         = &family{name: "eightsleep_pod_info", help: "Pod metadata; always 1.", typ: "gauge"}
+		room         = &family{name: "eightsleep_room_temperature_celsius", help: "Room temperature reported by the pod.", typ: "gauge"}
+		water        = &family{name: "eightsleep_has_water", help: "Whether the pod reports water in the tank.", typ: "gauge"}
+		priming      = &family{name: "eightsleep_priming", help: "Whether the pod is priming.", typ: "gauge"}
+		needsPriming = &family{name: "eightsleep_needs_priming", help: "Whether the pod asks to be primed.", typ: "gauge"}
+		target       = &family{name: "eightsleep_target_level", help: "Target heating/cooling level, -100 to 100.", typ: "gauge"}
+		power        = &family{name: "eightsleep_power_state", help: "Power mode of a side; 1 for the current state.", typ: "gauge"}
+		bed          = &family{name: "eightsleep_bed_temperature_celsius", help: "Latest bed surface temperature.", typ: "gauge"}
+		heart        = &family{name: "eightsleep_heart_rate_bpm", help: "Latest heart rate.", typ: "gauge"}
+		hrv          = &family{name: "eightsleep_hrv_milliseconds", help: "Latest heart rate variability.", typ: "gauge"}
+		breath       = &family{name: "eightsleep_breath_rate_per_minute", help: "Latest breathing rate.", typ: "gauge"}
+		present      = &family{name: "eightsleep_present", help: "Whether someone is in bed on the side.", typ: "gauge"}
+		score        = &family{name: "eightsleep_sleep_score", help: "Sleep score of the most recent completed session.", typ: "gauge"}
+	)
+
+	for _, m := range e.pods.Managers() {
+		dev := m.DeviceID()
+		info.add(1, "device", dev, "name", m.Name())
+		st, err := m.GetState(ctx)
+		if err != nil {
+			log.Debug("exporter: state fetch failed", "device", dev, "error", err)
+			up.add(0, "device", dev)
+			continue
+		}
+		up.add(1, "device", dev)
+		room.add(st.RoomTemperature, "device", dev)
+		water.add(boolValue(st.HasWater), "device", dev)
+		priming.add(boolValue(st.IsPriming), "device", dev)
+		needsPriming.add(boolValue(st.NeedsPriming), "device", dev)
+
+		for _, side := range []model.Side{model.Left, model.Right} {
+			u := st.GetSide(side)
+			if u == nil {
+				continue
+			}
+			labels := []string{"device", dev, "side", side.String()}
+			target.add(float64(u.TargetLevel), labels...)
+			for _, ps := range []model.PowerState{model.PowerOff, model.PowerSmart, model.PowerManual} {
+				power.add(boolValue(u.State == ps), append(labels, "state", ps.String())...)
+			}
+			present.add(boolValue(u.IsPresent()), labels...)
+			// Sensor readings are absent until the pod has a sleep session.
+			for f, v := range map[*family]float64{bed: u.BedTemperature, heart: u.HeartRate, hrv: u.HRV, breath: u.BreathRate, score: u.SleepScore} {
+				if v != 0 {
+					f.add(v, labels...)
+				}
+			}
+		}
+	}
+
+	families := []*family{up, info, room, water, priming, needsPriming, target, power, bed, heart, hrv, breath, present, score}
+	if e.api != nil {
+		families = append(families, e.api.families()...)
+	}
+	for _, f := range families {
+		if err := f.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
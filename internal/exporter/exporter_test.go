@@ -0,0 +1,123 @@
+package exporter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/99designs/keyring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/mockapi"
+	"github.com/steipete/eightctl/internal/state"
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+func useTempKeyring(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Cleanup(tokencache.SetOpenKeyringForTest(func() (keyring.Keyring, error) {
+		return keyring.Open(keyring.Config{
+			ServiceName:      "eightctl-test",
+			AllowedBackends:  []keyring.BackendType{keyring.FileBackend},
+			FileDir:          filepath.Join(dir, "keyring"),
+			FilePasswordFunc: func(string) (string, error) { return "test-pass", nil },
+		})
+	}))
+}
+
+// newMockExporter serves a mock pod with the left side switched on.
+func newMockExporter(t *testing.T) *Exporter {
+	t.Helper()
+	useTempKeyring(t)
+
+	srv := httptest.NewServer(mockapi.New(mockapi.WithRoomTemperature(19.5)))
+	t.Cleanup(srv.Close)
+
+	api := NewAPIMetrics()
+	c := client.New(mockapi.DefaultLeftEmail, "pw", "", "", "")
+	c.BaseURL = mockapi.BaseURL(srv.URL)
+	c.AppAPIBaseURL = mockapi.BaseURL(srv.URL)
+	c.AuthURL = mockapi.AuthURL(srv.URL)
+	c.HTTP = srv.Client()
+	c.Use(api.Middleware())
+	require.NoError(t, c.TurnOn(context.Background()))
+
+	mgr := state.NewManager(c, mockapi.DefaultDeviceID, state.WithName("Bedroom"), state.WithVitals())
+	return New([]*state.Manager{mgr}, api)
+}
+
+func TestExporter_Metrics(t *testing.T) {
+	e := newMockExporter(t)
+
+	rec := httptest.NewRecorder()
+	e.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+
+	body := rec.Body.String()
+	for _, line := range []string{
+		`eightsleep_up{device="mock-device"} 1`,
+		`eightsleep_pod_info{device="mock-device",name="Bedroom"} 1`,
+		`eightsleep_room_temperature_celsius{device="mock-device"} 19.5`,
+		`eightsleep_has_water{device="mock-device"} 1`,
+		`eightsleep_power_state{device="mock-device",side="left",state="smart"} 1`,
+		`eightsleep_power_state{device="mock-device",side="right",state="off"} 1`,
+		`eightsleep_bed_temperature_celsius{device="mock-device",side="left"} 30`,
+		`eightsleep_present{device="mock-device",side="left"} 1`,
+		`eightsleep_present{device="mock-device",side="right"} 0`,
+		"# TYPE eightctl_api_request_duration_seconds histogram",
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	assert.Regexp(t, `eightsleep_heart_rate_bpm\{device="mock-device",side="left"\} \d+`, body)
+	assert.Regexp(t, `eightsleep_sleep_score\{device="mock-device",side="right"\} \d+`, body)
+	assert.NotContains(t, body, `eightsleep_heart_rate_bpm{device="mock-device",side="right"}`, "no live readings while a side is off")
+}
+
+func TestAPIMetrics_CountsAndHistogram(t *testing.T) {
+	m := NewAPIMetrics()
+	m.observe("client-api.8slp.net", "GET", "200", 80*time.Millisecond)
+	m.observe("client-api.8slp.net", "GET", "200", 3*time.Second)
+	m.observe("client-api.8slp.net", "PUT", "429", 10*time.Millisecond)
+
+	var b strings.Builder
+	for _, f := range m.families() {
+		require.NoError(t, f.write(&b))
+	}
+	out := b.String()
+	assert.Contains(t, out, `eightctl_api_requests_total{host="client-api.8slp.net",method="GET",code="200"} 2`)
+	assert.Contains(t, out, `eightctl_api_requests_total{host="client-api.8slp.net",method="PUT",code="429"} 1`)
+	assert.Contains(t, out, `eightctl_api_request_duration_seconds_bucket{host="client-api.8slp.net",le="0.05"} 1`)
+	assert.Contains(t, out, `eightctl_api_request_duration_seconds_bucket{host="client-api.8slp.net",le="0.1"} 2`)
+	assert.Contains(t, out, `eightctl_api_request_duration_seconds_bucket{host="client-api.8slp.net",le="2.5"} 2`)
+	assert.Contains(t, out, `eightctl_api_request_duration_seconds_bucket{host="client-api.8slp.net",le="+Inf"} 3`)
+	assert.Contains(t, out, `eightctl_api_request_duration_seconds_count{host="client-api.8slp.net"} 3`)
+}
+
+func TestExporter_DownPod(t *testing.T) {
+	useTempKeyring(t)
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	c := client.New("e", "p", "uid", "", "")
+	c.BaseURL = srv.URL
+	c.AuthURL = srv.URL + "/tokens"
+	c.HTTP = srv.Client()
+	c.Retry.MaxAttempts = 1
+
+	e := New([]*state.Manager{state.NewManager(c, "dev-x")}, nil)
+	var b strings.Builder
+	require.NoError(t, e.Write(context.Background(), &b))
+	assert.Contains(t, b.String(), `eightsleep_up{device="dev-x"} 0`)
+	assert.NotContains(t, b.String(), "eightsleep_target_level")
+}
+
+func TestFormatLabelsEscapes(t *testing.T) {
+	assert.Equal(t, `{name="a\"b\\c\nd"}`, formatLabels([]string{"name", "a\"b\\c\nd"}))
+}
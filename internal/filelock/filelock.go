@@ -0,0 +1,39 @@
+// Package filelock provides advisory, cross-process locks on open files.
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Locked is an open file holding an exclusive lock.
+type Locked struct {
+	*os.File
+}
+
+// Open opens (creating if needed) path and blocks until an exclusive lock is held.
+func Open(path string) (*Locked, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := lock(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("lock %s: %w", path, err)
+	}
+	return &Locked{File: f}, nil
+}
+
+// Close releases the lock and closes the file.
+func (l *Locked) Close() error {
+	unlockErr := unlock(l.File)
+	closeErr := l.File.Close()
+	if unlockErr != nil {
+		return unlockErr
+	}
+	return closeErr
+}
@@ -0,0 +1,864 @@
+// Package mockapi emulates the parts of the Eight Sleep cloud that eightctl
+// talks to, with in-memory state per side of one pod. Point a client's
+// BaseURL, AppAPIBaseURL, and AuthURL at a running Server to exercise the
+// CLI, daemon, and smart home adapters without a real account.
+package mockapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/model"
+)
+
+// Defaults for a freshly created Server.
+const (
+	DefaultDeviceID   = "mock-device"
+	DefaultLeftEmail  = "left@example.com"
+	DefaultRightEmail = "right@example.com"
+
+	tokenTTL = 24 * time.Hour
+)
+
+// BaseURL returns the client-api and app-api base URL for a server rooted
+// at root (for example an httptest.Server URL). Both APIs share one namespace.
+func BaseURL(root string) string { return strings.TrimSuffix(root, "/") + "/v1" }
+
+// AuthURL returns the OAuth token endpoint for a server rooted at root.
+func AuthURL(root string) string { return BaseURL(root) + "/tokens" }
+
+// User is the mutable state of one side of the pod.
+type User struct {
+	ID               string
+	Email            string
+	Side             model.Side
+	Level            int  // target level, -100..100
+	On               bool // currentState.type is "smart" when on, "off" otherwise
+	Away             bool
+	Nap              bool
+	NapUntil         time.Time
+	HotFlash         bool
+	HotFlashSettings map[string]any
+	Bedtime          client.BedtimeSchedule
+	Alarms           []client.Alarm
+	Schedules        []client.TemperatureSchedule
+}
+
+// Server is an http.Handler serving the mock API. It is safe for
+// concurrent use.
+type Server struct {
+	mu              sync.Mutex
+	deviceID        string
+	roomTemperature float64
+	waterLevel      int
+	priming         string
+	users           map[string]*User
+	left, right     string
+	tokens          map[string]string // bearer token -> user ID
+	refreshTokens   map[string]string // refresh token -> user ID
+	nextID          int
+	now             func() time.Time
+
+	mux *http.ServeMux
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithDeviceID sets the pod's device ID.
+func WithDeviceID(id string) Option {
+	return func(s *Server) {
+		s.deviceID = id
+	}
+}
+
+// WithRoomTemperature sets the room temperature reported by the device.
+func WithRoomTemperature(celsius float64) Option {
+	return func(s *Server) {
+		s.roomTemperature = celsius
+	}
+}
+
+// New creates a Server with one user on each side. Any password is
+// accepted; the email picks the side (unknown emails sign in as left).
+func New(opts ...Option) *Server {
+	s := &Server{
+		deviceID:        DefaultDeviceID,
+		roomTemperature: 21.5,
+		waterLevel:      100,
+		priming:         "idle",
+		users:           map[string]*User{},
+		left:            "mock-left",
+		right:           "mock-right",
+		tokens:          map[string]string{},
+		refreshTokens:   map[string]string{},
+		now:             time.Now,
+	}
+	s.users[s.left] = newUser(s.left, DefaultLeftEmail, model.Left)
+	s.users[s.right] = newUser(s.right, DefaultRightEmail, model.Right)
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mux = s.routes()
+	return s
+}
+
+func newUser(id, email string, side model.Side) *User {
+	u := &User{ID: id, Email: email, Side: side, HotFlashSettings: map[string]any{}}
+	u.Bedtime.Time.Start = "22:30"
+	u.Bedtime.Time.End = "07:00"
+	return u
+}
+
+// User returns a copy of the state for side.
+func (s *Server) User(side model.Side) User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.left
+	if side == model.Right {
+		id = s.right
+	}
+	u := *s.users[id]
+	u.Alarms = append([]client.Alarm(nil), u.Alarms...)
+	u.Schedules = append([]client.TemperatureSchedule(nil), u.Schedules...)
+	return u
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	handle := func(pattern string, h func(http.ResponseWriter, *http.Request)) {
+		mux.HandleFunc(pattern, s.authenticated(h))
+	}
+
+	mux.HandleFunc("POST /v1/tokens", s.handleToken)
+	mux.HandleFunc("POST /v1/login", s.handleLogin)
+
+	handle("GET /v1/users/me", s.handleMe)
+	handle("GET /v1/devices/{device}", s.handleDevice)
+
+	handle("GET /v1/users/{id}/temperature", s.handleGetTemperature)
+	handle("PUT /v1/users/{id}/temperature", s.handleSetTemperature)
+	handle("POST /v1/users/{id}/devices/power", s.handlePower)
+
+	handle("GET /v1/users/{id}/alarms", s.handleListAlarms)
+	handle("GET /v2/users/{id}/alarms", s.handleListAlarms)
+	handle("POST /v1/users/{id}/alarms", s.handleCreateAlarm)
+	handle("PUT /v1/users/{id}/alarms/{alarm}", s.handleUpdateAlarm)
+	handle("DELETE /v1/users/{id}/alarms/{alarm}", s.handleDeleteAlarm)
+	handle("PUT /v1/users/{id}/alarms/{alarm}/snooze", s.handleAlarmAction)
+	handle("PUT /v1/users/{id}/alarms/{alarm}/dismiss", s.handleAlarmAction)
+	handle("PUT /v1/users/{id}/alarms/active/dismiss-all", s.handleAlarmAction)
+	handle("POST /v1/users/{id}/vibration-test", s.handleAlarmAction)
+
+	handle("GET /v1/users/{id}/temperature/schedules", s.handleListSchedules)
+	handle("POST /v1/users/{id}/temperature/schedules", s.handleCreateSchedule)
+	handle("PATCH /v1/users/{id}/temperature/schedules/{schedule}", s.handleUpdateSchedule)
+	handle("DELETE /v1/users/{id}/temperature/schedules/{schedule}", s.handleDeleteSchedule)
+
+	handle("PUT /v1/users/{id}/bedtime", s.handleBedtime)
+
+	handle("GET /v1/users/{id}/away-mode", s.handleGetAway)
+	handle("PUT /v1/users/{id}/away-mode", s.handleSetAway)
+
+	handle("POST /v1/users/{id}/temperature/nap-mode/{action}", s.handleNap)
+	handle("GET /v1/users/{id}/temperature/nap-mode/status", s.handleNapStatus)
+	handle("GET /v1/users/{id}/temperature/nap-mode", s.handleNapStatus)
+	handle("PUT /v1/users/{id}/temperature/hot-flash-mode/{action}", s.handleHotFlash)
+	handle("GET /v1/users/{id}/temperature/hot-flash-mode", s.handleGetHotFlash)
+	handle("PUT /v1/users/{id}/temperature/hot-flash-mode", s.handleUpdateHotFlash)
+	handle("DELETE /v1/users/{id}/temperature/hot-flash-mode", s.handleDeleteHotFlash)
+
+	handle("GET /v1/users/{id}/trends", s.handleTrends)
+	handle("GET /v1/users/{id}/intervals", s.handleIntervals)
+
+	handle("GET /v3/users/{id}/subscriptions", s.handleSubscriptions)
+	return mux
+}
+
+// authenticated rejects requests without a bearer token issued by this server.
+func (s *Server) authenticated(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mu.Lock()
+		_, ok := s.tokens[token]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
+		h(w, r)
+	}
+}
+
+// issueToken signs in the user whose email matches, defaulting to left.
+func (s *Server) issueToken(email string) (token, userID string) {
+	userID = s.left
+	for id, u := range s.users {
+		if strings.EqualFold(u.Email, email) {
+			userID = id
+		}
+	}
+	token = "mock-" + randomHex()
+	s.tokens[token] = userID
+	return token, userID
+}
+
+func randomHex() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// handleToken serves the password and refresh_token grants. Every grant
+// rotates the refresh token, as the real auth endpoint does.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		GrantType    string `json:"grant_type"`
+		Username     string `json:"username"`
+		Password     string `json:"password"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var token, userID string
+	switch req.GrantType {
+	case "refresh_token":
+		id, ok := s.refreshTokens[req.RefreshToken]
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "invalid refresh token")
+			return
+		}
+		delete(s.refreshTokens, req.RefreshToken)
+		token, userID = s.issueToken(s.users[id].Email)
+	case "", "password":
+		if req.Username == "" || req.Password == "" {
+			writeError(w, http.StatusUnauthorized, "username and password required")
+			return
+		}
+		token, userID = s.issueToken(req.Username)
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported grant_type "+req.GrantType)
+		return
+	}
+	refresh := "mock-refresh-" + randomHex()
+	s.refreshTokens[refresh] = userID
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token":  token,
+		"refresh_token": refresh,
+		"token_type":    "Bearer",
+		"expires_in":    int(tokenTTL.Seconds()),
+		"userId":        userID,
+	})
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Email == "" || req.Password == "" {
+		writeError(w, http.StatusUnauthorized, "email and password required")
+		return
+	}
+	s.mu.Lock()
+	token, userID := s.issueToken(req.Email)
+	exp := s.now().Add(tokenTTL).UTC().Format(time.RFC3339)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"session": map[string]any{"userId": userID, "token": token, "expirationDate": exp},
+	})
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	u := s.users[s.tokens[token]]
+	writeJSON(w, http.StatusOK, map[string]any{
+		"user": map[string]any{
+			"userId":        u.ID,
+			"email":         u.Email,
+			"firstName":     "Mock",
+			"lastName":      u.Side.String(),
+			"devices":       []string{s.deviceID},
+			"currentDevice": map[string]any{"id": s.deviceID, "side": u.Side.String()},
+		},
+	})
+}
+
+func (s *Server) handleDevice(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.PathValue("device") != s.deviceID {
+		writeError(w, http.StatusNotFound, "device not found")
+		return
+	}
+	var away []string
+	for _, id := range []string{s.left, s.right} {
+		if u := s.users[id]; u.Away {
+			away = append(away, u.Side.String())
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"result": map[string]any{
+			"id":              s.deviceID,
+			"deviceId":        s.deviceID,
+			"leftUserId":      s.left,
+			"rightUserId":     s.right,
+			"awaySides":       away,
+			"roomTemperature": s.roomTemperature,
+			"waterLevel":      s.waterLevel,
+			"priming":         map[string]any{"status": s.priming},
+			"online":          true,
+			"timezone":        "UTC",
+		},
+	})
+}
+
+// user resolves the {id} path value. Callers must hold s.mu.
+func (s *Server) user(w http.ResponseWriter, r *http.Request) (*User, bool) {
+	u, ok := s.users[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "user not found")
+	}
+	return u, ok
+}
+
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-%d", prefix, s.nextID)
+}
+
+func (s *Server) handleGetTemperature(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	state := "off"
+	if u.On {
+		state = "smart"
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"currentLevel":       u.Level,
+		"currentDeviceLevel": u.Level,
+		"currentState":       map[string]any{"type": state},
+		"bedtime":            u.Bedtime,
+	})
+}
+
+func (s *Server) handleSetTemperature(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		CurrentLevel *int `json:"currentLevel"`
+		CurrentState *struct {
+			Type string `json:"type"`
+		} `json:"currentState"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	if req.CurrentLevel != nil {
+		if *req.CurrentLevel < -100 || *req.CurrentLevel > 100 {
+			writeError(w, http.StatusBadRequest, "currentLevel must be between -100 and 100")
+			return
+		}
+		u.Level = *req.CurrentLevel
+	}
+	if req.CurrentState != nil {
+		u.On = req.CurrentState.Type != "off"
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) handlePower(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		On bool `json:"on"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	u.On = req.On
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) handleListAlarms(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	alarms := u.Alarms
+	if alarms == nil {
+		alarms = []client.Alarm{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"alarms": alarms})
+}
+
+func (s *Server) handleCreateAlarm(w http.ResponseWriter, r *http.Request) {
+	var alarm client.Alarm
+	if !readJSON(w, r, &alarm) {
+		return
+	}
+	if _, err := time.Parse("15:04:05", alarm.Time); err != nil {
+		writeError(w, http.StatusBadRequest, "time must be HH:MM:SS")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	alarm.ID = s.newID("alarm")
+	u.Alarms = append(u.Alarms, alarm)
+	writeJSON(w, http.StatusOK, map[string]any{"alarm": alarm})
+}
+
+func (s *Server) handleUpdateAlarm(w http.ResponseWriter, r *http.Request) {
+	var patch map[string]any
+	if !readJSON(w, r, &patch) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	for i := range u.Alarms {
+		if u.Alarms[i].ID != r.PathValue("alarm") {
+			continue
+		}
+		if err := mergePatch(&u.Alarms[i], patch); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		u.Alarms[i].ID = r.PathValue("alarm")
+		writeJSON(w, http.StatusOK, map[string]any{"alarm": u.Alarms[i]})
+		return
+	}
+	writeError(w, http.StatusNotFound, "alarm not found")
+}
+
+func (s *Server) handleDeleteAlarm(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	for i := range u.Alarms {
+		if u.Alarms[i].ID == r.PathValue("alarm") {
+			u.Alarms = append(u.Alarms[:i], u.Alarms[i+1:]...)
+			writeJSON(w, http.StatusOK, map[string]any{})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "alarm not found")
+}
+
+// handleAlarmAction acknowledges snooze, dismiss, and vibration tests,
+// which have no lasting effect on mock state.
+func (s *Server) handleAlarmAction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.user(w, r); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) handleListSchedules(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	schedules := u.Schedules
+	if schedules == nil {
+		schedules = []client.TemperatureSchedule{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"schedules": schedules})
+}
+
+func (s *Server) handleCreateSchedule(w http.ResponseWriter, r *http.Request) {
+	var sched client.TemperatureSchedule
+	if !readJSON(w, r, &sched) {
+		return
+	}
+	if sched.Level < -100 || sched.Level > 100 {
+		writeError(w, http.StatusBadRequest, "level must be between -100 and 100")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	sched.ID = s.newID("schedule")
+	u.Schedules = append(u.Schedules, sched)
+	writeJSON(w, http.StatusOK, map[string]any{"schedule": sched})
+}
+
+func (s *Server) handleUpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	var patch map[string]any
+	if !readJSON(w, r, &patch) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	for i := range u.Schedules {
+		if u.Schedules[i].ID != r.PathValue("schedule") {
+			continue
+		}
+		if err := mergePatch(&u.Schedules[i], patch); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		u.Schedules[i].ID = r.PathValue("schedule")
+		writeJSON(w, http.StatusOK, map[string]any{"schedule": u.Schedules[i]})
+		return
+	}
+	writeError(w, http.StatusNotFound, "schedule not found")
+}
+
+func (s *Server) handleDeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	for i := range u.Schedules {
+		if u.Schedules[i].ID == r.PathValue("schedule") {
+			u.Schedules = append(u.Schedules[:i], u.Schedules[i+1:]...)
+			writeJSON(w, http.StatusOK, map[string]any{})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "schedule not found")
+}
+
+func (s *Server) handleBedtime(w http.ResponseWriter, r *http.Request) {
+	var patch map[string]any
+	if !readJSON(w, r, &patch) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	if err := mergePatch(&u.Bedtime, patch); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"bedtime": u.Bedtime})
+}
+
+func (s *Server) handleGetAway(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, client.AwayModeStatus{Enabled: u.Away})
+}
+
+// handleSubscriptions reports a mock account with no paid plans.
+func (s *Server) handleSubscriptions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.user(w, r); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"subscriptions": []any{}})
+}
+
+func (s *Server) handleSetAway(w http.ResponseWriter, r *http.Request) {
+	var req client.AwayModeStatus
+	if !readJSON(w, r, &req) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	u.Away = req.Enabled
+	writeJSON(w, http.StatusOK, req)
+}
+
+// napLength is how long nap mode runs per activation or extension.
+const napLength = 30 * time.Minute
+
+func (s *Server) handleNap(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	now := s.now()
+	switch r.PathValue("action") {
+	case "activate":
+		u.Nap, u.NapUntil = true, now.Add(napLength)
+	case "deactivate":
+		u.Nap, u.NapUntil = false, time.Time{}
+	case "extend":
+		if !u.Nap || now.After(u.NapUntil) {
+			writeError(w, http.StatusConflict, "nap mode is not active")
+			return
+		}
+		u.NapUntil = u.NapUntil.Add(napLength)
+	default:
+		writeError(w, http.StatusNotFound, "unknown nap action")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) handleNapStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	active := u.Nap && s.now().Before(u.NapUntil)
+	res := map[string]any{"active": active, "durationSeconds": int(napLength.Seconds())}
+	if active {
+		res["endTime"] = u.NapUntil.UTC().Format(time.RFC3339)
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleHotFlash(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	switch r.PathValue("action") {
+	case "activate":
+		u.HotFlash = true
+	case "deactivate":
+		u.HotFlash = false
+	default:
+		writeError(w, http.StatusNotFound, "unknown hot flash action")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (s *Server) handleGetHotFlash(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	res := map[string]any{}
+	for k, v := range u.HotFlashSettings {
+		res[k] = v
+	}
+	res["active"] = u.HotFlash
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleUpdateHotFlash(w http.ResponseWriter, r *http.Request) {
+	var patch map[string]any
+	if !readJSON(w, r, &patch) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	for k, v := range patch {
+		u.HotFlashSettings[k] = v
+	}
+	writeJSON(w, http.StatusOK, u.HotFlashSettings)
+}
+
+func (s *Server) handleDeleteHotFlash(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.user(w, r)
+	if !ok {
+		return
+	}
+	u.HotFlash = false
+	u.HotFlashSettings = map[string]any{}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+// maxTrendDays bounds the trends range so a typo cannot build a huge response.
+const maxTrendDays = 366
+
+// handleTrends returns deterministic synthetic sleep days for the range.
+func (s *Server) handleTrends(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	_, ok := s.user(w, r)
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	from, err := time.Parse("2006-01-02", q.Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "from must be YYYY-MM-DD")
+		return
+	}
+	to, err := time.Parse("2006-01-02", q.Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "to must be YYYY-MM-DD")
+		return
+	}
+	days := []client.SleepDay{}
+	for d := from; !d.After(to) && len(days) < maxTrendDays; d = d.AddDate(0, 0, 1) {
+		days = append(days, syntheticDay(r.PathValue("id"), d))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"days": days})
+}
+
+func syntheticDay(userID string, d time.Time) client.SleepDay {
+	seed := d.YearDay() + len(userID)
+	day := client.SleepDay{
+		Date:          d.Format("2006-01-02"),
+		Score:         float64(70 + seed*7%25),
+		Tnt:           seed % 12,
+		Respiratory:   14 + float64(seed%4),
+		HeartRate:     55 + float64(seed%10),
+		LatencyAsleep: float64(300 + seed%8*60),
+		LatencyOut:    float64(120 + seed%5*60),
+		Duration:      float64(7*3600 + seed%90*60),
+		Stages: []client.Stage{
+			{Stage: "awake", Duration: 1800},
+			{Stage: "light", Duration: 12600},
+			{Stage: "deep", Duration: 5400},
+			{Stage: "rem", Duration: 6300},
+		},
+	}
+	day.SleepQuality.HRV.Score = float64(60 + seed%30)
+	day.SleepQuality.Resp.Score = float64(65 + seed%25)
+	return day
+}
+
+// handleIntervals returns last night's completed session and, while the side
+// is on, an in-progress session with a few minutes of sensor readings.
+func (s *Server) handleIntervals(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	u, ok := s.user(w, r)
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	on, now := u.On, s.now().UTC()
+	s.mu.Unlock()
+
+	yesterday := syntheticDay(u.ID, now.AddDate(0, 0, -1))
+	intervals := []map[string]any{}
+	if on {
+		series := func(base float64) [][]any {
+			var out [][]any
+			for i := 2; i >= 0; i-- {
+				ts := now.Add(-time.Duration(i) * time.Minute).Format(time.RFC3339)
+				out = append(out, []any{ts, base + float64(i)})
+			}
+			return out
+		}
+		intervals = append(intervals, map[string]any{
+			"id":         s.deviceID + "-" + u.ID + "-current",
+			"ts":         now.Add(-time.Hour).Format(time.RFC3339),
+			"incomplete": true,
+			"stages":     []client.Stage{{Stage: "light", Duration: 3600}},
+			"timeseries": map[string]any{
+				"heartRate":       series(yesterday.HeartRate),
+				"hrv":             series(yesterday.SleepQuality.HRV.Score),
+				"respiratoryRate": series(yesterday.Respiratory),
+				"tempBedC":        series(30),
+			},
+		})
+	}
+	intervals = append(intervals, map[string]any{
+		"id":         s.deviceID + "-" + u.ID + "-" + yesterday.Date,
+		"ts":         yesterday.Date + "T23:00:00Z",
+		"score":      yesterday.Score,
+		"incomplete": false,
+		"stages":     yesterday.Stages,
+	})
+	writeJSON(w, http.StatusOK, map[string]any{"intervals": intervals})
+}
+
+// mergePatch overlays a JSON object onto the struct pointed to by dst.
+func mergePatch(dst any, patch map[string]any) error {
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, dst)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError mirrors the {"code","message"} shape of real API errors.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]any{"code": status, "message": msg})
+}
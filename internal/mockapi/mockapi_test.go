@@ -0,0 +1,276 @@
+package mockapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/keyring"
+
+	"github.com/steipete/eightctl/internal/client"
+	"github.com/steipete/eightctl/internal/model"
+	"github.com/steipete/eightctl/internal/state"
+	"github.com/steipete/eightctl/internal/tokencache"
+)
+
+// newTestClient starts a mock server and returns a client signed in as email.
+func newTestClient(t *testing.T, email string) (*Server, *client.Client) {
+	t.Helper()
+	dir := t.TempDir()
+	restore := tokencache.SetOpenKeyringForTest(func() (keyring.Keyring, error) {
+		return keyring.Open(keyring.Config{
+			ServiceName:      "eightctl-test",
+			AllowedBackends:  []keyring.BackendType{keyring.FileBackend},
+			FileDir:          filepath.Join(dir, "keyring"),
+			FilePasswordFunc: func(string) (string, error) { return "test-pass", nil },
+		})
+	})
+	t.Cleanup(restore)
+
+	mock := New()
+	srv := httptest.NewServer(mock)
+	t.Cleanup(srv.Close)
+
+	c := client.New(email, "any-password", "", "", "")
+	c.BaseURL = BaseURL(srv.URL)
+	c.AppAPIBaseURL = BaseURL(srv.URL)
+	c.AuthURL = AuthURL(srv.URL)
+	c.HTTP = srv.Client()
+	return mock, c
+}
+
+func TestAuthAndIdentity(t *testing.T) {
+	_, c := newTestClient(t, DefaultRightEmail)
+	ctx := context.Background()
+
+	dev, err := c.EnsureDeviceID(ctx)
+	if err != nil {
+		t.Fatalf("ensure device: %v", err)
+	}
+	if dev != DefaultDeviceID {
+		t.Errorf("expected device %s, got %s", DefaultDeviceID, dev)
+	}
+	if c.UserID != "mock-right" {
+		t.Errorf("expected right-side user from token, got %q", c.UserID)
+	}
+}
+
+func TestUnauthenticatedRequestRejected(t *testing.T) {
+	srv := httptest.NewServer(New())
+	defer srv.Close()
+
+	resp, err := http.Get(BaseURL(srv.URL) + "/users/me")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestRefreshUsesRefreshGrant(t *testing.T) {
+	mock, c := newTestClient(t, DefaultLeftEmail)
+	ctx := context.Background()
+	if err := c.Authenticate(ctx); err != nil {
+		t.Fatalf("authenticate: %v", err)
+	}
+	mock.mu.Lock()
+	tokens := len(mock.tokens)
+	mock.mu.Unlock()
+
+	// Without a password a fallback sign-in would be rejected, so success
+	// means the refresh_token grant was used.
+	c.Password = ""
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	mock.mu.Lock()
+	issued := len(mock.tokens) - tokens
+	mock.mu.Unlock()
+	if issued != 1 {
+		t.Fatalf("expected refresh to issue one access token, got %d", issued)
+	}
+	if _, err := c.GetStatus(ctx); err != nil {
+		t.Fatalf("request with refreshed token: %v", err)
+	}
+	// The refresh token rotates; the second refresh must use the new one.
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("second refresh: %v", err)
+	}
+}
+
+func TestTemperatureAndPower(t *testing.T) {
+	mock, c := newTestClient(t, DefaultLeftEmail)
+	ctx := context.Background()
+
+	if err := c.SetTemperature(ctx, -30); err != nil {
+		t.Fatalf("set temperature: %v", err)
+	}
+	if err := c.TurnOn(ctx); err != nil {
+		t.Fatalf("turn on: %v", err)
+	}
+	st, err := c.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if st.CurrentLevel != -30 || st.CurrentState.Type != "smart" {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	if right := mock.User(model.Right); right.On || right.Level != 0 {
+		t.Errorf("right side should be untouched, got %+v", right)
+	}
+}
+
+func TestInvalidLevelRejected(t *testing.T) {
+	_, c := newTestClient(t, DefaultLeftEmail)
+	ctx := context.Background()
+	if err := c.EnsureUserID(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.CreateSchedule(ctx, client.TemperatureSchedule{StartTime: "22:00", Level: 300})
+	if !errors.Is(err, client.ErrInvalidRequest) {
+		t.Fatalf("expected invalid request, got %v", err)
+	}
+}
+
+func TestAlarmsCRUD(t *testing.T) {
+	mock, c := newTestClient(t, DefaultLeftEmail)
+	ctx := context.Background()
+
+	created, err := c.CreateAlarm(ctx, client.Alarm{Enabled: true, Time: "07:00:00"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("expected alarm id")
+	}
+	updated, err := c.UpdateAlarm(ctx, created.ID, map[string]any{"time": "06:45:00"})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.Time != "06:45:00" || !updated.Enabled {
+		t.Fatalf("patch not applied: %+v", updated)
+	}
+	alarms, err := c.ListAlarms(ctx)
+	if err != nil || len(alarms) != 1 {
+		t.Fatalf("list: %v %v", alarms, err)
+	}
+	if err := c.Alarms().Snooze(ctx, created.ID); err != nil {
+		t.Fatalf("snooze: %v", err)
+	}
+	if err := c.DeleteAlarm(ctx, created.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if n := len(mock.User(model.Left).Alarms); n != 0 {
+		t.Fatalf("expected no alarms, got %d", n)
+	}
+	if err := c.DeleteAlarm(ctx, created.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestBedtimeAwayAndModes(t *testing.T) {
+	mock, c := newTestClient(t, DefaultLeftEmail)
+	ctx := context.Background()
+
+	if err := c.Bedtime().Enable(ctx); err != nil {
+		t.Fatalf("bedtime enable: %v", err)
+	}
+	bt, err := c.Bedtime().Get(ctx)
+	if err != nil || !bt.Enabled || bt.Time.Start != "22:30" {
+		t.Fatalf("bedtime get: %+v %v", bt, err)
+	}
+	if err := c.AwayMode().Enable(ctx); err != nil {
+		t.Fatalf("away: %v", err)
+	}
+	away, err := c.AwayMode().Get(ctx)
+	if err != nil || !away.Enabled {
+		t.Fatalf("away get: %+v %v", away, err)
+	}
+	if err := c.TempModes().NapActivate(ctx); err != nil {
+		t.Fatalf("nap: %v", err)
+	}
+	var nap struct {
+		Active bool `json:"active"`
+	}
+	if err := c.TempModes().NapStatus(ctx, &nap); err != nil || !nap.Active {
+		t.Fatalf("nap status: %+v %v", nap, err)
+	}
+	if err := c.TempModes().HotFlashActivate(ctx); err != nil {
+		t.Fatalf("hot flash: %v", err)
+	}
+	if u := mock.User(model.Left); !u.HotFlash || !u.Nap || !u.Away {
+		t.Fatalf("modes not recorded: %+v", u)
+	}
+}
+
+func TestTrends(t *testing.T) {
+	_, c := newTestClient(t, DefaultLeftEmail)
+	day, err := c.GetSleepDay(context.Background(), "2025-03-09", "UTC")
+	if err != nil {
+		t.Fatalf("sleep day: %v", err)
+	}
+	if day.Date != "2025-03-09" || day.Score < 70 || day.Score > 95 {
+		t.Fatalf("unexpected day %+v", day)
+	}
+}
+
+func TestStateManagerAgainstMock(t *testing.T) {
+	mock, c := newTestClient(t, DefaultLeftEmail)
+	ctx := context.Background()
+
+	dev, err := c.EnsureDeviceID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mgr := state.NewManager(c, dev, state.WithCacheTTL(time.Minute))
+	if err := mgr.TurnOn(ctx, model.Right); err != nil {
+		t.Fatalf("turn on right: %v", err)
+	}
+	if err := mgr.SetTemperature(ctx, model.Right, 40); err != nil {
+		t.Fatalf("set right: %v", err)
+	}
+	st, err := mgr.GetState(ctx)
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	if st.RightUser == nil || st.RightUser.TargetLevel != 40 || st.RightUser.State != model.PowerSmart {
+		t.Fatalf("unexpected right side %+v", st.RightUser)
+	}
+	if st.LeftUser == nil || st.LeftUser.State != model.PowerOff {
+		t.Fatalf("unexpected left side %+v", st.LeftUser)
+	}
+	if !mock.User(model.Right).On {
+		t.Fatal("mock did not record power on")
+	}
+}
+
+// TestConcurrentManager mimics the MQTT adapter: command handlers and the
+// poll loop share one client from many goroutines. Run with -race.
+func TestConcurrentManager(t *testing.T) {
+	_, c := newTestClient(t, DefaultLeftEmail)
+	mgr := state.NewManager(c, DefaultDeviceID, state.WithCacheTTL(time.Millisecond))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 60)
+	for i := 0; i < 20; i++ {
+		wg.Add(3)
+		go func() { defer wg.Done(); _, err := mgr.GetState(ctx); errs <- err }()
+		go func() { defer wg.Done(); errs <- mgr.TurnOn(ctx, model.Left) }()
+		go func(level int) { defer wg.Done(); errs <- mgr.SetTemperature(ctx, model.Right, level) }(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent call: %v", err)
+		}
+	}
+}
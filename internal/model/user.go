@@ -17,6 +17,7 @@ type UserState struct {
 	HRV               float64    `json:"hrv"`
 	BreathRate        float64    `json:"breath_rate"`
 	LastHeartRateTime time.Time  `json:"last_heart_rate_time"`
+	SleepScore        float64    `json:"sleep_score"` // most recent completed session
 }
 
 // IsPresent returns true if the user appears to be in bed.
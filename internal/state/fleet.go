@@ -0,0 +1,47 @@
+package state
+
+import "strings"
+
+// Fleet is the set of pods a long-running command manages, one Manager each.
+// The first pod is the default for callers that don't name one.
+type Fleet struct {
+	managers []*Manager
+}
+
+// NewFleet groups managers; their order is preserved.
+func NewFleet(managers ...*Manager) *Fleet {
+	return &Fleet{managers: managers}
+}
+
+// Managers returns every pod's manager in order.
+func (f *Fleet) Managers() []*Manager {
+	return f.managers
+}
+
+// Default returns the first pod's manager, or nil for an empty fleet.
+func (f *Fleet) Default() *Manager {
+	if len(f.managers) == 0 {
+		return nil
+	}
+	return f.managers[0]
+}
+
+// Get finds a pod by device ID or case-insensitive name. An empty key
+// returns the default pod.
+func (f *Fleet) Get(key string) (*Manager, bool) {
+	if key == "" {
+		m := f.Default()
+		return m, m != nil
+	}
+	for _, m := range f.managers {
+		if m.deviceID == key {
+			return m, true
+		}
+	}
+	for _, m := range f.managers {
+		if name := m.Name(); name != "" && strings.EqualFold(name, key) {
+			return m, true
+		}
+	}
+	return nil, false
+}
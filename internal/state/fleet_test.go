@@ -0,0 +1,55 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/steipete/eightctl/internal/client"
+)
+
+func TestFleet_Get(t *testing.T) {
+	c := client.New("email", "pass", "", "", "")
+	bedroom := NewManager(c, "dev-1", WithName("Bedroom"))
+	guest := NewManager(c, "dev-2", WithName("Guest"))
+	f := NewFleet(bedroom, guest)
+
+	tests := []struct {
+		key  string
+		want *Manager
+	}{
+		{"", bedroom},
+		{"dev-2", guest},
+		{"guest", guest},
+		{"BEDROOM", bedroom},
+		{"dev-3", nil},
+	}
+	for _, tt := range tests {
+		got, ok := f.Get(tt.key)
+		if got != tt.want || ok != (tt.want != nil) {
+			t.Errorf("Get(%q) = %v, %v; want %v", tt.key, got, ok, tt.want)
+		}
+	}
+}
+
+func TestFleet_Empty(t *testing.T) {
+	f := NewFleet()
+	if f.Default() != nil {
+		t.Error("expected no default pod")
+	}
+	if _, ok := f.Get(""); ok {
+		t.Error("expected Get to fail on an empty fleet")
+	}
+}
+
+func TestFleet_GetAfterRename(t *testing.T) {
+	c := client.New("email", "pass", "", "", "")
+	m := NewManager(c, "dev-1", WithName("Bedroom"))
+	f := NewFleet(m)
+
+	m.SetName("Primary")
+	if _, ok := f.Get("bedroom"); ok {
+		t.Error("old name still matches after rename")
+	}
+	if got, ok := f.Get("primary"); !ok || got != m {
+		t.Errorf("Get(primary) = %v, %v; want the renamed pod", got, ok)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/charmbracelet/log"
+
 	"github.com/steipete/eightctl/internal/client"
 	"github.com/steipete/eightctl/internal/model"
 )
@@ -20,9 +22,11 @@ var _ StateProvider = (*Manager)(nil)
 type Manager struct {
 	client   *client.Client
 	deviceID string
-	cacheTTL time.Duration
+	vitals   bool
 
 	mu          sync.RWMutex
+	name        string
+	cacheTTL    time.Duration
 	cachedState *model.DeviceState
 	cacheExpiry time.Time
 	observers   []Observer
@@ -38,7 +42,24 @@ func WithCacheTTL(ttl time.Duration) Option {
 	}
 }
 
-// NewManager creates a new state manager.
+// WithName sets a friendly name for the pod, e.g. from the devices config section.
+func WithName(name string) Option {
+	return func(m *Manager) {
+		m.name = name
+	}
+}
+
+// WithVitals makes each refresh also read the latest sleep session for both
+// sides, filling bed temperature, heart rate, HRV, breath rate, sleep stage,
+// presence, and the last sleep score. It costs one extra API call per side.
+func WithVitals() Option {
+	return func(m *Manager) {
+		m.vitals = true
+	}
+}
+
+// NewManager creates a new state manager for one pod. An empty deviceID
+// tracks the client's selected device.
 func NewManager(c *client.Client, deviceID string, opts ...Option) *Manager {
 	m := &Manager{
 		client:   c,
@@ -51,6 +72,33 @@ func NewManager(c *client.Client, deviceID string, opts ...Option) *Manager {
 	return m
 }
 
+// DeviceID returns the pod this manager tracks.
+func (m *Manager) DeviceID() string {
+	return m.deviceID
+}
+
+// Name returns the pod's friendly name, or "" when none was configured.
+func (m *Manager) Name() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.name
+}
+
+// SetName renames the pod, e.g. after the devices config section changes.
+func (m *Manager) SetName(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.name = name
+}
+
+// SetCacheTTL changes the cache TTL. The state already cached keeps its
+// expiry.
+func (m *Manager) SetCacheTTL(ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cacheTTL = ttl
+}
+
 // AddObserver registers an observer for state changes.
 func (m *Manager) AddObserver(o Observer) {
 	m.mu.Lock()
@@ -86,7 +134,7 @@ func (m *Manager) GetState(ctx context.Context) (*model.DeviceState, error) {
 // refreshState fetches fresh state from the API and updates cache.
 func (m *Manager) refreshState(ctx context.Context) (*model.DeviceState, error) {
 	// Fetch device info with user assignments
-	device, err := m.client.Device().GetWithUsers(ctx)
+	device, err := m.client.DeviceByID(m.deviceID).GetWithUsers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -138,12 +186,55 @@ func (m *Manager) fetchUserState(ctx context.Context, userID string, side model.
 		return nil, err
 	}
 
-	return &model.UserState{
+	u := &model.UserState{
 		ID:          userID,
 		Side:        side,
 		TargetLevel: temp.CurrentLevel,
 		State:       model.ParsePowerState(temp.CurrentState.Type),
-	}, nil
+	}
+	if m.vitals {
+		if err := m.fetchVitals(ctx, u); err != nil {
+			log.Debug("sleep session unavailable", "user", userID, "error", err)
+		}
+	}
+	return u, nil
+}
+
+// fetchVitals fills u's sensor readings from the newest sleep session and
+// its sleep score from the newest completed one.
+func (m *Manager) fetchVitals(ctx context.Context, u *model.UserState) error {
+	res, err := m.client.UserIntervals(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+	if len(res.Intervals) == 0 {
+		return nil
+	}
+	current := res.Intervals[0]
+	ts := current.Timeseries
+	if p, ok := client.Latest(ts.HeartRate); ok {
+		u.HeartRate = p.Value
+		u.LastHeartRateTime = p.Time
+	}
+	if p, ok := client.Latest(ts.HRV); ok {
+		u.HRV = p.Value
+	}
+	if p, ok := client.Latest(ts.RespiratoryRate); ok {
+		u.BreathRate = p.Value
+	}
+	if p, ok := client.Latest(ts.TempBedC); ok {
+		u.BedTemperature = p.Value
+	}
+	if current.Incomplete && len(current.Stages) > 0 {
+		u.SleepStage = model.ParseSleepStage(current.Stages[len(current.Stages)-1].Stage)
+	}
+	for _, iv := range res.Intervals {
+		if !iv.Incomplete {
+			u.SleepScore = iv.Score
+			break
+		}
+	}
+	return nil
 }
 
 // notifyStateChange notifies observers of state and presence changes.
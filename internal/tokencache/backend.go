@@ -0,0 +1,352 @@
+package tokencache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/99designs/keyring"
+	"github.com/charmbracelet/log"
+)
+
+// legacyFilePassword encrypted the file backend before the passphrase was
+// configurable. Keyrings still using it are read with it until migrated;
+// new entries are only written with it under keyring_backend: file-insecure.
+const legacyFilePassword = serviceName + "-fallback"
+
+// insecureFileBackend is the keyring_backend value that opts in to writing
+// the file backend with the built-in passphrase.
+const insecureFileBackend = "file-insecure"
+
+// unlockPrompt asks for the file backend's passphrase.
+const unlockPrompt = "Enter passphrase to unlock the eightctl keyring"
+
+// ErrBuiltInPassphrase is returned when a token would be written to the
+// file backend under the built-in passphrase without the opt-in.
+var ErrBuiltInPassphrase = errors.New("file keyring has no passphrase; set EIGHTCTL_KEYRING_PASSWORD, or keyring_backend: " + insecureFileBackend + " to accept the built-in one")
+
+// Where the file backend's passphrase came from, as reported by Describe.
+const (
+	PassphraseConfigured = "configured" // Options.FilePassword
+	PassphrasePrompt     = "prompt"
+	PassphraseBuiltIn    = "built-in" // legacyFilePassword
+)
+
+// allowedBackends are tried in order when no backend is forced.
+var allowedBackends = []keyring.BackendType{
+	keyring.KeychainBackend,
+	keyring.SecretServiceBackend,
+	keyring.WinCredBackend,
+	keyring.FileBackend, // Fallback for unsigned dev builds
+}
+
+// Options choose the keyring backend and the file backend's passphrase.
+type Options struct {
+	// Backend forces one backend, e.g. "keychain", "secret-service",
+	// "wincred", or "file". Empty or "auto" tries the OS keyrings, then file.
+	// "file-insecure" is file with AllowBuiltInPassphrase set.
+	Backend string
+	// FilePassword encrypts the file backend.
+	FilePassword string
+	// AllowBuiltInPassphrase lets the file backend write entries with the
+	// built-in passphrase when no other one is available. Without it such
+	// writes fail with ErrBuiltInPassphrase; reading old entries still works.
+	AllowBuiltInPassphrase bool
+	// Prompt asks for the file passphrase when FilePassword is empty. Leave
+	// it nil when there is no terminal.
+	Prompt func(prompt string) (string, error)
+	// FileDir overrides ~/.config/eightctl/keyring.
+	FileDir string
+}
+
+var (
+	optsMu  sync.Mutex
+	options Options
+	// resolved caches the file passphrase so a process prompts at most once.
+	resolved struct{ password, source string }
+)
+
+// Configure sets how the keyring is opened. Call it before the first Load
+// or Save.
+func Configure(o Options) error {
+	switch o.Backend {
+	case "auto":
+		o.Backend = ""
+	case insecureFileBackend:
+		o.Backend = string(keyring.FileBackend)
+		o.AllowBuiltInPassphrase = true
+	}
+	if o.Backend != "" && !backendAvailable(o.Backend) {
+		names := make([]string, 0, len(keyring.AvailableBackends()))
+		for _, b := range keyring.AvailableBackends() {
+			names = append(names, string(b))
+		}
+		return fmt.Errorf("unknown keyring backend %q (available: auto, %s, %s)", o.Backend, insecureFileBackend, strings.Join(names, ", "))
+	}
+	optsMu.Lock()
+	defer optsMu.Unlock()
+	options = o
+	resolved.password, resolved.source = "", ""
+	return nil
+}
+
+func backendAvailable(name string) bool {
+	for _, b := range keyring.AvailableBackends() {
+		if string(b) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func currentOptions() Options {
+	optsMu.Lock()
+	defer optsMu.Unlock()
+	return options
+}
+
+func keyringConfig(o Options, b keyring.BackendType, password keyring.PromptFunc) keyring.Config {
+	dir := o.FileDir
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".config", "eightctl", "keyring")
+	}
+	return keyring.Config{
+		ServiceName:      serviceName,
+		AllowedBackends:  []keyring.BackendType{b},
+		FileDir:          dir,
+		FilePasswordFunc: password,
+	}
+}
+
+func defaultOpenKeyring() (keyring.Keyring, error) {
+	o := currentOptions()
+	backends := allowedBackends
+	if o.Backend != "" {
+		backends = []keyring.BackendType{keyring.BackendType(o.Backend)}
+	}
+	// Open one backend at a time so Describe can report which one won.
+	var lastErr error = keyring.ErrNoAvailImpl
+	for _, b := range backends {
+		ring, err := keyring.Open(keyringConfig(o, b, filePassword))
+		if err == nil {
+			return backendKeyring{ring, b}, nil
+		}
+		lastErr = err
+	}
+	if o.Backend != "" {
+		return nil, fmt.Errorf("open %s keyring: %w", o.Backend, lastErr)
+	}
+	return nil, lastErr
+}
+
+// backendKeyring remembers which backend a keyring was opened with.
+type backendKeyring struct {
+	keyring.Keyring
+	backend keyring.BackendType
+}
+
+// Set refuses to write the file backend with the built-in passphrase unless
+// AllowBuiltInPassphrase is set.
+func (k backendKeyring) Set(item keyring.Item) error {
+	if k.backend == keyring.FileBackend {
+		if _, err := filePassword(unlockPrompt); err != nil {
+			return err
+		}
+		optsMu.Lock()
+		refuse := resolved.source == PassphraseBuiltIn && !options.AllowBuiltInPassphrase
+		optsMu.Unlock()
+		if refuse {
+			return ErrBuiltInPassphrase
+		}
+	}
+	return k.Keyring.Set(item)
+}
+
+// filePassword unlocks the file backend. The passphrase is resolved once
+// per Configure: the configured one, else the built-in one while existing
+// entries still use it, else a prompt, else the built-in one if
+// AllowBuiltInPassphrase is set.
+func filePassword(prompt string) (string, error) {
+	optsMu.Lock()
+	defer optsMu.Unlock()
+	if resolved.password != "" {
+		return resolved.password, nil
+	}
+	o := options
+	switch {
+	case o.FilePassword != "":
+		resolved.password, resolved.source = o.FilePassword, PassphraseConfigured
+	case usesLegacyPassword(o):
+		resolved.password, resolved.source = legacyFilePassword, PassphraseBuiltIn
+	case o.Prompt != nil:
+		pw, err := o.Prompt(prompt)
+		if err != nil {
+			return "", fmt.Errorf("read keyring passphrase: %w", err)
+		}
+		if pw == "" {
+			return "", fmt.Errorf("keyring passphrase is required")
+		}
+		resolved.password, resolved.source = pw, PassphrasePrompt
+	case o.AllowBuiltInPassphrase:
+		resolved.password, resolved.source = legacyFilePassword, PassphraseBuiltIn
+	default:
+		return "", ErrBuiltInPassphrase
+	}
+	if resolved.source == PassphraseBuiltIn && !o.AllowBuiltInPassphrase {
+		log.Warn("file keyring uses the built-in passphrase; new tokens are not cached until EIGHTCTL_KEYRING_PASSWORD is set and 'eightctl keyring migrate' is run")
+	}
+	return resolved.password, nil
+}
+
+// usesLegacyPassword reports whether the file keyring holds an entry the
+// built-in passphrase decrypts.
+func usesLegacyPassword(o Options) bool {
+	ring, err := keyring.Open(keyringConfig(o, keyring.FileBackend, keyring.FixedStringPrompt(legacyFilePassword)))
+	if err != nil {
+		return false
+	}
+	keys, err := ring.Keys()
+	if err != nil {
+		return false
+	}
+	for _, k := range keys {
+		if _, err := ring.Get(k); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Info describes the keyring tokens are stored in.
+type Info struct {
+	// Backend is "keychain", "secret-service", "wincred", "file", etc., or
+	// "custom" for a keyring installed with SetOpenKeyringForTest.
+	Backend string
+	// Passphrase is where the file backend's passphrase came from; empty
+	// for other backends.
+	Passphrase string
+}
+
+// Describe opens the keyring and reports its backend. For the file backend
+// it resolves the passphrase, prompting if needed.
+func Describe() (Info, error) {
+	ring, err := openKeyring()
+	if err != nil {
+		return Info{}, err
+	}
+	b, ok := ring.(backendKeyring)
+	if !ok {
+		return Info{Backend: "custom"}, nil
+	}
+	info := Info{Backend: string(b.backend)}
+	if b.backend == keyring.FileBackend {
+		if _, err := filePassword(unlockPrompt); err != nil {
+			return info, err
+		}
+		optsMu.Lock()
+		info.Passphrase = resolved.source
+		optsMu.Unlock()
+	}
+	return info, nil
+}
+
+// Migrate copies every entry from the keyring described by from into the
+// configured one: to re-encrypt a file keyring under a new passphrase, or
+// to move tokens between backends. from.Backend defaults to "file" and
+// from.FilePassword to the built-in passphrase. Entries moved to another
+// backend are removed from the old one. Entries the old passphrase cannot
+// decrypt are left alone and counted as skipped.
+func Migrate(from Options) (moved, skipped int, err error) {
+	if from.Backend == "" {
+		from.Backend = string(keyring.FileBackend)
+	}
+	if from.FilePassword == "" {
+		from.FilePassword = legacyFilePassword
+	}
+	if from.FileDir == "" {
+		from.FileDir = currentOptions().FileDir
+	}
+	src, err := keyring.Open(keyringConfig(from, keyring.BackendType(from.Backend), keyring.FixedStringPrompt(from.FilePassword)))
+	if err != nil {
+		return 0, 0, fmt.Errorf("open %s keyring: %w", from.Backend, err)
+	}
+	keys, err := src.Keys()
+	if err != nil {
+		return 0, 0, err
+	}
+	var items []keyring.Item
+	for _, k := range keys {
+		item, err := src.Get(k)
+		if err != nil {
+			log.Debug("keyring migrate: skipping unreadable entry", "key", k, "error", err)
+			skipped++
+			continue
+		}
+		items = append(items, item)
+	}
+
+	ring, err := openKeyring()
+	if err != nil {
+		return 0, skipped, err
+	}
+	dst := ring
+	dstBackend := "custom"
+	if b, ok := ring.(backendKeyring); ok {
+		dstBackend = string(b.backend)
+	}
+	inPlace := dstBackend == from.Backend
+	if inPlace {
+		if dstBackend != string(keyring.FileBackend) {
+			return 0, skipped, fmt.Errorf("tokens are already in the %s keyring", dstBackend)
+		}
+		pw, err := newFilePassword()
+		if err != nil {
+			return 0, skipped, err
+		}
+		if pw == from.FilePassword {
+			return 0, skipped, fmt.Errorf("the file keyring already uses this passphrase; set EIGHTCTL_KEYRING_PASSWORD or keyring_backend first")
+		}
+	}
+
+	for _, item := range items {
+		if err := dst.Set(item); err != nil {
+			return moved, skipped, fmt.Errorf("write %s: %w", item.Key, err)
+		}
+		if !inPlace {
+			if err := src.Remove(item.Key); err != nil {
+				log.Debug("keyring migrate: failed to remove old entry", "key", item.Key, "error", err)
+			}
+		}
+		moved++
+	}
+	return moved, skipped, nil
+}
+
+// newFilePassword resolves the passphrase a re-encrypted file keyring will
+// use. Unlike filePassword it never settles for the built-in passphrase
+// just because existing entries use it, so a terminal user is prompted.
+func newFilePassword() (string, error) {
+	optsMu.Lock()
+	defer optsMu.Unlock()
+	o := options
+	switch {
+	case o.FilePassword != "":
+		resolved.password, resolved.source = o.FilePassword, PassphraseConfigured
+	case o.Prompt != nil:
+		pw, err := o.Prompt("New passphrase for the eightctl keyring")
+		if err != nil {
+			return "", fmt.Errorf("read keyring passphrase: %w", err)
+		}
+		if pw == "" {
+			return "", fmt.Errorf("keyring passphrase is required")
+		}
+		resolved.password, resolved.source = pw, PassphrasePrompt
+	default:
+		return legacyFilePassword, nil
+	}
+	return resolved.password, nil
+}
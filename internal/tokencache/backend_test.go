@@ -0,0 +1,162 @@
+package tokencache
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/99designs/keyring"
+)
+
+// withFileKeyring points the real opener at a temp file keyring.
+func withFileKeyring(t *testing.T, o Options) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "keyring")
+	o.Backend = "file"
+	o.FileDir = dir
+	if err := Configure(o); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() { _ = Configure(Options{}) })
+	return dir
+}
+
+func reconfigure(t *testing.T, dir string, o Options) {
+	t.Helper()
+	o.Backend = "file"
+	o.FileDir = dir
+	if err := Configure(o); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+}
+
+func decryptsWith(t *testing.T, dir, password string) bool {
+	t.Helper()
+	ring, err := keyring.Open(keyringConfig(Options{FileDir: dir}, keyring.FileBackend, keyring.FixedStringPrompt(password)))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	keys, _ := ring.Keys()
+	if len(keys) == 0 {
+		t.Fatalf("keyring is empty")
+	}
+	_, err = ring.Get(keys[0])
+	return err == nil
+}
+
+var testID = Identity{BaseURL: "https://api.example.com", ClientID: "client-1", Email: "me@example.com"}
+
+func TestConfiguredPassphraseEncryptsFileKeyring(t *testing.T) {
+	dir := withFileKeyring(t, Options{FilePassword: "s3cret"})
+	if err := Save(testID, "tok", time.Now().Add(time.Hour), "u1"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if decryptsWith(t, dir, legacyFilePassword) {
+		t.Fatal("entry readable with the built-in passphrase")
+	}
+	if !decryptsWith(t, dir, "s3cret") {
+		t.Fatal("entry not readable with the configured passphrase")
+	}
+	info, err := Describe()
+	if err != nil || info.Backend != "file" || info.Passphrase != PassphraseConfigured {
+		t.Fatalf("Describe = %+v, %v", info, err)
+	}
+}
+
+func TestPromptOnlyWithoutLegacyEntries(t *testing.T) {
+	prompts := 0
+	prompt := func(string) (string, error) { prompts++; return "typed", nil }
+
+	dir := withFileKeyring(t, Options{Prompt: prompt})
+	if err := Save(testID, "tok", time.Now().Add(time.Hour), "u1"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := Load(testID, ""); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if prompts != 1 {
+		t.Fatalf("prompted %d times, want once per process", prompts)
+	}
+	if !decryptsWith(t, dir, "typed") {
+		t.Fatal("entry not encrypted with the prompted passphrase")
+	}
+
+	// A keyring written by an older version keeps working without a prompt.
+	legacyDir := withFileKeyring(t, Options{AllowBuiltInPassphrase: true})
+	if err := Save(testID, "old", time.Now().Add(time.Hour), "u1"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	reconfigure(t, legacyDir, Options{Prompt: prompt})
+	if got, err := Load(testID, ""); err != nil || got.Token != "old" {
+		t.Fatalf("Load legacy = %+v, %v", got, err)
+	}
+	if prompts != 1 {
+		t.Fatalf("prompted for a legacy keyring")
+	}
+}
+
+func TestMigrateReencryptsLegacyEntries(t *testing.T) {
+	dir := withFileKeyring(t, Options{AllowBuiltInPassphrase: true})
+	other := testID
+	other.Email = "partner@example.com"
+	for _, id := range []Identity{testID, other} {
+		if err := Save(id, "tok-"+id.Email, time.Now().Add(time.Hour), ""); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	// Without a new passphrase there is nothing to migrate to.
+	if _, _, err := Migrate(Options{}); err == nil {
+		t.Fatal("expected error migrating onto the same passphrase")
+	}
+
+	reconfigure(t, dir, Options{FilePassword: "new-pass"})
+	moved, skipped, err := Migrate(Options{})
+	if err != nil || moved != 2 || skipped != 0 {
+		t.Fatalf("Migrate = %d, %d, %v", moved, skipped, err)
+	}
+	if decryptsWith(t, dir, legacyFilePassword) || !decryptsWith(t, dir, "new-pass") {
+		t.Fatal("entries not re-encrypted")
+	}
+	if got, err := Load(other, ""); err != nil || got.Token != "tok-partner@example.com" {
+		t.Fatalf("Load after migrate = %+v, %v", got, err)
+	}
+}
+
+func TestBuiltInPassphraseNeedsOptIn(t *testing.T) {
+	dir := withFileKeyring(t, Options{})
+	if err := Save(testID, "tok", time.Now().Add(time.Hour), "u1"); !errors.Is(err, ErrBuiltInPassphrase) {
+		t.Fatalf("Save without a passphrase = %v, want ErrBuiltInPassphrase", err)
+	}
+
+	reconfigure(t, dir, Options{AllowBuiltInPassphrase: true})
+	if err := Save(testID, "old", time.Now().Add(time.Hour), "u1"); err != nil {
+		t.Fatalf("Save with opt-in: %v", err)
+	}
+
+	// Without the opt-in, old entries stay readable but nothing new is written.
+	reconfigure(t, dir, Options{})
+	if got, err := Load(testID, ""); err != nil || got.Token != "old" {
+		t.Fatalf("Load legacy = %+v, %v", got, err)
+	}
+	if err := Save(testID, "new", time.Now().Add(time.Hour), "u1"); !errors.Is(err, ErrBuiltInPassphrase) {
+		t.Fatalf("Save over legacy entries = %v, want ErrBuiltInPassphrase", err)
+	}
+}
+
+func TestConfigureFileInsecure(t *testing.T) {
+	t.Cleanup(func() { _ = Configure(Options{}) })
+	if err := Configure(Options{Backend: "file-insecure"}); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	if o := currentOptions(); o.Backend != "file" || !o.AllowBuiltInPassphrase {
+		t.Fatalf("file-insecure configured as %+v", o)
+	}
+}
+
+func TestConfigureRejectsUnknownBackend(t *testing.T) {
+	if err := Configure(Options{Backend: "floppy"}); err == nil {
+		t.Fatal("expected error for unknown backend")
+	}
+}
@@ -0,0 +1,61 @@
+package tokencache
+
+import (
+	"os"
+	"strings"
+
+	"github.com/99designs/keyring"
+	"github.com/charmbracelet/log"
+)
+
+// passwordKey prefixes stored account passwords. They sit next to the
+// tokens, so keyring migrate moves them too, but List never reports them.
+const passwordKey = "password:"
+
+// SavePassword stores the account password for email, so sign-ins need no
+// plaintext password in the config.
+func SavePassword(email, password string) error {
+	ring, err := openKeyring()
+	if err != nil {
+		return err
+	}
+	return ring.Set(keyring.Item{
+		Key:   passwordKey + normalizeEmail(email),
+		Label: serviceName + " password",
+		Data:  []byte(password),
+	})
+}
+
+// LoadPassword returns the password stored for email, or
+// keyring.ErrKeyNotFound.
+func LoadPassword(email string) (string, error) {
+	ring, err := openKeyring()
+	if err != nil {
+		log.Debug("keyring open failed (password)", "error", err)
+		return "", err
+	}
+	item, err := ring.Get(passwordKey + normalizeEmail(email))
+	if err != nil {
+		return "", err
+	}
+	return string(item.Data), nil
+}
+
+// ClearPassword removes the password stored for email, if any.
+func ClearPassword(email string) error {
+	ring, err := openKeyring()
+	if err != nil {
+		return err
+	}
+	if err := ring.Remove(passwordKey + normalizeEmail(email)); err != nil {
+		if err == keyring.ErrKeyNotFound || os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -3,7 +3,7 @@ package tokencache
 import (
 	"encoding/json"
 	"os"
-	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,18 +17,21 @@ const (
 )
 
 type CachedToken struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
-	UserID    string    `json:"user_id,omitempty"`
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	UserID       string    `json:"user_id,omitempty"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
 }
 
 // Identity describes the authentication context a token belongs to.
 // Tokens are namespaced by base URL, client ID, and email so switching
 // between accounts or environments doesn't reuse the wrong credentials.
+// A config profile gets its own namespace on top of that.
 type Identity struct {
 	BaseURL  string
 	ClientID string
 	Email    string
+	Profile  string
 }
 
 var openKeyring = defaultOpenKeyring
@@ -41,37 +44,18 @@ func SetOpenKeyringForTest(fn func() (keyring.Keyring, error)) (restore func())
 	return func() { openKeyring = prev }
 }
 
-func defaultOpenKeyring() (keyring.Keyring, error) {
-	home, _ := os.UserHomeDir()
-	cfg := keyring.Config{
-		ServiceName: serviceName,
-		AllowedBackends: []keyring.BackendType{
-			keyring.KeychainBackend,
-			keyring.SecretServiceBackend,
-			keyring.WinCredBackend,
-			keyring.FileBackend, // Fallback for unsigned dev builds
-		},
-		FileDir:          filepath.Join(home, ".config", "eightctl", "keyring"),
-		FilePasswordFunc: filePassword,
-	}
-	return keyring.Open(cfg)
-}
-
-func filePassword(_ string) (string, error) {
-	return serviceName + "-fallback", nil
+func Save(id Identity, token string, expiresAt time.Time, userID string) error {
+	return SaveToken(id, CachedToken{Token: token, ExpiresAt: expiresAt, UserID: userID})
 }
 
-func Save(id Identity, token string, expiresAt time.Time, userID string) error {
+// SaveToken stores t, including its refresh token if any.
+func SaveToken(id Identity, t CachedToken) error {
 	ring, err := openKeyring()
 	if err != nil {
 		log.Debug("keyring open failed (save)", "error", err)
 		return err
 	}
-	data, err := json.Marshal(CachedToken{
-		Token:     token,
-		ExpiresAt: expiresAt,
-		UserID:    userID,
-	})
+	data, err := json.Marshal(t)
 	if err != nil {
 		return err
 	}
@@ -138,9 +122,18 @@ func Clear(id Identity) error {
 }
 
 func cacheKey(id Identity) string {
+	return keyPrefix(id) + normalizeEmail(id.Email)
+}
+
+// keyPrefix is the cache key up to the email. Profile tokens live under
+// "oauth-token@<profile>:" so they never match a lookup outside the profile.
+func keyPrefix(id Identity) string {
 	base := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(id.BaseURL)), "/")
-	email := strings.ToLower(strings.TrimSpace(id.Email))
-	return tokenKey + ":" + base + "|" + id.ClientID + "|" + email
+	ns := tokenKey
+	if id.Profile != "" {
+		ns += "@" + strings.ToLower(id.Profile)
+	}
+	return ns + ":" + base + "|" + id.ClientID + "|"
 }
 
 // findSingleForClient finds a single cached key for the given base/client when email is unknown.
@@ -150,7 +143,7 @@ func findSingleForClient(ring keyring.Keyring, id Identity) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	prefix := tokenKey + ":" + strings.TrimSuffix(strings.ToLower(strings.TrimSpace(id.BaseURL)), "/") + "|" + id.ClientID + "|"
+	prefix := keyPrefix(id)
 	matches := []string{}
 	for _, k := range keys {
 		if strings.HasPrefix(k, prefix) {
@@ -162,3 +155,94 @@ func findSingleForClient(ring keyring.Keyring, id Identity) (string, error) {
 	}
 	return "", keyring.ErrKeyNotFound
 }
+
+// Entry is one cached token together with the identity it belongs to.
+type Entry struct {
+	Identity
+	CachedToken
+	Key string // keyring key
+}
+
+// parseKey is the inverse of cacheKey. The base URL and email come back
+// normalized the way cacheKey stored them.
+func parseKey(key string) (Identity, bool) {
+	rest, ok := strings.CutPrefix(key, tokenKey)
+	if !ok {
+		return Identity{}, false
+	}
+	var id Identity
+	if p, r, found := strings.Cut(rest, ":"); found && strings.HasPrefix(p, "@") {
+		id.Profile, rest = p[1:], r
+	} else if found && p == "" {
+		rest = r
+	} else {
+		return Identity{}, false
+	}
+	last := strings.LastIndex(rest, "|")
+	if last < 0 {
+		return Identity{}, false
+	}
+	mid := strings.LastIndex(rest[:last], "|")
+	if mid < 0 {
+		return Identity{}, false
+	}
+	id.BaseURL, id.ClientID, id.Email = rest[:mid], rest[mid+1:last], rest[last+1:]
+	return id, true
+}
+
+// List returns every cached token, expired ones included, sorted by key.
+// Entries that cannot be read are skipped.
+func List() ([]Entry, error) {
+	ring, err := openKeyring()
+	if err != nil {
+		return nil, err
+	}
+	keys, err := ring.Keys()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	var entries []Entry
+	for _, k := range keys {
+		id, ok := parseKey(k)
+		if !ok {
+			continue
+		}
+		item, err := ring.Get(k)
+		if err != nil {
+			log.Debug("keyring get failed (list)", "key", k, "error", err)
+			continue
+		}
+		var t CachedToken
+		if err := json.Unmarshal(item.Data, &t); err != nil {
+			continue
+		}
+		entries = append(entries, Entry{Identity: id, CachedToken: t, Key: k})
+	}
+	return entries, nil
+}
+
+// ClearWhere removes every cached token whose identity matches and reports
+// how many were removed.
+func ClearWhere(match func(Identity) bool) (int, error) {
+	ring, err := openKeyring()
+	if err != nil {
+		return 0, err
+	}
+	keys, err := ring.Keys()
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, k := range keys {
+		id, ok := parseKey(k)
+		if !ok || !match(id) {
+			continue
+		}
+		if err := ring.Remove(k); err != nil && err != keyring.ErrKeyNotFound && !os.IsNotExist(err) {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
@@ -142,6 +142,79 @@ func TestClearOnlyRemovesMatchingIdentity(t *testing.T) {
 	}
 }
 
+func TestProfilesKeepSeparateTokens(t *testing.T) {
+	withTestKeyring(t)
+	plain := Identity{BaseURL: "https://api.example.com", ClientID: "client-1", Email: "a@example.com"}
+	work := plain
+	work.Profile = "Work"
+
+	if err := Save(plain, "token-plain", time.Now().Add(time.Hour), "user-a"); err != nil {
+		t.Fatalf("Save plain: %v", err)
+	}
+	if _, err := Load(work, ""); err != keyring.ErrKeyNotFound {
+		t.Fatalf("profile should not see the unprofiled token, got %v", err)
+	}
+	if err := Save(work, "token-work", time.Now().Add(time.Hour), "user-a"); err != nil {
+		t.Fatalf("Save work: %v", err)
+	}
+	if got, _ := Load(Identity{BaseURL: plain.BaseURL, ClientID: plain.ClientID, Profile: "work"}, ""); got == nil || got.Token != "token-work" {
+		t.Errorf("Load work without email = %+v, want token-work", got)
+	}
+	if got, _ := Load(plain, ""); got == nil || got.Token != "token-plain" {
+		t.Errorf("Load plain = %+v, want token-plain", got)
+	}
+}
+
+func TestParseKeyInvertsCacheKey(t *testing.T) {
+	for _, id := range []Identity{
+		{BaseURL: "https://api.example.com", ClientID: "client-1", Email: "a@example.com"},
+		{BaseURL: "https://api.example.com", ClientID: "client-1"},
+		{BaseURL: "http://127.0.0.1:8080", ClientID: "c", Email: "b@example.com", Profile: "work"},
+	} {
+		got, ok := parseKey(cacheKey(id))
+		if !ok || got != id {
+			t.Errorf("parseKey(cacheKey(%+v)) = %+v, %v", id, got, ok)
+		}
+	}
+	if _, ok := parseKey("something-else"); ok {
+		t.Error("parseKey accepted a foreign key")
+	}
+}
+
+func TestListAndClearWhere(t *testing.T) {
+	withTestKeyring(t)
+	a := Identity{BaseURL: "https://api.example.com", ClientID: "client-1", Email: "a@example.com"}
+	b := Identity{BaseURL: "https://api.example.com", ClientID: "client-1", Email: "b@example.com", Profile: "work"}
+	if err := Save(a, "token-a", time.Now().Add(time.Hour), "user-a"); err != nil {
+		t.Fatalf("Save a: %v", err)
+	}
+	if err := Save(b, "token-b", time.Now().Add(-time.Minute), "user-b"); err != nil {
+		t.Fatalf("Save b: %v", err)
+	}
+
+	entries, err := List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("List returned %d entries, want 2 (expired included)", len(entries))
+	}
+	if entries[0].Identity != a || entries[0].UserID != "user-a" {
+		t.Errorf("entries[0] = %+v, want identity %+v", entries[0], a)
+	}
+	if entries[1].Identity != b || entries[1].Token != "token-b" {
+		t.Errorf("entries[1] = %+v, want identity %+v", entries[1], b)
+	}
+
+	n, err := ClearWhere(func(id Identity) bool { return id.Email == "b@example.com" })
+	if err != nil || n != 1 {
+		t.Fatalf("ClearWhere = %d, %v; want 1", n, err)
+	}
+	if entries, _ := List(); len(entries) != 1 || entries[0].Identity != a {
+		t.Fatalf("after ClearWhere: %+v", entries)
+	}
+}
+
 func TestCacheKeyNormalization(t *testing.T) {
 	k1 := cacheKey(Identity{BaseURL: "https://API.example.com/", ClientID: "id", Email: "User@Example.com "})
 	k2 := cacheKey(Identity{BaseURL: "https://api.example.com", ClientID: "id", Email: "user@example.com"})
@@ -199,3 +272,25 @@ func TestFilePasswordFunc(t *testing.T) {
 		t.Fatalf("password = %q, want %q", pw, serviceName+"-fallback")
 	}
 }
+
+func TestPasswordRoundTrip(t *testing.T) {
+	withTestKeyring(t)
+	if err := SavePassword("User@Example.com ", "hunter2"); err != nil {
+		t.Fatalf("SavePassword: %v", err)
+	}
+	if pw, err := LoadPassword("user@example.com"); err != nil || pw != "hunter2" {
+		t.Fatalf("LoadPassword = %q, %v", pw, err)
+	}
+	if entries, _ := List(); len(entries) != 0 {
+		t.Errorf("List should not report passwords, got %+v", entries)
+	}
+	if err := ClearPassword("user@example.com"); err != nil {
+		t.Fatalf("ClearPassword: %v", err)
+	}
+	if _, err := LoadPassword("user@example.com"); err != keyring.ErrKeyNotFound {
+		t.Fatalf("expected ErrKeyNotFound after clear, got %v", err)
+	}
+	if err := ClearPassword("user@example.com"); err != nil {
+		t.Fatalf("ClearPassword on missing entry: %v", err)
+	}
+}